@@ -0,0 +1,125 @@
+package MyLog
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// adminState is the overview returned by the admin handler
+type adminState struct {
+	Level     string `json:"level"`
+	Verbosity int    `json:"verbosity"`
+	Debug     bool   `json:"debug"`
+	Color     bool   `json:"color"`
+	Format    string `json:"format"`
+	Buffer    bool   `json:"buffer"`
+}
+
+// AdminHandler returns a handler for runtime control of l, to be
+// mounted with http.StripPrefix on an admin port:
+//
+//	GET  /           current settings as JSON
+//	GET  /level      current level
+//	POST /level      set the level from the "level" form value
+//	POST /debug      set LgDebug from the "on" form value, or toggle it
+//	POST /color      set colors from the "on" form value, or toggle them
+//	GET  /buffer     the buffer as text, as JSON with ?format=json
+//	GET  /counters   Stats as JSON
+//
+// The handler has no authentication of its own.
+func (l *Log) AdminHandler() http.Handler {
+	if !l.ready() {
+		return http.NotFoundHandler()
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", l.adminState)
+	mux.HandleFunc("/level", l.adminLevel)
+	mux.HandleFunc("/debug", l.adminToggle(func(b bool) { l.SetModeBool(LgDebug, b) }, func() bool { return l.HasMode(LgDebug) }))
+	mux.HandleFunc("/color", l.adminToggle(l.SetColor, func() bool { return l.HasMode(LgColor) }))
+	mux.HandleFunc("/buffer", l.adminBuffer)
+	mux.HandleFunc("/counters", l.adminCounters)
+	return mux
+}
+
+func (l *Log) adminState(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeAdminJSON(w, adminState{
+		Level:     l.GetLevel().String(),
+		Verbosity: l.GetVerbosity(),
+		Debug:     l.HasMode(LgDebug),
+		Color:     l.HasMode(LgColor),
+		Format:    l.GetFormat().String(),
+		Buffer:    l.HasMode(LgBuffer),
+	})
+}
+
+func (l *Log) adminLevel(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost, http.MethodPut:
+		lv, err := ParseLevel(r.FormValue("level"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		l.SetLevel(lv)
+		l.Warn("log level set to %s via admin handler", lv)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeAdminJSON(w, map[string]string{"level": l.GetLevel().String()})
+}
+
+// adminToggle returns a handler setting or toggling a switch
+func (l *Log) adminToggle(set func(bool), get func() bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost, http.MethodPut:
+			b := !get()
+			if s := r.FormValue("on"); s != "" {
+				var err error
+				if b, err = strconv.ParseBool(s); err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			set(b)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeAdminJSON(w, map[string]bool{"on": get()})
+	}
+}
+
+func (l *Log) adminBuffer(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("format") == "json" {
+		writeAdminJSON(w, l.GetBufferEntries())
+		return
+	}
+
+	// render first, so that a slow client does not hold up the dump
+	var b bytes.Buffer
+	l.DumpBufferTo(ansiStripper{&b})
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(b.Bytes())
+}
+
+func (l *Log) adminCounters(w http.ResponseWriter, r *http.Request) {
+	writeAdminJSON(w, l.Stats())
+}
+
+func writeAdminJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,94 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// adminRequest sends a request to h and returns the response
+func adminRequest(h http.Handler, method, target string, form url.Values) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+	if form != nil {
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestAdminState(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.SetMode(LgDebug | LgBuffer)
+	h := l.AdminHandler()
+
+	w := adminRequest(h, http.MethodGet, "/", nil)
+	var s adminState
+	if err := json.Unmarshal(w.Body.Bytes(), &s); err != nil || s.Level != "info" || !s.Debug || !s.Buffer || s.Format != "text" {
+		t.Errorf("state = %+v, %v", s, err)
+	}
+	if w := adminRequest(h, http.MethodGet, "/unknown", nil); w.Code != http.StatusNotFound {
+		t.Errorf("GET /unknown: %d", w.Code)
+	}
+}
+
+func TestAdminLevel(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	h := l.AdminHandler()
+
+	w := adminRequest(h, http.MethodPost, "/level", url.Values{"level": {"error"}})
+	if w.Code != http.StatusOK || l.GetLevel() != LevelError || !strings.Contains(w.Body.String(), `"level": "error"`) {
+		t.Errorf("POST /level: %d %s, level %v", w.Code, w.Body.String(), l.GetLevel())
+	}
+	adminRequest(h, http.MethodPost, "/level", url.Values{"level": {"debug"}})
+	if !strings.Contains(stdout.String(), "WARN:  log level set to debug via admin handler") {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	if w := adminRequest(h, http.MethodPost, "/level", url.Values{"level": {"loud"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid level: %d", w.Code)
+	}
+	if w := adminRequest(h, http.MethodDelete, "/level", nil); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE /level: %d", w.Code)
+	}
+}
+
+func TestAdminToggle(t *testing.T) {
+	l, _, _ := newTestLog()
+	h := l.AdminHandler()
+
+	adminRequest(h, http.MethodPost, "/debug", nil)
+	if !l.HasMode(LgDebug) {
+		t.Error("POST /debug did not toggle debug mode")
+	}
+	adminRequest(h, http.MethodPost, "/debug", url.Values{"on": {"true"}})
+	if !l.HasMode(LgDebug) {
+		t.Error("POST /debug on=true cleared debug mode")
+	}
+	adminRequest(h, http.MethodPost, "/color", url.Values{"on": {"1"}})
+	if !l.HasMode(LgColor) {
+		t.Error("POST /color on=1 did not enable colors")
+	}
+	if w := adminRequest(h, http.MethodPost, "/color", url.Values{"on": {"maybe"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid switch: %d", w.Code)
+	}
+}
+
+func TestAdminBuffer(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.EnableBuffer()
+	l.Warn("buffered")
+	h := l.AdminHandler()
+
+	if w := adminRequest(h, http.MethodGet, "/buffer", nil); !strings.Contains(w.Body.String(), "buffered") {
+		t.Errorf("GET /buffer = %q", w.Body.String())
+	}
+	var entries []BufferEntry
+	w := adminRequest(h, http.MethodGet, "/buffer?format=json", nil)
+	if err := json.Unmarshal(w.Body.Bytes(), &entries); err != nil || len(entries) != 1 || entries[0].Msg != "buffered" {
+		t.Errorf("GET /buffer?format=json = %s, %v", w.Body.String(), err)
+	}
+}
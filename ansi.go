@@ -0,0 +1,50 @@
+package MyLog
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ansiPattern matches CSI sequences (colors, cursor movement) and OSC
+// sequences (titles, hyperlinks) terminated by BEL or ST
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-?]*[ -/]*[@-~]|\x1b\\][^\x07\x1b]*(\x07|\x1b\\\\)")
+
+// stripANSI removes all escape sequences from s
+func stripANSI(s string) string {
+	if strings.IndexByte(s, 0x1b) < 0 {
+		return s
+	}
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+func hasEscape(p []byte) bool {
+	return bytes.IndexByte(p, 0x1b) >= 0
+}
+
+// ansiStripper removes escape sequences from everything written to w
+type ansiStripper struct {
+	w io.Writer
+}
+
+func (s ansiStripper) Write(p []byte) (int, error) {
+	if !hasEscape(p) {
+		return s.w.Write(p)
+	}
+	if _, err := s.w.Write(ansiPattern.ReplaceAll(p, nil)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// plainUnlessTerminal wraps w in an ansiStripper unless it is a terminal
+func plainUnlessTerminal(w io.Writer) io.Writer {
+	if w == nil || isTerminal(w) {
+		return w
+	}
+	if _, ok := w.(ansiStripper); ok {
+		return w
+	}
+	return ansiStripper{w}
+}
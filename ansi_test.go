@@ -0,0 +1,56 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"plain", "plain"},
+		{"\x1b[31mred\x1b[0m", "red"},
+		{"\x1b[1;38;5;208mbold\x1b[m text", "bold text"},
+		{"\x1b[2K\x1b[1Aup", "up"},
+		{"\x1b]0;title\x07after", "after"},
+		{"\x1b]8;;https://example.com\x1b\\link\x1b]8;;\x1b\\", "link"},
+	}
+	for _, tt := range tests {
+		if got := stripANSI(tt.in); got != tt.want {
+			t.Errorf("stripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestANSIStripper(t *testing.T) {
+	var b strings.Builder
+	w := ansiStripper{&b}
+	in := "\x1b[33mwarn\x1b[0m\n"
+	n, err := w.Write([]byte(in))
+	if err != nil || n != len(in) {
+		t.Fatalf("Write = %d, %v, want %d, nil", n, err, len(in))
+	}
+	if b.String() != "warn\n" {
+		t.Errorf("wrote %q, want %q", b.String(), "warn\n")
+	}
+	if _, ok := plainUnlessTerminal(w).(ansiStripper); !ok {
+		t.Error("plainUnlessTerminal did not keep the stripper")
+	}
+	if plainUnlessTerminal(w).(ansiStripper).w != &b {
+		t.Error("plainUnlessTerminal wrapped a stripper twice")
+	}
+}
+
+func TestColorStrippedOnBuffers(t *testing.T) {
+	forceColor(t)
+	l, stdout, stderr := newTestLog()
+	l.SetColor(true)
+
+	l.Warn("careful")
+	l.Error("failed")
+	if got := stdout.String() + stderr.String(); strings.IndexByte(got, 0x1b) >= 0 {
+		t.Errorf("escape codes written to a buffer: %q", got)
+	}
+	if stdout.String() != "WARN:  careful\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
@@ -0,0 +1,172 @@
+package MyLog
+
+import (
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+// DropPolicy selects what happens if the async queue is full
+type DropPolicy uint8
+
+const (
+	DropNewest DropPolicy = iota // discard the entry being logged
+	DropOldest                   // discard the oldest queued entry
+	Block                        // wait for room in the queue
+)
+
+// asyncItem is a queued entry, or a sync marker if done is set
+type asyncItem struct {
+	l     *Log
+	lg    *log.Logger
+	paint paintFunc
+	e     *Entry
+	done  chan struct{}
+}
+
+// asyncQueue hands entries to a background writer
+type asyncQueue struct {
+	mu      sync.RWMutex // guards closed against concurrent pushes
+	closed  bool
+	ch      chan asyncItem
+	policy  DropPolicy
+	dropped *atomic.Uint64
+	stopped chan struct{}
+}
+
+// EnableAsync moves writing entries to a background goroutine, fed by a
+// queue of queueSize entries. Panic and fatal entries are still written
+// synchronously, after the queue has been drained.
+func (l *Log) EnableAsync(queueSize int, policy DropPolicy) {
+	if !l.ready() {
+		return
+	}
+	l.DisableAsync()
+
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	q := &asyncQueue{
+		ch:      make(chan asyncItem, queueSize),
+		policy:  policy,
+		dropped: &l.stats.dropped,
+		stopped: make(chan struct{}),
+	}
+	go q.run()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.async = q
+}
+
+// DisableAsync writes all queued entries and returns to synchronous writing
+func (l *Log) DisableAsync() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	q := l.async
+	l.async = nil
+	l.mu.Unlock()
+
+	if q != nil {
+		q.close()
+	}
+}
+
+// AsyncDropped returns the number of entries dropped because the async
+// queue was full
+func (l *Log) AsyncDropped() uint64 {
+	if !l.ready() {
+		return 0
+	}
+	return l.stats.dropped.Load()
+}
+
+// asyncQueue returns the queue if async mode is enabled
+func (l *Log) asyncQueue() *asyncQueue {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.async
+}
+
+// syncAsync waits until all entries queued so far have been written
+func (l *Log) syncAsync() {
+	if q := l.asyncQueue(); q != nil {
+		q.sync()
+	}
+}
+
+func (q *asyncQueue) run() {
+	defer close(q.stopped)
+	for it := range q.ch {
+		if it.done != nil {
+			close(it.done)
+			continue
+		}
+		it.l.writeOut(it.lg, it.paint, it.e)
+	}
+}
+
+// push queues it according to the drop policy. It reports false if the
+// queue has been closed and it must be written by the caller.
+func (q *asyncQueue) push(it asyncItem) bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return false
+	}
+
+	if q.policy == Block {
+		q.ch <- it
+		return true
+	}
+
+	for {
+		select {
+		case q.ch <- it:
+			return true
+		default:
+		}
+
+		if q.policy == DropNewest {
+			q.dropped.Add(1)
+			return true
+		}
+
+		select {
+		case old := <-q.ch:
+			if old.done != nil {
+				close(old.done)
+			} else {
+				q.dropped.Add(1)
+			}
+		default:
+		}
+	}
+}
+
+// sync waits until the background writer has caught up
+func (q *asyncQueue) sync() {
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		<-q.stopped
+		return
+	}
+	done := make(chan struct{})
+	q.ch <- asyncItem{done: done}
+	q.mu.RUnlock()
+	<-done
+}
+
+// close drains the queue and stops the background writer
+func (q *asyncQueue) close() {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.ch)
+	}
+	q.mu.Unlock()
+	<-q.stopped
+}
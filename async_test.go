@@ -0,0 +1,86 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+)
+
+// gateWriter blocks every write until release is closed, announcing
+// the first one on entered
+type gateWriter struct {
+	syncBuffer
+	entered chan struct{}
+	release chan struct{}
+	first   bool
+}
+
+func newGateWriter() *gateWriter {
+	return &gateWriter{entered: make(chan struct{}), release: make(chan struct{}), first: true}
+}
+
+func (w *gateWriter) Write(p []byte) (int, error) {
+	if w.first {
+		w.first = false
+		close(w.entered)
+	}
+	<-w.release
+	return w.syncBuffer.Write(p)
+}
+
+func TestAsync(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.EnableAsync(4, Block)
+	for i := 0; i < 100; i++ {
+		l.StandardInfo("line %d", i)
+	}
+	l.DisableAsync()
+
+	lines := stdout.lines()
+	if len(lines) != 100 || lines[0] != "INFO:  line 0" || lines[99] != "INFO:  line 99" {
+		t.Errorf("got %d lines, first %q", len(lines), lines[0])
+	}
+	if l.AsyncDropped() != 0 {
+		t.Errorf("Block dropped %d entries", l.AsyncDropped())
+	}
+}
+
+func TestAsyncDropPolicy(t *testing.T) {
+	tests := []struct {
+		policy DropPolicy
+		want   string
+	}{
+		{DropNewest, "INFO:  1\nINFO:  2\n"},
+		{DropOldest, "INFO:  1\nINFO:  4\n"},
+	}
+	for _, tt := range tests {
+		l, _, _ := newTestLog()
+		w := newGateWriter()
+		l.SetOutput(w, w)
+		l.EnableAsync(1, tt.policy)
+
+		l.StandardInfo("1")
+		<-w.entered // the writer holds entry 1, the queue is empty
+		l.StandardInfo("2")
+		l.StandardInfo("3")
+		l.StandardInfo("4")
+		close(w.release)
+		l.DisableAsync()
+
+		if w.String() != tt.want || l.AsyncDropped() != 2 {
+			t.Errorf("policy %d: output %q, %d dropped", tt.policy, w.String(), l.AsyncDropped())
+		}
+	}
+}
+
+func TestAsyncPanicDrainsQueue(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetOutput(stdout, stdout)
+	l.EnableAsync(16, Block)
+	defer l.DisableAsync()
+
+	l.StandardInfo("queued")
+	l.Panic("now")
+	if !strings.HasPrefix(stdout.String(), "INFO:  queued\nPANIC: now\n") || stderr.String() != "" {
+		t.Errorf("output = %q", stdout.String())
+	}
+}
@@ -0,0 +1,192 @@
+// Package audit writes a tamper-evident audit trail of MyLog entries.
+// Every record carries the hash of the previous one, so that changing,
+// removing or reordering records breaks the chain, which Verify
+// detects. With a key the hashes are HMACs, so that the chain cannot
+// be recomputed without it.
+//
+// Records are JSON lines of the form
+//
+//	{"seq":1,"entry":{...},"prev":"<hex>","hash":"<hex>"}
+//
+// where hash covers the exact bytes of the record without the hash,
+// closed by "}".
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// genesis is the previous hash of the first record
+var genesis = make([]byte, sha256.Size)
+
+// Writer appends chained records to an io.Writer
+type Writer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	key  []byte
+	seq  uint64
+	prev []byte
+}
+
+// record is a line without its hash
+type record struct {
+	Seq   uint64          `json:"seq"`
+	Entry json.RawMessage `json:"entry"`
+	Prev  string          `json:"prev"`
+}
+
+// New returns a Writer starting a new chain on w. key may be nil for
+// plain SHA-256 hashes.
+func New(w io.Writer, key []byte) *Writer {
+	return &Writer{w: w, key: key, prev: genesis}
+}
+
+// Open continues the chain of the audit file at path, creating it if
+// needed. The existing records are verified first.
+func Open(path string, key []byte) (*Writer, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	seq, prev, err := verify(f, key)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Writer{w: f, key: key, seq: seq, prev: prev}, nil
+}
+
+// Attach adds w as a hook for the entries of the given levels of l, or
+// of all levels if none are given
+func Attach(l *MyLog.Log, w *Writer, levels ...MyLog.Level) {
+	l.AddHook(w.Hook, levels...)
+}
+
+// Hook appends e as the next record
+func (w *Writer) Hook(e MyLog.Entry) error {
+	entry, err := e.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	body, err := json.Marshal(record{Seq: w.seq + 1, Entry: entry, Prev: hex.EncodeToString(w.prev)})
+	if err != nil {
+		return err
+	}
+	h := sum(w.key, body)
+
+	line := append(body[:len(body)-1], `,"hash":"`...)
+	line = append(line, hex.EncodeToString(h)...)
+	line = append(line, "\"}\n"...)
+	if _, err := w.w.Write(line); err != nil {
+		return err
+	}
+
+	w.seq++
+	w.prev = h
+	return nil
+}
+
+// Close closes the underlying writer if it is an io.Closer
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Verify checks the chain of the records read from r and returns the
+// number of valid records. The error names the first broken record.
+func Verify(r io.Reader, key []byte) (int, error) {
+	seq, _, err := verify(r, key)
+	return int(seq), err
+}
+
+// VerifyFile runs Verify on the audit file at path
+func VerifyFile(path string, key []byte) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return Verify(f, key)
+}
+
+// verify returns the sequence number and hash of the last valid record
+func verify(r io.Reader, key []byte) (uint64, []byte, error) {
+	var seq uint64
+	prev := genesis
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, 16<<20)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		fail := func(reason string) (uint64, []byte, error) {
+			return seq, prev, fmt.Errorf("audit: record %d: %s", seq+1, reason)
+		}
+
+		// split off ,"hash":"<hex>"}
+		const tail = len(`,"hash":""}`) + 2*sha256.Size
+		if len(line) < tail || !bytes.HasPrefix(line[len(line)-tail:], []byte(`,"hash":"`)) {
+			return fail("malformed")
+		}
+		got, err := hex.DecodeString(string(line[len(line)-tail+9 : len(line)-2]))
+		if err != nil {
+			return fail("malformed hash")
+		}
+		body := append(line[:len(line)-tail:len(line)-tail], '}')
+
+		var rec record
+		if err := json.Unmarshal(body, &rec); err != nil {
+			return fail("malformed")
+		}
+		switch {
+		case rec.Seq != seq+1:
+			return fail(fmt.Sprintf("sequence %d out of order", rec.Seq))
+		case rec.Prev != hex.EncodeToString(prev):
+			return fail("chain broken")
+		}
+		h := sum(key, body)
+		if !hmac.Equal(h, got) {
+			return fail("hash mismatch")
+		}
+
+		seq, prev = rec.Seq, h
+	}
+	if err := sc.Err(); err != nil {
+		return seq, prev, err
+	}
+	return seq, prev, nil
+}
+
+// sum returns the HMAC-SHA256 of body with key, or its SHA-256 without
+func sum(key, body []byte) []byte {
+	if len(key) == 0 {
+		s := sha256.Sum256(body)
+		return s[:]
+	}
+	h := hmac.New(sha256.New, key)
+	h.Write(body)
+	return h.Sum(nil)
+}
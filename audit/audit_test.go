@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+var testKey = []byte("secret")
+
+// trail returns an audit trail of n records written through a Log
+func trail(t *testing.T, n int, key []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	l := MyLog.New()
+	l.SetOutput(io.Discard, io.Discard)
+	Attach(l, New(&buf, key))
+	for i := 0; i < n; i++ {
+		l.WithField("i", i).Warn("user %d deleted", i)
+	}
+	return buf.Bytes()
+}
+
+func TestVerify(t *testing.T) {
+	data := trail(t, 3, testKey)
+	if n, err := Verify(bytes.NewReader(data), testKey); n != 3 || err != nil {
+		t.Errorf("Verify() = %d, %v", n, err)
+	}
+	if _, err := Verify(bytes.NewReader(data), []byte("wrong")); err == nil || !strings.Contains(err.Error(), "record 1: hash mismatch") {
+		t.Errorf("Verify() with the wrong key = %v", err)
+	}
+	if n, err := Verify(bytes.NewReader(trail(t, 2, nil)), nil); n != 2 || err != nil {
+		t.Errorf("Verify() without key = %d, %v", n, err)
+	}
+}
+
+func TestVerifyTampered(t *testing.T) {
+	lines := strings.SplitAfter(string(trail(t, 3, testKey)), "\n")[:3]
+
+	tests := []struct {
+		name string
+		data string
+		n    int
+		want string
+	}{
+		{"changed", lines[0] + strings.Replace(lines[1], "user 1", "user 7", 1) + lines[2], 1, "record 2: hash mismatch"},
+		{"removed", lines[0] + lines[2], 1, "record 2: sequence 3 out of order"},
+		{"reordered", lines[1] + lines[0], 0, "record 1: sequence 2 out of order"},
+		{"truncated", lines[0] + lines[1][:40] + "\n", 1, "record 2: malformed"},
+	}
+	for _, tt := range tests {
+		n, err := Verify(strings.NewReader(tt.data), testKey)
+		if n != tt.n || err == nil || err.Error() != "audit: "+tt.want {
+			t.Errorf("%s: Verify() = %d, %v, want %d, %q", tt.name, n, err, tt.n, tt.want)
+		}
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	for i := 0; i < 2; i++ {
+		w, err := Open(path, testKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Hook(MyLog.Entry{Level: MyLog.LevelWarn, Message: "m"}); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+	}
+	if n, err := VerifyFile(path, testKey); n != 2 || err != nil {
+		t.Errorf("VerifyFile() = %d, %v, want the chain continued", n, err)
+	}
+
+	os.WriteFile(path, []byte("garbage\n"), 0o600)
+	if _, err := Open(path, testKey); err == nil {
+		t.Error("Open() accepted a broken trail")
+	}
+}
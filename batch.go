@@ -0,0 +1,212 @@
+package MyLog
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// BatchOptions configures a BatchSink
+type BatchOptions struct {
+	MaxSize    int           // entries per batch, default 100
+	MaxLatency time.Duration // maximum time an entry waits, default 1s
+	MaxBytes   int           // approximate bytes per batch, default 1MB
+	MaxPending int           // entries kept while the sink is busy, default 10 * MaxSize
+	Interval   time.Duration // minimum time between two writes, e.g. for alerts, default none
+}
+
+// BatchSink collects entries and writes them to a Sink in batches from
+// a background goroutine, so WriteEntries never waits for the sink. A
+// batch is written when it is full, MaxLatency has passed, an Error or
+// higher entry arrives, or on Flush and Close, but not within Interval
+// of the previous write unless flushed. Once MaxPending entries wait,
+// the oldest are dropped.
+type BatchSink struct {
+	s    Sink
+	opts BatchOptions
+
+	mu      sync.Mutex
+	pending []Entry
+	bytes   int // approximate size of pending
+	dropped uint64
+	err     error
+	closed  bool
+
+	kick    chan struct{}
+	flushed chan chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// Batch returns a running BatchSink writing to s
+func Batch(s Sink, opts BatchOptions) *BatchSink {
+	if opts.MaxSize <= 0 {
+		opts.MaxSize = 100
+	}
+	if opts.MaxLatency <= 0 {
+		opts.MaxLatency = time.Second
+	}
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 1 << 20
+	}
+	if opts.MaxPending < opts.MaxSize {
+		opts.MaxPending = 10 * opts.MaxSize
+	}
+
+	b := &BatchSink{
+		s:       s,
+		opts:    opts,
+		kick:    make(chan struct{}, 1),
+		flushed: make(chan chan struct{}),
+		done:    make(chan struct{}),
+	}
+	b.wg.Add(1)
+	go b.run()
+	return b
+}
+
+// WriteEntries queues entries. The error of a failed batch write is
+// returned by the following call. After Close it returns os.ErrClosed.
+func (b *BatchSink) WriteEntries(entries []Entry) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return os.ErrClosed
+	}
+	urgent := false
+	for _, e := range entries {
+		b.pending = append(b.pending, e)
+		b.bytes += entrySize(&e)
+		urgent = urgent || e.Level >= LevelError
+	}
+	if n := len(b.pending) - b.opts.MaxPending; n > 0 {
+		for i := range b.pending[:n] {
+			b.bytes -= entrySize(&b.pending[i])
+		}
+		b.pending = b.pending[n:]
+		b.dropped += uint64(n)
+	}
+	full := len(b.pending) >= b.opts.MaxSize || b.bytes >= b.opts.MaxBytes
+	err := b.err
+	b.err = nil
+	b.mu.Unlock()
+
+	if full || urgent {
+		select {
+		case b.kick <- struct{}{}:
+		default:
+		}
+	}
+	return err
+}
+
+// Flush writes all queued entries and waits until they are written
+func (b *BatchSink) Flush() {
+	ack := make(chan struct{})
+	select {
+	case b.flushed <- ack:
+		<-ack
+	case <-b.done:
+	}
+}
+
+// Dropped returns the number of entries dropped so far
+func (b *BatchSink) Dropped() uint64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.dropped
+}
+
+// Close writes the queued entries, stops the background goroutine and
+// closes the sink. Further calls do nothing.
+func (b *BatchSink) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	b.mu.Unlock()
+
+	close(b.done)
+	b.wg.Wait()
+	b.writeAll()
+
+	b.mu.Lock()
+	err := b.err
+	b.err = nil
+	b.mu.Unlock()
+	return errors.Join(err, b.s.Close())
+}
+
+func (b *BatchSink) run() {
+	defer b.wg.Done()
+
+	t := time.NewTicker(b.opts.MaxLatency)
+	defer t.Stop()
+	var last time.Time
+	for {
+		select {
+		case <-t.C:
+		case <-b.kick:
+		case ack := <-b.flushed:
+			b.writeAll()
+			last = time.Now()
+			close(ack)
+			continue
+		case <-b.done:
+			return
+		}
+
+		if wait := b.opts.Interval - time.Since(last); wait > 0 {
+			select {
+			case <-time.After(wait):
+			case ack := <-b.flushed:
+				b.writeAll()
+				last = time.Now()
+				close(ack)
+				continue
+			case <-b.done:
+				return
+			}
+		}
+		b.writeAll()
+		last = time.Now()
+	}
+}
+
+// writeAll writes the pending entries in batches within the limits
+func (b *BatchSink) writeAll() {
+	b.mu.Lock()
+	entries := b.pending
+	b.pending = nil
+	b.bytes = 0
+	b.mu.Unlock()
+
+	for len(entries) > 0 {
+		n, size := 0, 0
+		for n < len(entries) && n < b.opts.MaxSize {
+			if size += entrySize(&entries[n]); size > b.opts.MaxBytes && n > 0 {
+				break
+			}
+			n++
+		}
+
+		if err := b.s.WriteEntries(entries[:n]); err != nil {
+			b.mu.Lock()
+			b.err = err
+			b.mu.Unlock()
+		}
+		entries = entries[n:]
+	}
+}
+
+// entrySize estimates the size of the JSON rendering of e
+func entrySize(e *Entry) int {
+	n := 100 + len(e.Name) + len(e.Message) + len(e.Caller) + len(e.Stack)
+	for k := range e.Fields {
+		n += len(k) + 20
+	}
+	return n
+}
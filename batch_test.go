@@ -0,0 +1,193 @@
+package MyLog
+
+import (
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// batchRecorder keeps the sizes of the batches written to it, safe for
+// the background goroutine of BatchSink
+type batchRecorder struct {
+	mu      sync.Mutex
+	batches []int
+	err     error
+	closed  bool
+}
+
+func (s *batchRecorder) WriteEntries(entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, len(entries))
+	return s.err
+}
+
+func (s *batchRecorder) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *batchRecorder) sizes() []int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int(nil), s.batches...)
+}
+
+func testEntries(n int, lvl Level) []Entry {
+	es := make([]Entry, n)
+	for i := range es {
+		es[i] = Entry{Level: lvl, Message: "x"}
+	}
+	return es
+}
+
+func TestBatchMaxSize(t *testing.T) {
+	s := new(batchRecorder)
+	b := Batch(s, BatchOptions{MaxSize: 3, MaxLatency: time.Hour})
+	defer b.Close()
+
+	b.WriteEntries(testEntries(2, LevelInfo))
+	time.Sleep(20 * time.Millisecond)
+	if got := s.sizes(); len(got) != 0 {
+		t.Fatalf("batches %v written before the batch was full", got)
+	}
+	b.WriteEntries(testEntries(1, LevelInfo))
+	if !eventually(func() bool { return len(s.sizes()) == 1 }) || s.sizes()[0] != 3 {
+		t.Errorf("batches = %v, want [3]", s.sizes())
+	}
+}
+
+func TestBatchMaxLatency(t *testing.T) {
+	s := new(batchRecorder)
+	b := Batch(s, BatchOptions{MaxSize: 100, MaxLatency: 10 * time.Millisecond})
+	defer b.Close()
+
+	b.WriteEntries(testEntries(1, LevelInfo))
+	if !eventually(func() bool { return len(s.sizes()) == 1 }) {
+		t.Error("entry not written after MaxLatency")
+	}
+}
+
+func TestBatchUrgent(t *testing.T) {
+	s := new(batchRecorder)
+	b := Batch(s, BatchOptions{MaxLatency: time.Hour})
+	defer b.Close()
+
+	b.WriteEntries(testEntries(1, LevelError))
+	if !eventually(func() bool { return len(s.sizes()) == 1 }) {
+		t.Error("error entry not written at once")
+	}
+}
+
+func TestBatchInterval(t *testing.T) {
+	s := new(batchRecorder)
+	b := Batch(s, BatchOptions{MaxLatency: time.Hour, Interval: time.Hour})
+	defer b.Close()
+
+	b.WriteEntries(testEntries(1, LevelError))
+	if !eventually(func() bool { return len(s.sizes()) == 1 }) {
+		t.Fatal("first error entry not written")
+	}
+	b.WriteEntries(testEntries(1, LevelError))
+	time.Sleep(20 * time.Millisecond)
+	if got := s.sizes(); len(got) != 1 {
+		t.Fatalf("batches = %v, want the second one held back by Interval", got)
+	}
+
+	// Flush does not wait for the interval
+	b.Flush()
+	if got := s.sizes(); len(got) != 2 {
+		t.Errorf("batches after Flush = %v", got)
+	}
+}
+
+func TestBatchMaxPending(t *testing.T) {
+	s := new(batchRecorder)
+	b := Batch(s, BatchOptions{MaxSize: 100, MaxLatency: time.Hour, MaxPending: 150})
+
+	b.WriteEntries(testEntries(200, LevelInfo)) // kicks, but the oldest 50 are gone already
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if b.Dropped() != 50 {
+		t.Errorf("Dropped() = %d, want 50", b.Dropped())
+	}
+	total := 0
+	for _, n := range s.sizes() {
+		if n > 100 {
+			t.Errorf("batch of %d entries exceeds MaxSize", n)
+		}
+		total += n
+	}
+	if total != 150 || !s.closed {
+		t.Errorf("%d entries written, sink closed %v", total, s.closed)
+	}
+}
+
+func TestBatchMaxBytes(t *testing.T) {
+	s := new(batchRecorder)
+	b := Batch(s, BatchOptions{MaxLatency: time.Hour, MaxBytes: 250})
+
+	b.WriteEntries(testEntries(5, LevelInfo))
+	b.Close()
+	if got := s.sizes(); len(got) != 3 || got[0] != 2 {
+		t.Errorf("batches = %v, want [2 2 1]", got)
+	}
+}
+
+func TestBatchError(t *testing.T) {
+	s := &batchRecorder{err: errDown}
+	b := Batch(s, BatchOptions{MaxLatency: time.Hour})
+
+	b.WriteEntries(testEntries(1, LevelInfo))
+	b.Flush()
+	if err := b.WriteEntries(testEntries(1, LevelInfo)); !errors.Is(err, errDown) {
+		t.Errorf("WriteEntries() = %v, want the previous batch error", err)
+	}
+	if err := b.Close(); !errors.Is(err, errDown) {
+		t.Errorf("Close() = %v, want the last batch error", err)
+	}
+}
+
+func TestLogFlushesBatch(t *testing.T) {
+	l, _, _ := newTestLog()
+	s := new(batchRecorder)
+	l.AddSink(Batch(s, BatchOptions{MaxLatency: time.Hour}))
+
+	l.StandardInfo("queued")
+	l.Flush()
+	if got := s.sizes(); len(got) != 1 {
+		t.Errorf("batches after Flush = %v", got)
+	}
+	l.Close()
+	if !s.closed {
+		t.Error("Close did not close the batched sink")
+	}
+}
+
+func TestBatchCloseTwice(t *testing.T) {
+	l, _, _ := newTestLog()
+	s := new(batchRecorder)
+	b := Batch(s, BatchOptions{MaxLatency: time.Hour})
+	l.AddSink(b)
+
+	l.StandardInfo("queued")
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WriteEntries(testEntries(1, LevelInfo)); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("WriteEntries after Close = %v, want os.ErrClosed", err)
+	}
+	if err := b.Close(); err != nil {
+		t.Errorf("second Close() = %v", err)
+	}
+	l.Close()
+	b.Flush()
+	if got := s.sizes(); len(got) != 1 || got[0] != 1 {
+		t.Errorf("batches = %v, want the entry logged before Close", got)
+	}
+}
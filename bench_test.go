@@ -0,0 +1,87 @@
+package MyLog
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+// newBenchLog returns a Log discarding its output, with timestamps
+func newBenchLog(f Format) *Log {
+	l := new(Log)
+	l.Init(io.Discard, io.Discard)
+	l.SetFormat(f)
+	return l
+}
+
+var benchFields = Fields{"user": "alice", "attempt": 3, "err": errors.New("timeout")}
+
+func benchmarkPlain(b *testing.B, f Format) {
+	l := newBenchLog(f)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Standard("request served in %d ms", 42)
+	}
+}
+
+func benchmarkFields(b *testing.B, f Format) {
+	l := newBenchLog(f).WithFields(benchFields)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Warn("request failed")
+	}
+}
+
+func BenchmarkText(b *testing.B)         { benchmarkPlain(b, FormatText) }
+func BenchmarkTextFields(b *testing.B)   { benchmarkFields(b, FormatText) }
+func BenchmarkJSON(b *testing.B)         { benchmarkPlain(b, FormatJSON) }
+func BenchmarkJSONFields(b *testing.B)   { benchmarkFields(b, FormatJSON) }
+func BenchmarkLogfmt(b *testing.B)       { benchmarkPlain(b, FormatLogfmt) }
+func BenchmarkLogfmtFields(b *testing.B) { benchmarkFields(b, FormatLogfmt) }
+
+func BenchmarkDisabled(b *testing.B) {
+	l := newBenchLog(FormatText)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Debug("cache miss for %s", "key")
+	}
+}
+
+func BenchmarkParallel(b *testing.B) {
+	l := newBenchLog(FormatText)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			l.Standard("request served in %d ms", 42)
+		}
+	})
+}
+
+// TestAllocs keeps the text fast path from regressing, see the pooled
+// buffers in pool.go
+func TestAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector allocates")
+	}
+	l := newBenchLog(FormatText)
+	fl := l.WithFields(benchFields)
+
+	tests := []struct {
+		name string
+		max  float64
+		fn   func()
+	}{
+		{"Standard", 3, func() { l.Standard("request served in %d ms", 42) }},
+		{"Warn with fields", 5, func() { fl.Warn("request failed") }},
+		{"disabled Debug", 0, func() { l.Debug("cache miss for %s", "key") }},
+	}
+	for _, tt := range tests {
+		if got := testing.AllocsPerRun(100, tt.fn); got > tt.max {
+			t.Errorf("%s: %v allocations, want at most %v", tt.name, got, tt.max)
+		}
+	}
+}
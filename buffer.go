@@ -0,0 +1,243 @@
+package MyLog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// BufferEntry is a single message kept in the log buffer
+type BufferEntry struct {
+	Time   time.Time
+	Level  Level
+	Prefix string // level label like "INFO", empty for standard messages
+	Msg    string
+}
+
+// ring keeps entries in insertion order, dropping the oldest
+// entry once max entries are stored. A max of 0 is unlimited.
+type ring struct {
+	data  []BufferEntry
+	start int // index of the oldest entry once the ring is full
+	max   int
+	added uint64 // entries added so far, see dropWritten
+}
+
+func (r *ring) add(e BufferEntry) {
+	r.added++
+	if r.max > 0 && len(r.data) >= r.max {
+		r.data[r.start] = e
+		r.start = (r.start + 1) % r.max
+		return
+	}
+	r.data = append(r.data, e)
+}
+
+// entries returns a copy of the stored entries, oldest first
+func (r *ring) entries() []BufferEntry {
+	entries := make([]BufferEntry, 0, len(r.data))
+	entries = append(entries, r.data[r.start:]...)
+	return append(entries, r.data[:r.start]...)
+}
+
+// resize changes the maximum, keeping the newest entries
+func (r *ring) resize(max int) {
+	entries := r.entries()
+	if max > 0 && len(entries) > max {
+		entries = entries[len(entries)-max:]
+	}
+
+	r.data = entries
+	r.start = 0
+	r.max = max
+}
+
+func (r *ring) reset() {
+	r.data = nil
+	r.start = 0
+}
+
+// dropWritten drops the entries that were stored when r.added was
+// added, keeping those added since
+func (r *ring) dropWritten(added uint64) {
+	keep := r.added - added
+	if keep >= uint64(len(r.data)) {
+		return
+	}
+	entries := r.entries()
+	r.data = entries[len(entries)-int(keep):]
+	r.start = 0
+}
+
+// Buffer Handling
+func (l *Log) AddBuffer(format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.buffer(LevelInfo, "", l.redactMsg(fmt.Sprintf(format, v...)))
+}
+
+// buffer stores msg with the given level if the buffer is enabled
+func (l *Log) buffer(lv Level, prefix, msg string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.modeRegister&LgBuffer != 0 {
+		l.bufferData.add(BufferEntry{Time: time.Now(), Level: lv, Prefix: prefix, Msg: stripANSI(msg)})
+	}
+}
+
+func (l *Log) GetBuffer() string {
+	if !l.ready() {
+		return ""
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	entries := l.bufferData.entries()
+	msgs := make([]string, len(entries))
+	for i, e := range entries {
+		msgs[i] = e.Msg
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// GetBufferEntries returns a copy of the buffered entries, oldest first
+func (l *Log) GetBufferEntries() []BufferEntry {
+	if !l.ready() {
+		return nil
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.bufferData.entries()
+}
+
+// EnableBufferN enables the buffer as a ring keeping only the last
+// maxEntries entries. A value of 0 makes the buffer unbounded again.
+func (l *Log) EnableBufferN(maxEntries int) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bufferData.resize(maxEntries)
+	l.modeRegister = l.modeRegister | LgBuffer
+}
+
+// BufferLen returns the number of buffered entries
+func (l *Log) BufferLen() int {
+	if !l.ready() {
+		return 0
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.bufferData.data)
+}
+
+// ClearBuffer drops all buffered entries
+func (l *Log) ClearBuffer() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bufferData.reset()
+}
+
+// FlushBufferTo writes all buffered entries to w, rendered with the
+// prefixes, flags and format of l and their original timestamps.
+// The written entries are dropped afterwards unless writing fails;
+// entries buffered in the meantime are kept. l is not locked while
+// writing, so w may log to l itself.
+func (l *Log) FlushBufferTo(w io.Writer) error {
+	if !l.ready() {
+		return nil
+	}
+	added, err := l.writeBuffer(w)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bufferData.dropWritten(added)
+
+	return nil
+}
+
+// DumpBufferTo writes all buffered entries to w like FlushBufferTo,
+// but keeps them in the buffer
+func (l *Log) DumpBufferTo(w io.Writer) error {
+	if !l.ready() {
+		return nil
+	}
+	_, err := l.writeBuffer(w)
+	return err
+}
+
+// writeBuffer renders a copy of the buffer to w without holding l.mu
+// and returns the number of entries added to the buffer at the time of
+// the copy
+func (l *Log) writeBuffer(w io.Writer) (uint64, error) {
+	l.mu.RLock()
+	entries, added := l.bufferData.entries(), l.bufferData.added
+	f, tf := l.lineFormatter(), l.timeFmt
+	l.mu.RUnlock()
+
+	for _, e := range entries {
+		var err error
+		if f != nil {
+			err = writeFormatted(w, f, e.entry())
+		} else {
+			_, err = w.Write(formatLine(l.levelVar(e.Level, e.Prefix), tf, e.Time, e.Msg))
+		}
+		if err != nil {
+			return added, err
+		}
+	}
+	return added, nil
+}
+
+// ReplayBuffer writes all buffered entries to the outputs of other,
+// keeping their original timestamps, and adds them to its buffer.
+// The buffer of l is left untouched.
+func (l *Log) ReplayBuffer(other *Log) {
+	if !l.ready() {
+		return
+	}
+	for _, e := range l.GetBufferEntries() {
+		other.writeEntry(e)
+
+		other.mu.Lock()
+		if other.modeRegister&LgBuffer != 0 {
+			other.bufferData.add(e)
+		}
+		other.mu.Unlock()
+	}
+}
+
+// writeEntry writes e to the matching level logger of l,
+// keeping its original timestamp
+func (l *Log) writeEntry(e BufferEntry) {
+	lg := l.levelVar(e.Level, e.Prefix)
+	w, routed := l.writerFor(e.Level)
+	if !routed {
+		w = lg.Writer()
+	}
+
+	l.mu.RLock()
+	f := l.lineFormatter()
+	l.mu.RUnlock()
+
+	if f != nil {
+		writeFormatted(w, f, e.entry())
+	} else {
+		w.Write(formatLine(lg, l.timeFormat(nil), e.Time, e.Msg))
+	}
+	l.toOutputs(e.entry(), plain)
+}
+
+// entry converts e for writing; fields and caller are part of Msg
+func (e BufferEntry) entry() *Entry {
+	return &Entry{Time: e.Time, Level: e.Level, Prefix: e.Prefix, Message: e.Msg}
+}
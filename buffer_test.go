@@ -0,0 +1,201 @@
+package MyLog
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRing(t *testing.T) {
+	r := ring{max: 3}
+	for i := 0; i < 5; i++ {
+		r.add(BufferEntry{Msg: fmt.Sprint(i)})
+	}
+	if got := msgs(r.entries()); got != "2 3 4" {
+		t.Errorf("entries = %s, want 2 3 4", got)
+	}
+
+	r.resize(2)
+	if got := msgs(r.entries()); got != "3 4" {
+		t.Errorf("after resize(2) = %s, want 3 4", got)
+	}
+	r.resize(0)
+	r.add(BufferEntry{Msg: "5"})
+	r.add(BufferEntry{Msg: "6"})
+	if got := msgs(r.entries()); got != "3 4 5 6" {
+		t.Errorf("unbounded = %s, want 3 4 5 6", got)
+	}
+}
+
+func msgs(entries []BufferEntry) string {
+	s := make([]string, len(entries))
+	for i, e := range entries {
+		s[i] = e.Msg
+	}
+	return strings.Join(s, " ")
+}
+
+func TestEnableBufferN(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.EnableBufferN(2)
+
+	l.StandardInfo("one")
+	l.StandardInfo("two")
+	l.AddBuffer("three")
+	if got, want := l.GetBuffer(), "two\nthree"; got != want {
+		t.Errorf("GetBuffer() = %q, want %q", got, want)
+	}
+	if l.BufferLen() != 2 {
+		t.Errorf("BufferLen() = %d, want 2", l.BufferLen())
+	}
+
+	l.ClearBuffer()
+	if l.BufferLen() != 0 || l.GetBuffer() != "" {
+		t.Error("ClearBuffer left entries")
+	}
+
+	l.DisableBuffer()
+	l.StandardInfo("not buffered")
+	if l.BufferLen() != 0 {
+		t.Error("a disabled buffer grew")
+	}
+}
+
+func TestBufferEntries(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.EnableBuffer()
+	l.SetColor(true)
+
+	l.Standard("plain")
+	l.WithField("k", 1).Warn("careful")
+	l.Error("failed")
+
+	entries := l.GetBufferEntries()
+	if len(entries) != 3 {
+		t.Fatalf("got %d entries, want 3", len(entries))
+	}
+	want := []BufferEntry{
+		{Level: LevelInfo, Prefix: "", Msg: "plain"},
+		{Level: LevelWarn, Prefix: "WARN", Msg: "careful k=1"},
+		{Level: LevelError, Prefix: "ERROR", Msg: "failed"},
+	}
+	for i, e := range entries {
+		if e.Level != want[i].Level || e.Prefix != want[i].Prefix || e.Msg != want[i].Msg {
+			t.Errorf("entry %d = %+v, want %+v", i, e, want[i])
+		}
+		if e.Time.IsZero() {
+			t.Errorf("entry %d has no time", i)
+		}
+	}
+
+	// entries are copies
+	entries[0].Msg = "changed"
+	if l.GetBufferEntries()[0].Msg != "plain" {
+		t.Error("GetBufferEntries returned the internal slice")
+	}
+}
+
+func TestFlushBufferTo(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.EnableBuffer()
+	l.StandardInfo("one")
+	l.Error("two")
+
+	var dump, flush syncBuffer
+	if err := l.DumpBufferTo(&dump); err != nil {
+		t.Fatal(err)
+	}
+	if l.BufferLen() != 2 {
+		t.Error("DumpBufferTo cleared the buffer")
+	}
+	if err := l.FlushBufferTo(&flush); err != nil {
+		t.Fatal(err)
+	}
+	if l.BufferLen() != 0 {
+		t.Error("FlushBufferTo kept the buffer")
+	}
+
+	want := "INFO:  one\nERROR: two\n"
+	if dump.String() != want || flush.String() != want {
+		t.Errorf("dump = %q, flush = %q, want %q", dump.String(), flush.String(), want)
+	}
+}
+
+func TestFlushBufferToSelf(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.EnableBuffer()
+	l.StandardInfo("one")
+	l.Error("two")
+
+	done := make(chan error)
+	go func() { done <- l.FlushBufferTo(l.WriterLevel(LevelWarn)) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("FlushBufferTo into the logger itself deadlocks")
+	}
+
+	// the flushed entries were logged again and are buffered anew
+	if got := stdout.lines(); len(got) != 3 || got[2] != "WARN:  ERROR: two" {
+		t.Errorf("stdout = %q", got)
+	}
+	if got := l.GetBuffer(); got != "INFO:  one\nERROR: two" {
+		t.Errorf("buffer after the flush = %q", got)
+	}
+}
+
+func TestDumpBufferToSlowWriter(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.EnableBuffer()
+	l.StandardInfo("buffered")
+
+	w := newGateWriter()
+	done := make(chan error)
+	go func() { done <- l.DumpBufferTo(w) }()
+	<-w.entered
+
+	// logging goes on while the writer is stuck
+	logged := make(chan struct{})
+	go func() {
+		l.StandardInfo("meanwhile")
+		close(logged)
+	}()
+	select {
+	case <-logged:
+	case <-time.After(5 * time.Second):
+		t.Fatal("a slow DumpBufferTo blocks logging")
+	}
+
+	close(w.release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if w.String() != "INFO:  buffered\n" || len(stdout.lines()) != 2 {
+		t.Errorf("dump = %q, stdout = %q", w.String(), stdout.lines())
+	}
+}
+
+func TestReplayBuffer(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.EnableBuffer()
+	l.StandardInfo("one")
+	l.Error("two")
+
+	other, stdout, stderr := newTestLog()
+	other.EnableBuffer()
+	l.ReplayBuffer(other)
+
+	if stdout.String() != "INFO:  one\n" || stderr.String() != "ERROR: two\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+	if got := other.GetBufferEntries(); len(got) != 2 || !got[0].Time.Equal(l.GetBufferEntries()[0].Time) {
+		t.Errorf("replayed buffer = %+v, want the original entries", got)
+	}
+	if l.BufferLen() != 2 {
+		t.Error("ReplayBuffer changed the source buffer")
+	}
+}
@@ -0,0 +1,57 @@
+package MyLog_test
+
+import (
+	"bytes"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hleinders/MyLog"
+)
+
+// The caller and stack tests live outside of package MyLog because its
+// own frames, test functions included, are never reported.
+
+// newLog returns a Log writing stdout and stderr to a single buffer,
+// without timestamps
+func newLog() (*MyLog.Log, *bytes.Buffer) {
+	var b bytes.Buffer
+	return MyLog.New(MyLog.WithOutput(&b, &b), MyLog.WithFlags(0)), &b
+}
+
+func line() int {
+	_, _, n, _ := runtime.Caller(1)
+	return n
+}
+
+func TestReportCaller(t *testing.T) {
+	l, b := newLog()
+	l.SetReportCaller(true)
+
+	n := line() + 1
+	l.Warn("careful")
+	want := "WARN:  careful [caller_test.go:" + strconv.Itoa(n) + " MyLog_test.TestReportCaller]\n"
+	if b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+
+	b.Reset()
+	l.SetReportCaller(false)
+	l.Warn("careful")
+	if b.String() != "WARN:  careful\n" {
+		t.Errorf("output = %q without caller", b.String())
+	}
+}
+
+func TestReportCallerJSON(t *testing.T) {
+	l, b := newLog()
+	l.SetReportCaller(true)
+	l.SetFormat(MyLog.FormatJSON)
+
+	l.WithFields(MyLog.Fields{"k": 1}).Error("failed")
+	if !regexp.MustCompile(`"caller":"caller_test.go:\d+ MyLog_test.TestReportCallerJSON"`).MatchString(b.String()) {
+		t.Errorf("JSON entry = %s", strings.TrimSpace(b.String()))
+	}
+}
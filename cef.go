@@ -0,0 +1,205 @@
+package MyLog
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SIEMOptions configures CEFFormatter and LEEFFormatter
+type SIEMOptions struct {
+	Vendor  string
+	Product string
+	Version string
+
+	// EventIDField is the field holding the event class ID, like
+	// "login_failed". Entries without it use the level name.
+	EventIDField string
+
+	// Mapping renames fields to extension keys of the vendor
+	// dictionary, e.g. "user" to "suser" or "client_ip" to "src".
+	Mapping map[string]string
+
+	// DropUnmapped omits fields without a mapping
+	DropUnmapped bool
+}
+
+// CEFFormatter renders entries in the ArcSight Common Event Format:
+//
+//	CEF:0|Vendor|Product|Version|EventID|Message|Severity|rt=... key=value
+type CEFFormatter struct {
+	SIEMOptions
+}
+
+// LEEFFormatter renders entries in the QRadar Log Event Extended Format
+// 2.0, with tab separated attributes:
+//
+//	LEEF:2.0|Vendor|Product|Version|EventID|x09|devTime=...	sev=...	msg=...
+type LEEFFormatter struct {
+	SIEMOptions
+}
+
+func (f CEFFormatter) Format(e Entry) ([]byte, error) {
+	return f.appendEntry(nil, &e)
+}
+
+func (f CEFFormatter) appendEntry(b []byte, e *Entry) ([]byte, error) {
+	b = append(b, "CEF:0|"...)
+	for _, s := range []string{f.Vendor, f.Product, f.Version, f.eventID(e), e.Message} {
+		b = appendCEFHeader(b, s)
+		b = append(b, '|')
+	}
+	b = strconv.AppendInt(b, int64(siemSeverity(e.Level)), 10)
+	b = append(b, "|rt="...)
+	b = strconv.AppendInt(b, e.Time.UnixMilli(), 10)
+
+	for _, kv := range f.extensions(e) {
+		b = append(b, ' ')
+		b = append(b, kv[0]...)
+		b = append(b, '=')
+		b = appendCEFValue(b, kv[1])
+	}
+	return append(b, '\n'), nil
+}
+
+func (f LEEFFormatter) Format(e Entry) ([]byte, error) {
+	return f.appendEntry(nil, &e)
+}
+
+func (f LEEFFormatter) appendEntry(b []byte, e *Entry) ([]byte, error) {
+	b = append(b, "LEEF:2.0|"...)
+	for _, s := range []string{f.Vendor, f.Product, f.Version, f.eventID(e)} {
+		b = append(b, strings.ReplaceAll(s, "|", "_")...)
+		b = append(b, '|')
+	}
+	b = append(b, "x09|devTimeFormat=yyyy-MM-dd'T'HH:mm:ss.SSSZ\tdevTime="...)
+	b = e.Time.AppendFormat(b, "2006-01-02T15:04:05.000-0700")
+	b = append(b, "\tsev="...)
+	b = strconv.AppendInt(b, int64(siemSeverity(e.Level)), 10)
+	b = append(b, "\tcat="...)
+	b = append(b, e.Level.String()...)
+	b = append(b, "\tmsg="...)
+	b = append(b, leefValue(e.Message)...)
+
+	for _, kv := range f.extensions(e) {
+		b = append(b, '\t')
+		b = append(b, kv[0]...)
+		b = append(b, '=')
+		b = append(b, leefValue(kv[1])...)
+	}
+	return append(b, '\n'), nil
+}
+
+func (o SIEMOptions) eventID(e *Entry) string {
+	if v, ok := e.Fields[o.EventIDField]; ok && o.EventIDField != "" {
+		return fmt.Sprint(v)
+	}
+	return e.Level.String()
+}
+
+// extensions returns the mapped fields sorted by key, followed by the
+// logger name, caller and stack
+func (o SIEMOptions) extensions(e *Entry) [][2]string {
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		if k != o.EventIDField || o.EventIDField == "" {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+
+	ext := make([][2]string, 0, len(keys)+3)
+	for _, k := range keys {
+		name, ok := o.Mapping[k]
+		if !ok {
+			if o.DropUnmapped {
+				continue
+			}
+			name = siemKey(k)
+		}
+
+		var s string
+		switch v := e.Fields[k].(type) {
+		case string:
+			s = v
+		case error:
+			s = v.Error()
+		default:
+			s = fmt.Sprint(v)
+		}
+		ext = append(ext, [2]string{name, s})
+	}
+
+	if e.Name != "" {
+		ext = append(ext, [2]string{"deviceProcessName", e.Name})
+	}
+	if e.Caller != "" {
+		ext = append(ext, [2]string{"sourceServiceName", e.Caller})
+	}
+	if e.Stack != "" {
+		ext = append(ext, [2]string{"stack", e.Stack})
+	}
+	return ext
+}
+
+// siemSeverity maps a level onto the 0-10 scale of CEF and LEEF
+func siemSeverity(lv Level) int {
+	switch lv {
+	case LevelTrace, LevelDebug:
+		return 1
+	case LevelVerbose, LevelInfo:
+		return 3
+	case LevelWarn:
+		return 6
+	case LevelError:
+		return 8
+	}
+	return 10
+}
+
+// appendCEFHeader escapes backslashes and pipes of a header field
+func appendCEFHeader(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\', '|':
+			b = append(b, '\\', c)
+		case '\r', '\n':
+			b = append(b, ' ')
+		default:
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// appendCEFValue escapes backslashes, equal signs and line breaks of an
+// extension value
+func appendCEFValue(b []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\', '=':
+			b = append(b, '\\', c)
+		case '\n':
+			b = append(b, `\n`...)
+		case '\r':
+			b = append(b, `\r`...)
+		default:
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// leefValue replaces the tab delimiter and line breaks of a value
+var leefValue = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ").Replace
+
+// siemKey replaces the characters not allowed in extension keys
+func siemKey(k string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '|' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, k)
+}
@@ -0,0 +1,75 @@
+package MyLog
+
+import (
+	"testing"
+	"time"
+)
+
+var siemTime = time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC)
+
+func siemOptions() SIEMOptions {
+	return SIEMOptions{
+		Vendor:       "Acme",
+		Product:      "Shop|Web",
+		Version:      "1.0",
+		EventIDField: "event",
+		Mapping:      map[string]string{"user": "suser"},
+	}
+}
+
+func TestCEFFormatter(t *testing.T) {
+	e := Entry{
+		Time:    siemTime,
+		Level:   LevelWarn,
+		Message: `login failed for a\b`,
+		Fields:  Fields{"event": "login_failed", "user": "alice", "query": "a=b\nc"},
+		Caller:  "auth.go:12 auth.Login",
+	}
+	b, err := CEFFormatter{siemOptions()}.Format(e)
+	want := `CEF:0|Acme|Shop\|Web|1.0|login_failed|login failed for a\\b|6|rt=1704207845000` +
+		` query=a\=b\nc suser=alice sourceServiceName=auth.go:12 auth.Login` + "\n"
+	if err != nil || string(b) != want {
+		t.Errorf("Format() =\n%q, want\n%q", b, want)
+	}
+}
+
+func TestLEEFFormatter(t *testing.T) {
+	e := Entry{
+		Time:    siemTime,
+		Level:   LevelError,
+		Name:    "auth",
+		Message: "locked\tout",
+		Fields:  Fields{"user": "alice"},
+	}
+	b, err := LEEFFormatter{siemOptions()}.Format(e)
+	want := "LEEF:2.0|Acme|Shop_Web|1.0|error|x09|devTimeFormat=yyyy-MM-dd'T'HH:mm:ss.SSSZ\t" +
+		"devTime=2024-01-02T15:04:05.000+0000\tsev=8\tcat=error\tmsg=locked out\tsuser=alice\tdeviceProcessName=auth\n"
+	if err != nil || string(b) != want {
+		t.Errorf("Format() =\n%q, want\n%q", b, want)
+	}
+}
+
+func TestSIEMDropUnmapped(t *testing.T) {
+	opts := siemOptions()
+	opts.DropUnmapped = true
+	e := Entry{Time: siemTime, Level: LevelInfo, Message: "m", Fields: Fields{"user": "alice", "a b": 1}}
+
+	b, _ := CEFFormatter{opts}.Format(e)
+	if want := "CEF:0|Acme|Shop\\|Web|1.0|info|m|3|rt=1704207845000 suser=alice\n"; string(b) != want {
+		t.Errorf("dropped unmapped = %q, want %q", b, want)
+	}
+	opts.DropUnmapped = false
+	b, _ = CEFFormatter{opts}.Format(e)
+	if want := "CEF:0|Acme|Shop\\|Web|1.0|info|m|3|rt=1704207845000 a_b=1 suser=alice\n"; string(b) != want {
+		t.Errorf("kept unmapped = %q, want %q", b, want)
+	}
+}
+
+func TestSIEMSeverity(t *testing.T) {
+	want := map[Level]int{LevelTrace: 1, LevelDebug: 1, LevelInfo: 3, LevelWarn: 6, LevelError: 8, LevelPanic: 10}
+	for lv, sev := range want {
+		if got := siemSeverity(lv); got != sev {
+			t.Errorf("siemSeverity(%v) = %d, want %d", lv, got, sev)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package MyLog
+
+import (
+	"io"
+	"log"
+	"maps"
+	"slices"
+)
+
+// Clone returns an independent Log with the settings of l: writers,
+// outputs, flags, prefixes, modes, levels, formats, colors, hooks and
+// redactors. Changing either Log leaves the other untouched. The clone
+// writes to the log file and syslog of l but does not close them. The
+// files of the outputs of ApplyConfig are shared and stay open until
+// both Logs have closed or replaced them. The buffer, statistics and
+// collected errors start anew, as do sampling, deduplication and rate
+// limits with the settings of l. Async mode is not copied.
+func (l *Log) Clone() *Log {
+	if !l.ready() {
+		return nil
+	}
+	l.mu.RLock()
+	c := &Log{core: &core{
+		bufferData:    ring{max: l.bufferData.max},
+		modeRegister:  l.modeRegister,
+		level:         l.level,
+		quiet:         l.quiet,
+		warnErrors:    l.warnErrors,
+		suppressed:    l.suppressed,
+		moduleLevels:  maps.Clone(l.moduleLevels),
+		format:        l.format,
+		formatter:     l.formatter,
+		stdOut:        l.stdOut,
+		stdErr:        l.stdErr,
+		panicOut:      l.panicOut,
+		file:          l.file,
+		syslog:        l.syslog,
+		sharedFile:    l.file != nil,
+		sharedSyslog:  l.syslog != nil,
+		levelOut:      maps.Clone(l.levelOut),
+		tty:           l.tty,
+		colorAuto:     l.colorAuto,
+		coloredPrefix: l.coloredPrefix,
+		colorScope:    l.colorScope,
+		painters:      maps.Clone(l.painters),
+		levelPrefixes: maps.Clone(l.levelPrefixes),
+		iconMode:      l.iconMode,
+		multiline:     l.multiline,
+		wrap:          l.wrap,
+		dumpLimit:     l.dumpLimit,
+		sectionRule:   l.sectionRule,
+		bannerRule:    l.bannerRule,
+		ruleWidth:     l.ruleWidth,
+		verbosity:     l.verbosity,
+		reportCaller:  l.reportCaller,
+		callerLinks:   l.callerLinks,
+		stackDepth:    l.stackDepth,
+		includeStack:  l.includeStack,
+		ctxExtractor:  l.ctxExtractor,
+		redactors:     slices.Clone(l.redactors),
+		hookPolicy:    l.hookPolicy,
+		timeFmt:       l.timeFmt,
+	}}
+	if l.recorder != nil {
+		c.recorder = &ring{max: l.recorder.max}
+	}
+	if l.collector != nil {
+		c.collector = newCollector(l.collector.entries.max)
+	}
+	if l.sampler != nil {
+		c.sampler = &sampler{initial: l.sampler.initial, thereafter: l.sampler.thereafter, counts: make(map[sampleKey]int)}
+	}
+	if l.dedup != nil {
+		c.dedup = &deduper{window: l.dedup.window}
+	}
+	for lv, r := range l.rateLimits {
+		if c.rateLimits == nil {
+			c.rateLimits = make(map[Level]*rateLimiter)
+		}
+		c.rateLimits[lv] = &rateLimiter{n: r.n, per: r.per}
+	}
+	c.outputs = slices.Clone(l.outputs)
+	for _, f := range l.configFiles {
+		c.configFiles = append(c.configFiles, f.acquire())
+	}
+	for _, h := range l.hooks {
+		hc := *h
+		c.hooks = append(c.hooks, &hc)
+	}
+	l.mu.RUnlock()
+
+	c.fields, c.name, c.prefix = l.fields, l.name, l.prefix
+	c.stdVar = cloneLogger(l.stdVar)
+	c.infoVar = cloneLogger(l.infoVar)
+	c.warningVar = cloneLogger(l.warningVar)
+	c.debugVar = cloneLogger(l.debugVar)
+	c.traceVar = cloneLogger(l.traceVar)
+	c.errorVar = cloneLogger(l.errorVar)
+	c.panicVar = cloneLogger(l.panicVar)
+	c.fatalVar = cloneLogger(l.fatalVar)
+	c.applyOutput()
+	return c
+}
+
+// With returns a Clone of l with opts applied
+func (l *Log) With(opts ...Option) *Log {
+	if !l.ready() {
+		return nil
+	}
+	c := l.Clone()
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func cloneLogger(lg *log.Logger) *log.Logger {
+	return log.New(io.Discard, lg.Prefix(), lg.Flags())
+}
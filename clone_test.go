@@ -0,0 +1,62 @@
+package MyLog
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetLevel(LevelDebug)
+	l.SetLevelPrefix(LevelWarn, "W: ")
+	l.AddRedactor(regexp.MustCompile(`secret`), "***")
+	hooked := 0
+	l.AddHook(func(Entry) error { hooked++; return nil })
+	l.EnableBuffer()
+	l.AddBuffer("original")
+	fl := l.Named("db").WithField("k", "v")
+
+	c := fl.Clone()
+	c.Warn("secret")
+	c.Debug("debug")
+	if stdout.String() != "W: [db] *** k=v\n" || stderr.String() != "DEBUG: [db] debug k=v\n" {
+		t.Errorf("clone wrote stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+	if hooked != 2 {
+		t.Errorf("hook ran %d times for the clone, want 2", hooked)
+	}
+	if c.GetBuffer() == l.GetBuffer() {
+		t.Error("the clone shares the buffer")
+	}
+
+	// changing the clone leaves the original alone, and vice versa
+	c.SetLevel(LevelError)
+	c.SetLevelPrefix(LevelWarn, "")
+	c.RemoveRedactors()
+	l.SetOutput(new(syncBuffer), new(syncBuffer))
+	if l.GetLevel() != LevelDebug {
+		t.Errorf("original level = %v", l.GetLevel())
+	}
+	c.SetLevel(LevelWarn)
+	c.Warn("secret")
+	if got := stdout.lines(); got[len(got)-1] != "WARN:  [db] secret k=v" {
+		t.Errorf("clone after changes = %q", got[len(got)-1])
+	}
+	l.Warn("secret")
+	if got := stdout.lines(); len(got) != 2 {
+		t.Errorf("the original still writes to the old output: %q", got)
+	}
+}
+
+func TestWith(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	other := new(syncBuffer)
+
+	c := l.With(WithOutput(other, other), WithLevel(LevelWarn))
+	c.StandardInfo("hidden")
+	c.Warn("shown")
+	l.StandardInfo("original")
+	if other.String() != "WARN:  shown\n" || stdout.String() != "INFO:  original\n" {
+		t.Errorf("clone = %q, original = %q", other.String(), stdout.String())
+	}
+}
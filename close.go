@@ -0,0 +1,64 @@
+package MyLog
+
+import "errors"
+
+// Flush writes pending repeat and rate limit summaries and all queued
+// entries, flushes sinks like BatchSink and syncs the writers,
+// including the log file
+func (l *Log) Flush() {
+	if !l.ready() {
+		return
+	}
+	l.mu.RLock()
+	d := l.dedup
+	limits := make(map[Level]*rateLimiter, len(l.rateLimits))
+	for lv, r := range l.rateLimits {
+		limits[lv] = r
+	}
+	l.mu.RUnlock()
+
+	for lv, r := range limits {
+		l.reportSuppressed(lv, r)
+	}
+	l.flushDedup(d)
+	l.syncAsync()
+	l.flushSinks()
+	l.flush()
+}
+
+// Close stops watching the config file and signals, removes the status
+// line, flushes l, stops async mode, closes the log file, the outputs
+// of ApplyConfig, the sinks and the syslog connection and runs the exit
+// hooks. l keeps writing to the standard outputs afterwards.
+func (l *Log) Close() error {
+	if !l.ready() {
+		return nil
+	}
+	l.StopWatchConfig()
+	l.StopSignals()
+	l.ClearStatus()
+	l.Flush()
+	l.DisableAsync()
+
+	errs := []error{l.closeFile(), l.DisableSyslog(), l.setConfigOutputs(nil, nil), l.closeSinks()}
+
+	l.runExitHooks()
+	return errors.Join(errs...)
+}
+
+// closeFile stops writing to the log file set with SetFile, if any
+func (l *Log) closeFile() error {
+	l.mu.Lock()
+	file, shared := l.file, l.sharedFile
+	l.file, l.sharedFile = nil, false
+	l.mu.Unlock()
+
+	if file == nil {
+		return nil
+	}
+	l.applyOutput()
+	if shared {
+		return nil
+	}
+	return file.Close()
+}
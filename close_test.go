@@ -0,0 +1,70 @@
+package MyLog
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// recordSink keeps the entries written to it
+type recordSink struct {
+	entries []Entry
+	closed  bool
+	err     error
+}
+
+func (s *recordSink) WriteEntries(entries []Entry) error {
+	s.entries = append(s.entries, entries...)
+	return nil
+}
+
+func (s *recordSink) Close() error {
+	s.closed = true
+	return s.err
+}
+
+func TestFlush(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	w := newGateWriter()
+	l.SetOutput(w, stdout)
+	l.EnableAsync(8, Block)
+	defer l.DisableAsync()
+
+	l.StandardInfo("queued")
+	close(w.release)
+	l.Flush()
+	if w.String() != "INFO:  queued\n" {
+		t.Errorf("output after Flush = %q", w.String())
+	}
+}
+
+func TestClose(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := l.SetFile(path, RotationConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	s := &recordSink{err: errors.New("close failed")}
+	l.AddSink(s)
+	exited := false
+	l.RegisterExitHook(func() { exited = true })
+	l.EnableAsync(8, Block)
+
+	l.StandardInfo("before")
+	if err := l.Close(); err == nil || err.Error() != "close failed" {
+		t.Errorf("Close() = %v, want the sink error", err)
+	}
+	l.StandardInfo("after")
+
+	b, err := os.ReadFile(path)
+	if err != nil || string(b) != "INFO:  before\n" {
+		t.Errorf("file = %q, %v", b, err)
+	}
+	if stdout.String() != "INFO:  before\nINFO:  after\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if !s.closed || len(s.entries) != 1 || !exited {
+		t.Errorf("sink closed %v with %d entries, exit hook run %v", s.closed, len(s.entries), exited)
+	}
+}
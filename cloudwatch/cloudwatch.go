@@ -0,0 +1,235 @@
+// Package cloudwatch sends MyLog entries to AWS CloudWatch Logs as JSON
+// log events, without going through the CloudWatch agent.
+package cloudwatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// PutLogEvents limits
+const (
+	maxBatchEvents = 10000
+	maxBatchBytes  = 1048576
+	maxEventBytes  = 262144 - eventOverhead
+	maxBatchSpan   = 24 * time.Hour
+	eventOverhead  = 26 // bytes counted per event in addition to the message
+)
+
+var errTooLarge = errors.New("cloudwatch: event too large")
+
+// Client is the part of *cloudwatchlogs.Client used by the Writer
+type Client interface {
+	PutLogEvents(ctx context.Context, in *cloudwatchlogs.PutLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogEventsOutput, error)
+	CreateLogStream(ctx context.Context, in *cloudwatchlogs.CreateLogStreamInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error)
+}
+
+// Options configures a Writer
+type Options struct {
+	Client       Client                  // e.g. cloudwatchlogs.NewFromConfig(cfg)
+	Group        string                  // log group, must exist
+	Stream       string                  // log stream, default "<hostname>-<pid>"
+	CreateStream bool                    // create the stream if it does not exist
+	Batch        MyLog.BatchOptions      // used by Attach, MaxLatency defaults to 5s
+	Resilience   MyLog.ResilienceOptions // retries when throttled, used by Attach
+}
+
+// Writer is a MyLog.Sink sending entries with PutLogEvents, in
+// chronological order and split into requests within the API limits.
+// Only throttling and unavailability are reported as retryable; if a
+// later request of a split batch fails, a retry repeats the earlier
+// ones.
+type Writer struct {
+	opts Options
+
+	mu    sync.Mutex
+	token *string // sequence token
+}
+
+// New returns a Writer, creating the stream first if requested. It
+// sends synchronously, see Attach for batching and retries.
+func New(opts Options) (*Writer, error) {
+	if opts.Client == nil || opts.Group == "" {
+		return nil, errors.New("cloudwatch: client and group are required")
+	}
+	if opts.Stream == "" {
+		host, _ := os.Hostname()
+		opts.Stream = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	if opts.Batch.MaxLatency <= 0 {
+		opts.Batch.MaxLatency = 5 * time.Second
+	}
+	if opts.Batch.MaxSize <= 0 {
+		opts.Batch.MaxSize = 1000
+	}
+	if opts.Resilience.Retries == 0 {
+		opts.Resilience.Retries = 5
+	}
+	if opts.Resilience.MinBackoff <= 0 {
+		opts.Resilience.MinBackoff = 200 * time.Millisecond
+	}
+	if opts.Resilience.MaxBackoff <= 0 {
+		opts.Resilience.MaxBackoff = 10 * time.Second
+	}
+
+	if opts.CreateStream {
+		_, err := opts.Client.CreateLogStream(context.Background(), &cloudwatchlogs.CreateLogStreamInput{
+			LogGroupName:  &opts.Group,
+			LogStreamName: &opts.Stream,
+		})
+		var exists *types.ResourceAlreadyExistsException
+		if err != nil && !errors.As(err, &exists) {
+			return nil, err
+		}
+	}
+	return &Writer{opts: opts}, nil
+}
+
+// Attach adds a Writer for all entries of l as a sink, batched and
+// with retries. l.Flush and l.Close send the queued entries.
+func Attach(l *MyLog.Log, opts Options) (*MyLog.BatchSink, error) {
+	w, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+	b := MyLog.Batch(MyLog.NewResilientSink(w, w.opts.Resilience), w.opts.Batch)
+	l.AddSink(b)
+	return b, nil
+}
+
+// WriteEntries puts entries as JSON log events. Entries that cannot be
+// marshalled are skipped and reported with MyLog.Skipped once the
+// others are sent.
+func (w *Writer) WriteEntries(entries []MyLog.Entry) error {
+	var skipped []int
+	var errs []error
+	events := make([]types.InputLogEvent, 0, len(entries))
+	for i, e := range entries {
+		msg, err := eventJSON(e)
+		if err != nil {
+			skipped, errs = append(skipped, i), append(errs, err)
+			continue
+		}
+		s, ts := string(msg), e.Time.UnixMilli()
+		events = append(events, types.InputLogEvent{Message: &s, Timestamp: &ts})
+	}
+	sort.SliceStable(events, func(i, j int) bool { return *events[i].Timestamp < *events[j].Timestamp })
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for len(events) > 0 {
+		n := batchLen(events)
+		if err := w.put(events[:n]); err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+	return MyLog.Skipped(errors.Join(errs...), skipped)
+}
+
+// eventJSON marshals e, shortening the message of entries over the
+// event size limit and marking them with the field truncated=true
+func eventJSON(e MyLog.Entry) ([]byte, error) {
+	msg, err := e.MarshalJSON()
+	if err != nil || len(msg) <= maxEventBytes {
+		return msg, err
+	}
+
+	fields := make(MyLog.Fields, len(e.Fields)+1)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields["truncated"] = true
+	e.Fields = fields
+
+	// the message gets what remains of the limit as JSON string
+	text := e.Message
+	e.Message = ""
+	empty, err := e.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	budget := maxEventBytes - len(empty)
+	if budget < 0 {
+		return nil, errTooLarge
+	}
+	for {
+		quoted, _ := json.Marshal(text)
+		size := len(quoted) - 2
+		if size <= budget {
+			break
+		}
+		n := len(text) * budget / size
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		text = text[:n]
+	}
+	e.Message = text
+	return e.MarshalJSON()
+}
+
+// Close does nothing, the client is owned by the caller
+func (w *Writer) Close() error {
+	return nil
+}
+
+// batchLen returns how many of the sorted events fit into one request
+func batchLen(events []types.InputLogEvent) int {
+	size := 0
+	first := time.UnixMilli(*events[0].Timestamp)
+	for i, ev := range events {
+		size += len(*ev.Message) + eventOverhead
+		if i == maxBatchEvents || size > maxBatchBytes || time.UnixMilli(*ev.Timestamp).Sub(first) >= maxBatchSpan {
+			return i
+		}
+	}
+	return len(events)
+}
+
+// put sends one request, once more with the expected token after a
+// sequence token error. Errors other than throttling and unavailability
+// are permanent.
+func (w *Writer) put(events []types.InputLogEvent) error {
+	for try := 0; ; try++ {
+		out, err := w.opts.Client.PutLogEvents(context.Background(), &cloudwatchlogs.PutLogEventsInput{
+			LogGroupName:  &w.opts.Group,
+			LogStreamName: &w.opts.Stream,
+			LogEvents:     events,
+			SequenceToken: w.token,
+		})
+		if err == nil {
+			w.token = out.NextSequenceToken
+			return nil
+		}
+
+		var (
+			invalid   *types.InvalidSequenceTokenException
+			accepted  *types.DataAlreadyAcceptedException
+			throttled *types.ThrottlingException
+			down      *types.ServiceUnavailableException
+		)
+		switch {
+		case errors.As(err, &accepted):
+			w.token = accepted.ExpectedSequenceToken
+			return nil
+		case errors.As(err, &invalid) && try == 0:
+			w.token = invalid.ExpectedSequenceToken
+		case errors.As(err, &throttled), errors.As(err, &down):
+			return err
+		default:
+			return MyLog.Permanent(err)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package cloudwatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// fakeClient records the requests and fails with the queued errors
+type fakeClient struct {
+	puts      []*cloudwatchlogs.PutLogEventsInput
+	errs      []error
+	createErr error
+	created   bool
+}
+
+func (c *fakeClient) PutLogEvents(_ context.Context, in *cloudwatchlogs.PutLogEventsInput, _ ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	c.puts = append(c.puts, in)
+	if len(c.errs) > 0 {
+		err := c.errs[0]
+		c.errs = c.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	token := "next"
+	return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: &token}, nil
+}
+
+func (c *fakeClient) CreateLogStream(context.Context, *cloudwatchlogs.CreateLogStreamInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	c.created = true
+	return &cloudwatchlogs.CreateLogStreamOutput{}, c.createErr
+}
+
+func TestWriteEntries(t *testing.T) {
+	c := new(fakeClient)
+	w, err := New(Options{Client: c, Group: "app", Stream: "web1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := time.Now()
+	err = w.WriteEntries([]MyLog.Entry{
+		{Time: base.Add(time.Second), Level: MyLog.LevelWarn, Message: "second"},
+		{Time: base, Level: MyLog.LevelInfo, Message: "first"},
+		{Time: base.Add(25 * time.Hour), Level: MyLog.LevelError, Message: "next day"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// events more than 24h apart need separate requests
+	if len(c.puts) != 2 || len(c.puts[0].LogEvents) != 2 || len(c.puts[1].LogEvents) != 1 {
+		t.Fatalf("puts = %+v", c.puts)
+	}
+	if *c.puts[0].LogGroupName != "app" || *c.puts[0].LogStreamName != "web1" || c.puts[0].SequenceToken != nil {
+		t.Errorf("first request = %+v", c.puts[0])
+	}
+	if *c.puts[0].LogEvents[0].Timestamp != base.UnixMilli() {
+		t.Error("events not sorted by time")
+	}
+	if c.puts[1].SequenceToken == nil || *c.puts[1].SequenceToken != "next" {
+		t.Error("sequence token not passed on")
+	}
+}
+
+func TestInvalidSequenceToken(t *testing.T) {
+	expected := "expected"
+	c := &fakeClient{errs: []error{&types.InvalidSequenceTokenException{ExpectedSequenceToken: &expected}}}
+	w, _ := New(Options{Client: c, Group: "app"})
+
+	if err := w.WriteEntries([]MyLog.Entry{{Time: time.Now()}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(c.puts) != 2 || *c.puts[1].SequenceToken != "expected" {
+		t.Errorf("puts = %+v", c.puts)
+	}
+}
+
+func TestPutErrors(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{&types.ThrottlingException{}, true},
+		{&types.ServiceUnavailableException{}, true},
+		{&types.ResourceNotFoundException{}, false},
+	}
+	for _, tt := range tests {
+		c := &fakeClient{errs: []error{tt.err, tt.err, tt.err, tt.err}}
+		w, _ := New(Options{Client: c, Group: "app"})
+		r := MyLog.NewResilientSink(w, MyLog.ResilienceOptions{Retries: 1, MinBackoff: time.Millisecond})
+
+		if err := r.WriteEntries([]MyLog.Entry{{Time: time.Now()}}); err == nil {
+			t.Errorf("%T: no error", tt.err)
+		}
+		if want := map[bool]int{true: 2, false: 1}[tt.retryable]; len(c.puts) != want {
+			t.Errorf("%T: %d requests, want %d", tt.err, len(c.puts), want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Options{Group: "app"}); err == nil {
+		t.Error("New without client succeeded")
+	}
+
+	c := &fakeClient{createErr: &types.ResourceAlreadyExistsException{}}
+	if _, err := New(Options{Client: c, Group: "app", CreateStream: true}); err != nil || !c.created {
+		t.Errorf("existing stream: %v, created %v", err, c.created)
+	}
+	c = &fakeClient{createErr: errors.New("denied")}
+	if _, err := New(Options{Client: c, Group: "app", CreateStream: true}); err == nil {
+		t.Error("failed CreateLogStream ignored")
+	}
+}
+
+func TestEventTooLarge(t *testing.T) {
+	e := MyLog.Entry{Time: time.Now(), Level: MyLog.LevelInfo, Message: strings.Repeat("ä<", maxEventBytes), Fields: MyLog.Fields{"k": "v"}}
+	msg, err := eventJSON(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// "ä<" takes 8 bytes as JSON
+	var m struct {
+		Message string
+		Fields  map[string]interface{}
+	}
+	if len(msg) > maxEventBytes || !utf8.Valid(msg) || json.Unmarshal(msg, &m) != nil {
+		t.Fatalf("event of %d bytes is not valid JSON within the limit", len(msg))
+	}
+	if s := m.Message; m.Fields["truncated"] != true || m.Fields["k"] != "v" || !strings.HasPrefix(e.Message, s) || len(s) < maxEventBytes/4 {
+		t.Errorf("truncated event: fields %v, message of %d bytes", m.Fields, len(s))
+	}
+	if len(e.Fields) != 1 {
+		t.Error("eventJSON changed the fields of the entry")
+	}
+
+	// fields alone over the limit cannot be shortened
+	e.Fields = MyLog.Fields{"big": strings.Repeat("x", maxEventBytes)}
+	if _, err := eventJSON(e); !errors.Is(err, errTooLarge) {
+		t.Errorf("eventJSON() = %v, want %v", err, errTooLarge)
+	}
+}
@@ -0,0 +1,107 @@
+package MyLog
+
+import (
+	"io"
+	"os"
+)
+
+// isTerminal reports whether w writes to a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	return isTerminalFd(f.Fd())
+}
+
+// colorSupported reports whether colored output is appropriate: both
+// writers are terminals, NO_COLOR is not set and TERM is not "dumb"
+func (l *Log) colorSupported() bool {
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.tty
+}
+
+// SetColorAuto enables LgColor only if colored output is appropriate,
+// see colorSupported. The decision is updated whenever SetOutput is
+// called, until SetColor is used to set the mode explicitly.
+func (l *Log) SetColorAuto() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	l.colorAuto = true
+	l.mu.Unlock()
+
+	l.setColor(l.colorSupported())
+}
+
+// SetColor explicitly enables or disables color mode, including
+// the colored prefixes. It overrides SetColorAuto.
+func (l *Log) SetColor(b bool) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	l.colorAuto = false
+	l.mu.Unlock()
+
+	l.setColor(b)
+}
+
+func (l *Log) setColor(b bool) {
+	l.SetModeBool(LgColor, b)
+
+	l.mu.RLock()
+	colored := l.coloredPrefix
+	l.mu.RUnlock()
+
+	if b {
+		l.SetColorPrefix()
+	} else if colored {
+		l.setPlainPrefix()
+	}
+}
+
+// ColorScope selects the parts of an entry colored in color mode
+type ColorScope uint8
+
+const (
+	ColorBoth        ColorScope = iota // level prefix and message
+	ColorPrefixOnly                    // only the level prefix
+	ColorMessageOnly                   // only the message
+)
+
+// SetColorScope sets the parts of an entry that are colored in color
+// mode and by outputs with OutputOptions.Color; the default is
+// ColorBoth
+func (l *Log) SetColorScope(s ColorScope) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	l.colorScope = s
+	colored := l.coloredPrefix
+	l.mu.Unlock()
+
+	switch {
+	case s == ColorMessageOnly && colored:
+		l.setPlainPrefix()
+	case l.modeHas(LgColor):
+		l.SetColorPrefix()
+	}
+}
+
+// messagePaint returns paint if the message of an entry is colored,
+// for the main writers if main is set, otherwise for the outputs;
+// l.mu must be held
+func (l *Log) messagePaint(paint paintFunc, main bool) paintFunc {
+	if l.colorScope == ColorPrefixOnly || main && l.modeRegister&LgColor == 0 {
+		return plain
+	}
+	return paint
+}
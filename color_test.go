@@ -0,0 +1,116 @@
+package MyLog
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsTerminal(t *testing.T) {
+	if isTerminal(new(syncBuffer)) {
+		t.Error("a buffer is a terminal")
+	}
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Skip(err)
+	}
+	defer f.Close()
+	if isTerminal(f) {
+		t.Errorf("%s is a terminal", os.DevNull)
+	}
+}
+
+func TestSetColorAuto(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm")
+
+	l, _, _ := newTestLog()
+	l.SetColorAuto()
+	if l.HasMode(LgColor) {
+		t.Error("color enabled for buffers")
+	}
+
+	// pretend both writers are terminals
+	l.mu.Lock()
+	l.tty = true
+	l.mu.Unlock()
+	l.SetColorAuto()
+	if !l.HasMode(LgColor) {
+		t.Error("color disabled on a terminal")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	l.SetColorAuto()
+	if l.HasMode(LgColor) {
+		t.Error("color enabled with NO_COLOR set")
+	}
+
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "dumb")
+	l.SetColorAuto()
+	if l.HasMode(LgColor) {
+		t.Error("color enabled with TERM=dumb")
+	}
+}
+
+func TestSetColorOverridesAuto(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.SetColorAuto()
+	l.SetColor(true)
+	l.SetOutput(new(syncBuffer), new(syncBuffer))
+	if !l.HasMode(LgColor) {
+		t.Error("SetOutput re-evaluated an explicit SetColor")
+	}
+}
+
+func TestColorScope(t *testing.T) {
+	tests := []struct {
+		scope ColorScope
+		want  string
+	}{
+		{ColorBoth, "\x1b[33mWARN:  \x1b[0m\x1b[33mdisk full\x1b[0m\n"},
+		{ColorPrefixOnly, "\x1b[33mWARN:  \x1b[0mdisk full\n"},
+		{ColorMessageOnly, "WARN:  \x1b[33mdisk full\x1b[0m\n"},
+	}
+	for _, tt := range tests {
+		l, out := coloredOutput(t)
+		l.SetColorScope(tt.scope)
+		l.Warn("disk full")
+		if out.String() != tt.want {
+			t.Errorf("scope %d: %q, want %q", tt.scope, out.String(), tt.want)
+		}
+	}
+}
+
+func TestMessagePaint(t *testing.T) {
+	forceColor(t)
+	l, _, _ := newTestLog()
+	red := l.painter(LevelError)
+	painted := func(main bool) bool {
+		l.mu.RLock()
+		defer l.mu.RUnlock()
+		return l.messagePaint(red, main)("x") != "x"
+	}
+
+	// without color mode only the outputs color messages
+	if painted(true) || !painted(false) {
+		t.Error("main writers colored without LgColor")
+	}
+	l.SetColor(true)
+	if !painted(true) {
+		t.Error("main writers not colored with LgColor")
+	}
+	l.SetColorScope(ColorPrefixOnly)
+	if painted(true) || painted(false) {
+		t.Error("messages colored with ColorPrefixOnly")
+	}
+
+	// the prefixes follow the scope
+	l.SetColorScope(ColorMessageOnly)
+	if p := l.warningVar.Prefix(); p != "WARN:  " {
+		t.Errorf("prefix with ColorMessageOnly = %q", p)
+	}
+	l.SetColorScope(ColorBoth)
+	if p := l.warningVar.Prefix(); p == "WARN:  " {
+		t.Error("prefix not colored with ColorBoth")
+	}
+}
@@ -0,0 +1,85 @@
+package MyLog
+
+import "fmt"
+
+// InfoIf writes an info message like StandardInfo if cond is true
+func (l *Log) InfoIf(cond bool, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if cond {
+		l.StandardInfo(format, v...)
+	}
+}
+
+// WarnIf writes a warning like Warn if cond is true
+func (l *Log) WarnIf(cond bool, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if cond {
+		l.Warn(format, v...)
+	}
+}
+
+// ErrorIfErr writes an error message like Error if err is not nil,
+// followed by ": " and err, and reports whether it did
+func (l *Log) ErrorIfErr(err error, format string, v ...interface{}) bool {
+	if err == nil {
+		return false
+	}
+	if l.ready() {
+		l.Error("%s: %v", fmt.Sprintf(format, v...), err)
+	}
+	return true
+}
+
+// InfoOnce writes an info message like StandardInfo, but only the
+// first time for each format
+func (l *Log) InfoOnce(format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if l.first(LevelInfo, format) {
+		l.StandardInfo(format, v...)
+	}
+}
+
+// WarnOnce writes a warning like Warn, but only the first time for
+// each format
+func (l *Log) WarnOnce(format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if l.first(LevelWarn, format) {
+		l.Warn(format, v...)
+	}
+}
+
+// ErrorOnce writes an error message like Error, but only the first
+// time for each format
+func (l *Log) ErrorOnce(format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if l.first(LevelError, format) {
+		l.Error(format, v...)
+	}
+}
+
+// first reports whether format is used for the first time with lv
+// by a Once variant
+func (l *Log) first(lv Level, format string) bool {
+	key := lv.String() + "\x00" + format
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.onceSeen[key]; ok {
+		return false
+	}
+	if l.onceSeen == nil {
+		l.onceSeen = make(map[string]struct{})
+	}
+	l.onceSeen[key] = struct{}{}
+	return true
+}
@@ -0,0 +1,61 @@
+package MyLog
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestConditional(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+
+	l.InfoIf(false, "hidden")
+	l.InfoIf(true, "info %d", 1)
+	l.WarnIf(false, "hidden")
+	l.WarnIf(true, "warn")
+	if l.ErrorIfErr(nil, "hidden") {
+		t.Error("ErrorIfErr(nil) = true")
+	}
+	if !l.ErrorIfErr(errors.New("denied"), "open %s", "f") {
+		t.Error("ErrorIfErr(err) = false")
+	}
+
+	if stdout.String() != "INFO:  info 1\nWARN:  warn\n" || stderr.String() != "ERROR: open f: denied\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	var nl *Log
+	if !nl.ErrorIfErr(errors.New("x"), "nil") {
+		t.Error("ErrorIfErr on a nil Log does not report the error")
+	}
+}
+
+func TestOnce(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			l.WarnOnce("deprecated option %d", i)
+			l.InfoOnce("deprecated option %d", i)
+		}(i)
+	}
+	wg.Wait()
+	l.ErrorOnce("failed")
+	l.ErrorOnce("failed")
+
+	if got := stdout.lines(); len(got) != 2 {
+		t.Errorf("stdout = %q, want one warning and one info", got)
+	}
+	if stderr.String() != "ERROR: failed\n" {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+
+	// derived loggers share what was seen
+	l.WithField("k", 1).ErrorOnce("failed")
+	if stderr.String() != "ERROR: failed\n" {
+		t.Errorf("derived logger repeated the message: %q", stderr.String())
+	}
+}
@@ -0,0 +1,300 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Config declares the settings of a Log, e.g. in a configuration file.
+// Zero values select the defaults.
+type Config struct {
+	Level        string         `json:"level" yaml:"level" toml:"level"`                   // e.g. "info"
+	Modules      string         `json:"modules" yaml:"modules" toml:"modules"`             // e.g. "db=debug,*=info"
+	Verbosity    int            `json:"verbosity" yaml:"verbosity" toml:"verbosity"`       // see SetVerbosity
+	Debug        bool           `json:"debug" yaml:"debug" toml:"debug"`                   // enables LgDebug
+	Format       string         `json:"format" yaml:"format" toml:"format"`                // text, json or logfmt
+	Color        string         `json:"color" yaml:"color" toml:"color"`                   // auto, always or never
+	TimeFormat   string         `json:"time_format" yaml:"time_format" toml:"time_format"` // see SetTimeFormat
+	Layout       string         `json:"layout" yaml:"layout" toml:"layout"`                // see SetLayout, replaces Format
+	ReportCaller bool           `json:"report_caller" yaml:"report_caller" toml:"report_caller"`
+	Buffer       BufferConfig   `json:"buffer" yaml:"buffer" toml:"buffer"`
+	File         FileConfig     `json:"file" yaml:"file" toml:"file"`
+	Outputs      []OutputConfig `json:"outputs" yaml:"outputs" toml:"outputs"`
+}
+
+// BufferConfig enables the entry buffer
+type BufferConfig struct {
+	Enabled bool `json:"enabled" yaml:"enabled" toml:"enabled"`
+	Size    int  `json:"size" yaml:"size" toml:"size"` // see EnableBufferN, 0 is unbounded
+}
+
+// FileConfig sets the log file, see SetFile
+type FileConfig struct {
+	Path       string `json:"path" yaml:"path" toml:"path"` // no file if empty
+	MaxSizeMB  int    `json:"max_size_mb" yaml:"max_size_mb" toml:"max_size_mb"`
+	MaxBackups int    `json:"max_backups" yaml:"max_backups" toml:"max_backups"`
+	MaxAgeDays int    `json:"max_age_days" yaml:"max_age_days" toml:"max_age_days"`
+	Compress   bool   `json:"compress" yaml:"compress" toml:"compress"`
+	Interval   string `json:"interval" yaml:"interval" toml:"interval"` // rotation period like "24h"
+}
+
+// OutputConfig adds an output, see AddOutput
+type OutputConfig struct {
+	Path     string `json:"path" yaml:"path" toml:"path"`       // "stdout", "stderr" or a file
+	Format   string `json:"format" yaml:"format" toml:"format"` // text, json or logfmt
+	Color    bool   `json:"color" yaml:"color" toml:"color"`
+	MinLevel string `json:"min_level" yaml:"min_level" toml:"min_level"`
+}
+
+var (
+	configFormatsMu sync.RWMutex
+	configFormats   = map[string]func(data []byte, v interface{}) error{".json": json.Unmarshal}
+)
+
+// RegisterConfigFormat makes LoadConfig decode files with the extension
+// ext, like ".yaml", using unmarshal. JSON is built in; importing
+// mylogyaml or mylogtoml registers YAML or TOML.
+func RegisterConfigFormat(ext string, unmarshal func(data []byte, v interface{}) error) {
+	configFormatsMu.Lock()
+	defer configFormatsMu.Unlock()
+	configFormats[strings.ToLower(ext)] = unmarshal
+}
+
+// LoadConfig reads a configuration file in JSON or a format registered
+// with RegisterConfigFormat, selected by its extension
+func LoadConfig(path string) (Config, error) {
+	var cfg Config
+
+	configFormatsMu.RLock()
+	unmarshal := configFormats[strings.ToLower(filepath.Ext(path))]
+	configFormatsMu.RUnlock()
+	if unmarshal == nil {
+		return cfg, fmt.Errorf("unknown config file type %q", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err = unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// NewFromConfig returns a Log writing to os.Stdout and os.Stderr with
+// the settings of cfg
+func NewFromConfig(cfg Config) (*Log, error) {
+	l := new(Log)
+	l.Init(os.Stdout, os.Stderr)
+	if err := l.ApplyConfig(cfg); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ApplyConfig replaces the settings of l with cfg. Outputs added by an
+// earlier ApplyConfig are replaced, those added with AddOutput are kept.
+// Nothing is changed if cfg is invalid.
+func (l *Log) ApplyConfig(cfg Config) error {
+	if !l.ready() {
+		return nil
+	}
+	lv, err := parseOr(cfg.Level, ParseLevel, LevelInfo)
+	if err != nil {
+		return err
+	}
+	format, err := parseOr(cfg.Format, ParseFormat, FormatText)
+	if err != nil {
+		return err
+	}
+	layout, err := l.parseLayout(cfg.Layout)
+	if err != nil {
+		return err
+	}
+
+	var interval time.Duration
+	if cfg.File.Interval != "" {
+		if interval, err = time.ParseDuration(cfg.File.Interval); err != nil {
+			return fmt.Errorf("invalid rotation interval %q", cfg.File.Interval)
+		}
+	}
+
+	outputs := make([]output, 0, len(cfg.Outputs))
+	var files []*sharedCloser
+	fail := func(err error) error {
+		for _, f := range files {
+			f.Close()
+		}
+		return err
+	}
+	for _, oc := range cfg.Outputs {
+		o, f, err := oc.open()
+		if err != nil {
+			return fail(err)
+		}
+		if f != nil {
+			files = append(files, &sharedCloser{c: f, refs: 1})
+		}
+		outputs = append(outputs, o)
+	}
+
+	modules, err := parseModuleLevels(cfg.Modules)
+	if err != nil {
+		return fail(err)
+	}
+	if cfg.Color == "" {
+		cfg.Color = "auto"
+	}
+	applyColor, err := parseColorChoice(cfg.Color)
+	if err != nil {
+		return fail(err)
+	}
+
+	var file *rotatingFile
+	if cfg.File.Path != "" {
+		file, err = openRotatingFile(cfg.File.Path, RotationConfig{
+			MaxSizeMB:  cfg.File.MaxSizeMB,
+			MaxBackups: cfg.File.MaxBackups,
+			MaxAgeDays: cfg.File.MaxAgeDays,
+			Compress:   cfg.File.Compress,
+			Interval:   interval,
+		})
+		if err != nil {
+			return fail(err)
+		}
+	}
+
+	// from here on cfg is valid and every setting is applied
+	var errs []error
+	if file != nil {
+		errs = append(errs, l.setFile(file))
+	} else {
+		errs = append(errs, l.closeFile())
+	}
+
+	l.mu.Lock()
+	l.moduleLevels = modules
+	l.mu.Unlock()
+	applyColor(l)
+
+	l.SetLevel(lv)
+	l.SetVerbosity(cfg.Verbosity)
+	l.SetModeBool(LgDebug, cfg.Debug)
+	l.SetFormat(format)
+	l.SetFormatter(layout)
+	l.SetTimeFormat(cfg.TimeFormat)
+	l.SetReportCaller(cfg.ReportCaller)
+
+	if cfg.Buffer.Enabled {
+		l.EnableBufferN(cfg.Buffer.Size)
+	} else {
+		l.DisableBuffer()
+	}
+
+	errs = append(errs, l.setConfigOutputs(outputs, files))
+	return errors.Join(errs...)
+}
+
+// parseOr parses s, or returns def if s is empty
+func parseOr[T any](s string, parse func(string) (T, error), def T) (T, error) {
+	if s == "" {
+		return def, nil
+	}
+	return parse(s)
+}
+
+// open creates the output described by oc. It returns the file to close
+// when the output is removed, if any.
+func (oc OutputConfig) open() (output, io.Closer, error) {
+	format, err := parseOr(oc.Format, ParseFormat, FormatText)
+	if err != nil {
+		return output{}, nil, err
+	}
+	lv, err := parseOr(oc.MinLevel, ParseLevel, LevelTrace)
+	if err != nil {
+		return output{}, nil, err
+	}
+
+	opts := OutputOptions{Format: format, Color: oc.Color, Flags: log.LstdFlags, MinLevel: lv}
+
+	var w io.Writer
+	var f io.Closer
+	switch oc.Path {
+	case "stdout":
+		w = os.Stdout
+	case "stderr":
+		w = os.Stderr
+	case "":
+		return output{}, nil, errors.New("output without path")
+	default:
+		rf, err := openRotatingFile(oc.Path, RotationConfig{})
+		if err != nil {
+			return output{}, nil, err
+		}
+		w, f = rf, rf
+	}
+
+	if !opts.Color {
+		w = plainUnlessTerminal(w)
+	}
+	return output{w: w, opts: opts, fromConfig: true}, f, nil
+}
+
+// sharedCloser is a file of a config output shared by clones, which is
+// closed once all of them have closed it
+type sharedCloser struct {
+	c    io.Closer
+	mu   sync.Mutex
+	refs int
+}
+
+func (s *sharedCloser) acquire() *sharedCloser {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refs++
+	return s
+}
+
+func (s *sharedCloser) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.refs--; s.refs == 0 {
+		return s.c.Close()
+	}
+	return nil
+}
+
+// setConfigOutputs replaces the outputs of an earlier ApplyConfig and
+// closes their files
+func (l *Log) setConfigOutputs(outputs []output, files []*sharedCloser) error {
+	l.mu.Lock()
+	kept := make([]output, 0, len(l.outputs)+len(outputs))
+	for _, o := range l.outputs {
+		if !o.fromConfig {
+			kept = append(kept, o)
+		}
+	}
+	for _, o := range outputs {
+		o.w = l.locked(o.w)
+		kept = append(kept, o)
+	}
+	l.outputs = kept
+	old := l.configFiles
+	l.configFiles = files
+	l.mu.Unlock()
+
+	var errs []error
+	for _, f := range old {
+		errs = append(errs, f.Close())
+	}
+	return errors.Join(errs...)
+}
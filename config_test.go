@@ -0,0 +1,122 @@
+package MyLog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, name, data string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := writeFile(t, "log.JSON", `{"level": "warn", "modules": "db=debug", "buffer": {"enabled": true, "size": 5},
+		"outputs": [{"path": "stderr", "format": "json", "min_level": "error"}]}`)
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Level != "warn" || cfg.Modules != "db=debug" || cfg.Buffer.Size != 5 || len(cfg.Outputs) != 1 || cfg.Outputs[0].MinLevel != "error" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+
+	if _, err := LoadConfig(writeFile(t, "log.json", "{")); err == nil || !strings.Contains(err.Error(), "log.json") {
+		t.Errorf("invalid JSON: %v", err)
+	}
+	if _, err := LoadConfig(writeFile(t, "log.ini", "")); err == nil {
+		t.Error("unknown extension accepted")
+	}
+}
+
+func TestRegisterConfigFormat(t *testing.T) {
+	RegisterConfigFormat(".LEVEL", func(data []byte, v interface{}) error {
+		v.(*Config).Level = strings.TrimSpace(string(data))
+		return nil
+	})
+	t.Cleanup(func() {
+		configFormatsMu.Lock()
+		delete(configFormats, ".level")
+		configFormatsMu.Unlock()
+	})
+
+	cfg, err := LoadConfig(writeFile(t, "app.level", "debug\n"))
+	if err != nil || cfg.Level != "debug" {
+		t.Errorf("cfg = %+v, %v", cfg, err)
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	kept := new(syncBuffer)
+	l.AddOutput(kept, OutputOptions{})
+	dir := t.TempDir()
+
+	cfg := Config{
+		Level:  "warn",
+		Debug:  true,
+		Format: "logfmt",
+		Color:  "never",
+		Buffer: BufferConfig{Enabled: true, Size: 2},
+		File:   FileConfig{Path: filepath.Join(dir, "app.log")},
+		Outputs: []OutputConfig{
+			{Path: filepath.Join(dir, "errors.log"), MinLevel: "error"},
+		},
+	}
+	if err := l.ApplyConfig(cfg); err != nil {
+		t.Fatal(err)
+	}
+	if l.GetLevel() != LevelWarn || !l.HasMode(LgDebug) || l.GetFormat() != FormatLogfmt || !l.HasMode(LgBuffer) {
+		t.Errorf("level %v, mode %b, format %v", l.GetLevel(), l.GetMode(), l.GetFormat())
+	}
+
+	l.Warn("warn")
+	l.Error("error")
+	if err := l.ApplyConfig(Config{}); err != nil {
+		t.Fatal(err)
+	}
+	l.Error("after")
+
+	if !strings.Contains(stdout.String(), "msg=warn") || len(kept.lines()) != 3 {
+		t.Errorf("stdout = %q, kept output = %q", stdout.String(), kept.String())
+	}
+	b, _ := os.ReadFile(filepath.Join(dir, "errors.log"))
+	if n := strings.Count(string(b), "\n"); n != 1 || !strings.Contains(string(b), "error") {
+		t.Errorf("errors.log = %q, want the error entry only", b)
+	}
+	b, _ = os.ReadFile(filepath.Join(dir, "app.log"))
+	if n := strings.Count(string(b), "\n"); n != 2 {
+		t.Errorf("app.log = %q, want the entries before the second ApplyConfig", b)
+	}
+}
+
+func TestApplyConfigInvalid(t *testing.T) {
+	dir := t.TempDir()
+	tests := []Config{
+		{Level: "loud"},
+		{Format: "xml"},
+		{Color: "sometimes"},
+		{Modules: "db"},
+		{File: FileConfig{Path: filepath.Join(dir, "a.log"), Interval: "daily"}},
+		{Outputs: []OutputConfig{{Path: filepath.Join(dir, "b.log")}, {Path: ""}}},
+		{Outputs: []OutputConfig{{Path: filepath.Join(dir, "c.log")}}, Modules: "=debug"},
+	}
+	for _, cfg := range tests {
+		l, _, _ := newTestLog()
+		l.SetLevel(LevelError)
+		if err := l.ApplyConfig(cfg); err == nil {
+			t.Errorf("%+v accepted", cfg)
+		}
+		if l.GetLevel() != LevelError {
+			t.Errorf("%+v changed the level", cfg)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a.log")); err == nil {
+		t.Error("the file of an invalid config was opened")
+	}
+}
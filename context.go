@@ -0,0 +1,121 @@
+package MyLog
+
+import "context"
+
+type ctxKey struct{}
+
+// IntoContext returns a copy of ctx carrying l
+func IntoContext(ctx context.Context, l *Log) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the Log stored with IntoContext,
+// or the package default Log if there is none
+func FromContext(ctx context.Context) *Log {
+	if l, ok := ctx.Value(ctxKey{}).(*Log); ok && l != nil {
+		return l
+	}
+	return Default()
+}
+
+// ContextExtractor returns fields to log for a context, e.g. a trace
+// or request ID stored there by some middleware
+type ContextExtractor func(ctx context.Context) Fields
+
+// SetContextExtractor sets a function whose fields are added to every
+// entry logged through the Ctx variants. A nil fn removes it.
+func (l *Log) SetContextExtractor(fn ContextExtractor) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ctxExtractor = fn
+}
+
+// ContextValueExtractor returns a ContextExtractor logging the value
+// stored under key in the context as field, if there is one
+func ContextValueExtractor(field string, key interface{}) ContextExtractor {
+	return func(ctx context.Context) Fields {
+		if v := ctx.Value(key); v != nil {
+			return Fields{field: v}
+		}
+		return nil
+	}
+}
+
+// withContext returns l extended by the fields of the Log in ctx, if
+// any, and by the fields of the context extractor
+func (l *Log) withContext(ctx context.Context) *Log {
+	if ctx == nil {
+		return l
+	}
+
+	if cl, ok := ctx.Value(ctxKey{}).(*Log); ok && cl != nil && len(cl.fields) > 0 {
+		l = l.WithFields(cl.fields)
+	}
+
+	l.mu.RLock()
+	extract := l.ctxExtractor
+	l.mu.RUnlock()
+
+	if extract != nil {
+		if f := extract(ctx); len(f) > 0 {
+			l = l.WithFields(f)
+		}
+	}
+
+	return l
+}
+
+// Context variants of the user functions. They log through l, adding
+// the fields of the Log stored in ctx with IntoContext and those of
+// the context extractor.
+func (l *Log) StandardCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.withContext(ctx).Standard(format, v...)
+}
+
+func (l *Log) InfoCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.withContext(ctx).StandardInfo(format, v...)
+}
+
+func (l *Log) VerboseCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.withContext(ctx).Verbose(format, v...)
+}
+
+func (l *Log) TraceCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.withContext(ctx).Trace(format, v...)
+}
+
+func (l *Log) DebugCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.withContext(ctx).Debug(format, v...)
+}
+
+func (l *Log) WarnCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.withContext(ctx).Warn(format, v...)
+}
+
+func (l *Log) ErrorCtx(ctx context.Context, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.withContext(ctx).Error(format, v...)
+}
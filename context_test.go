@@ -0,0 +1,54 @@
+package MyLog
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	l, _, _ := newTestLog()
+	useDefault(t, l)
+
+	if FromContext(context.Background()) != l {
+		t.Error("FromContext without a Log did not return the default")
+	}
+	c := l.Named("req")
+	if FromContext(IntoContext(context.Background(), c)) != c {
+		t.Error("FromContext did not return the stored Log")
+	}
+}
+
+func TestCtxMethods(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	ctx := IntoContext(context.Background(), l.WithField("request", 7))
+
+	l.InfoCtx(ctx, "served %s", "/")
+	l.ErrorCtx(ctx, "failed")
+	l.WarnCtx(nil, "no context")
+	if want := "INFO:  served / request=7\nWARN:  no context\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if want := "ERROR: failed request=7\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+}
+
+type traceKey struct{}
+
+func TestContextExtractor(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetContextExtractor(ContextValueExtractor("trace_id", traceKey{}))
+
+	ctx := context.WithValue(context.Background(), traceKey{}, "abc123")
+	l.InfoCtx(ctx, "traced")
+	l.InfoCtx(context.Background(), "untraced")
+	l.StandardInfo("plain")
+
+	l.SetContextExtractor(nil)
+	l.InfoCtx(ctx, "removed")
+
+	want := "INFO:  traced trace_id=abc123\nINFO:  untraced\nINFO:  plain\nINFO:  removed\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
@@ -0,0 +1,114 @@
+package MyLog
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// deduper collapses consecutive identical entries
+type deduper struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	lg      *log.Logger
+	paint   paintFunc
+	last    *Entry
+	key     string
+	repeats int
+	timer   *time.Timer
+}
+
+// SetDedup collapses consecutive identical entries: repeats are counted
+// instead of written, and a "last message repeated N times" line follows
+// once a different entry arrives or window has elapsed since the first
+// repeat. A window of 0 disables deduplication.
+func (l *Log) SetDedup(window time.Duration) {
+	if !l.ready() {
+		return
+	}
+	var d *deduper
+	if window > 0 {
+		d = &deduper{window: window}
+	}
+
+	l.mu.Lock()
+	old := l.dedup
+	l.dedup = d
+	l.mu.Unlock()
+
+	l.flushDedup(old)
+}
+
+// deduped reports whether e has to be written, after writing the repeat
+// summary of the previous entry if e differs from it
+func (l *Log) deduped(lg *log.Logger, paint paintFunc, e *Entry) bool {
+	l.mu.RLock()
+	d := l.dedup
+	l.mu.RUnlock()
+
+	if d == nil {
+		return true
+	}
+
+	key := e.Prefix + "\x00" + e.text()
+
+	d.mu.Lock()
+	if d.last != nil && key == d.key && e.Level == d.last.Level {
+		d.repeats++
+		l.stats.deduped.Add(1)
+		if d.timer == nil {
+			d.timer = time.AfterFunc(d.window, func() { l.flushDedup(d) })
+		}
+		d.mu.Unlock()
+		return false
+	}
+
+	s, slg, spaint := d.summary(), d.lg, d.paint
+	d.lg, d.paint, d.last, d.key = lg, paint, e, key
+	d.mu.Unlock()
+
+	// delivered without d.mu, hooks and sinks may log through l
+	if s != nil {
+		l.deliver(slg, spaint, s)
+	}
+	return true
+}
+
+// flushDedup writes the pending repeat summary of d, if any
+func (l *Log) flushDedup(d *deduper) {
+	if d == nil {
+		return
+	}
+
+	d.mu.Lock()
+	s, lg, paint := d.summary(), d.lg, d.paint
+	d.mu.Unlock()
+
+	if s != nil {
+		l.deliver(lg, paint, s)
+	}
+}
+
+// summary returns the entry reporting the repeats of the last entry and
+// resets the counter, or nil if there were none
+func (d *deduper) summary() *Entry {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	if d.repeats == 0 {
+		return nil
+	}
+
+	s := &Entry{
+		Time:    time.Now(),
+		Level:   d.last.Level,
+		Prefix:  d.last.Prefix,
+		Name:    d.last.Name,
+		Message: fmt.Sprintf("last message repeated %d times", d.repeats),
+	}
+	d.repeats = 0
+	return s
+}
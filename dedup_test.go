@@ -0,0 +1,52 @@
+package MyLog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetDedup(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetDedup(time.Hour)
+
+	for i := 0; i < 3; i++ {
+		l.Warn("disk full")
+	}
+	l.StandardInfo("disk full")
+	l.StandardInfo("other")
+	l.Flush()
+
+	want := "WARN:  disk full\nWARN:  last message repeated 2 times\nINFO:  disk full\nINFO:  other\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestDedupWindow(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetDedup(10 * time.Millisecond)
+
+	l.Warn("disk full")
+	l.Warn("disk full")
+	deadline := time.Now().Add(5 * time.Second)
+	for len(stdout.lines()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if want := "WARN:  disk full\nWARN:  last message repeated 1 times\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestDedupDisable(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetDedup(time.Hour)
+	l.Warn("disk full")
+	l.Warn("disk full")
+	l.SetDedup(0)
+	l.Warn("disk full")
+
+	want := "WARN:  disk full\nWARN:  last message repeated 1 times\nWARN:  disk full\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
@@ -0,0 +1,181 @@
+package MyLog
+
+import (
+	"os"
+	"sync/atomic"
+)
+
+// defaultLog is used by the package level functions
+var defaultLog atomic.Pointer[Log]
+
+// Default returns the package default Log. Unless set with SetDefault,
+// it is initialized to os.Stdout and os.Stderr on first use.
+func Default() *Log {
+	for {
+		if l := defaultLog.Load(); l != nil {
+			return l
+		}
+
+		l := new(Log)
+		l.Init(os.Stdout, os.Stderr)
+		if defaultLog.CompareAndSwap(nil, l) {
+			return l
+		}
+	}
+}
+
+// SetDefault replaces the package default Log. Passing nil makes
+// Default initialize a new one on next use.
+func SetDefault(l *Log) {
+	defaultLog.Store(l)
+}
+
+// Mode handling of the default Log
+func SetVerbose(b bool) {
+	Default().SetModeBool(LgVerbose, b)
+}
+
+func SetDebug(b bool) {
+	Default().SetModeBool(LgDebug, b)
+}
+
+func SetVerbosity(n int) {
+	Default().SetVerbosity(n)
+}
+
+func SetLevel(lv Level) {
+	Default().SetLevel(lv)
+}
+
+func SetMode(f BitSet) {
+	Default().SetMode(f)
+}
+
+func ClearMode(f BitSet) {
+	Default().ClearMode(f)
+}
+
+func SetFormat(f Format) {
+	Default().SetFormat(f)
+}
+
+func SetColor(b bool) {
+	Default().SetColor(b)
+}
+
+func SetTimeFormat(layout string) {
+	Default().SetTimeFormat(layout)
+}
+
+// Derived loggers of the default Log
+func WithField(key string, value interface{}) *Log {
+	return Default().WithField(key, value)
+}
+
+func WithFields(f Fields) *Log {
+	return Default().WithFields(f)
+}
+
+func Named(name string) *Log {
+	return Default().Named(name)
+}
+
+// Logging functions of the default Log
+func Standard(format string, v ...interface{}) {
+	Default().Standard(format, v...)
+}
+
+func Bold(format string, v ...interface{}) {
+	Default().Bold(format, v...)
+}
+
+// Info is the same as StandardInfo
+func Info(format string, v ...interface{}) {
+	Default().StandardInfo(format, v...)
+}
+
+func StandardInfo(format string, v ...interface{}) {
+	Default().StandardInfo(format, v...)
+}
+
+func BoldInfo(format string, v ...interface{}) {
+	Default().BoldInfo(format, v...)
+}
+
+func Verbose(format string, v ...interface{}) {
+	Default().Verbose(format, v...)
+}
+
+func VerboseInfo(format string, v ...interface{}) {
+	Default().VerboseInfo(format, v...)
+}
+
+func VerboseN(n int, format string, v ...interface{}) {
+	Default().VerboseN(n, format, v...)
+}
+
+func V(n int) VLogger {
+	return Default().V(n)
+}
+
+func Trace(format string, v ...interface{}) {
+	Default().Trace(format, v...)
+}
+
+func Debug(format string, v ...interface{}) {
+	Default().Debug(format, v...)
+}
+
+func TraceLazy(fn LazyFunc) {
+	Default().TraceLazy(fn)
+}
+
+func DebugLazy(fn LazyFunc) {
+	Default().DebugLazy(fn)
+}
+
+func VerboseLazy(fn LazyFunc) {
+	Default().VerboseLazy(fn)
+}
+
+func Check(lv Level) bool {
+	return Default().Check(lv)
+}
+
+func Warn(format string, v ...interface{}) {
+	Default().Warn(format, v...)
+}
+
+func Error(format string, v ...interface{}) {
+	Default().Error(format, v...)
+}
+
+func ErrorWithStack(err error, format string, v ...interface{}) {
+	Default().ErrorWithStack(err, format, v...)
+}
+
+func Panic(format string, v ...interface{}) {
+	Default().Panic(format, v...)
+}
+
+func Panicf(format string, v ...interface{}) {
+	Default().Panicf(format, v...)
+}
+
+func Fatal(format string, v ...interface{}) {
+	Default().Fatal(format, v...)
+}
+
+// Configuration of the default Log
+func ConfigureFromEnv() error {
+	return Default().ConfigureFromEnv()
+}
+
+// Lifecycle of the default Log
+func Flush() {
+	Default().Flush()
+}
+
+func Close() error {
+	return Default().Close()
+}
@@ -0,0 +1,42 @@
+package MyLog
+
+import "testing"
+
+// useDefault makes l the default Log for the duration of the test
+func useDefault(t *testing.T, l *Log) {
+	old := defaultLog.Load()
+	SetDefault(l)
+	t.Cleanup(func() { defaultLog.Store(old) })
+}
+
+func TestDefault(t *testing.T) {
+	useDefault(t, nil)
+	l := Default()
+	if l == nil || Default() != l {
+		t.Fatal("Default did not initialize a single Log")
+	}
+}
+
+func TestPackageFunctions(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	useDefault(t, l)
+
+	StandardInfo("info")
+	Debug("hidden")
+	SetDebug(true)
+	Debug("shown")
+	WithField("k", 1).Warn("fields")
+	Named("db").Error("named")
+	SetLevel(LevelError)
+	Warn("hidden")
+
+	if want := "INFO:  info\nWARN:  fields k=1\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if want := "DEBUG: shown\nERROR: [db] named\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+	if !Check(LevelError) || Check(LevelWarn) {
+		t.Error("Check does not follow the default level")
+	}
+}
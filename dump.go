@@ -0,0 +1,62 @@
+package MyLog
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// defaultDumpLimit is the number of bytes dumped without SetDumpLimit
+const defaultDumpLimit = 4096
+
+// SetDumpLimit sets the maximum number of bytes written by the dump
+// helpers like DumpHex, 4096 by default. 0 restores the default, a
+// negative limit dumps everything.
+func (l *Log) SetDumpLimit(n int) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.dumpLimit = n
+}
+
+// limitDump cuts data to the dump limit and returns the number of
+// bytes left out
+func (l *Log) limitDump(data []byte) ([]byte, int) {
+	n := l.dumpMax()
+	if n < 0 || len(data) <= n {
+		return data, 0
+	}
+	return data[:n], len(data) - n
+}
+
+// dumpMax returns the dump limit, negative if there is none
+func (l *Log) dumpMax() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if l.dumpLimit == 0 {
+		return defaultDumpLimit
+	}
+	return l.dumpLimit
+}
+
+// DumpHex logs data with label as hex and ASCII dump like hexdump -C,
+// as a single entry of level lv. The dump is only built if lv is
+// enabled, and is cut to the limit set with SetDumpLimit.
+func (l *Log) DumpHex(lv Level, label string, data []byte) {
+	if !l.ready() || !l.Check(lv) {
+		return
+	}
+
+	shown, rest := l.limitDump(data)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s (%d bytes):\n", label, len(data))
+	sb.WriteString(strings.TrimRight(hex.Dump(shown), "\n"))
+	if rest > 0 {
+		fmt.Fprintf(&sb, "\n... %d more bytes", rest)
+	}
+	l.logLevel(lv, sb.String())
+}
@@ -0,0 +1,52 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDumpHex(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetLevel(LevelDebug)
+
+	l.DumpHex(LevelDebug, "frame", []byte("hello, world!\x00\x01"))
+	want := []string{
+		"DEBUG: frame (15 bytes):",
+		"00000000  68 65 6c 6c 6f 2c 20 77  6f 72 6c 64 21 00 01     |hello, world!..|",
+	}
+	if got := stderr.lines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("dump =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestDumpLimit(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	data := make([]byte, 5000)
+
+	l.DumpHex(LevelWarn, "big", data)
+	if !strings.HasSuffix(stdout.String(), "\n... 904 more bytes\n") {
+		t.Errorf("default limit: %q", stdout.String()[len(stdout.String())-40:])
+	}
+
+	l, stdout, _ = newTestLog()
+	l.SetDumpLimit(16)
+	l.DumpHex(LevelWarn, "big", data)
+	if got := stdout.lines(); len(got) != 3 || got[0] != "WARN:  big (5000 bytes):" || got[2] != "... 4984 more bytes" {
+		t.Errorf("limit 16 = %q", got)
+	}
+
+	l, stdout, _ = newTestLog()
+	l.SetDumpLimit(-1)
+	l.DumpHex(LevelWarn, "big", data)
+	if strings.Contains(stdout.String(), "more bytes") {
+		t.Error("negative limit cuts the dump")
+	}
+}
+
+func TestDumpHexDisabled(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.DumpHex(LevelDebug, "hidden", []byte("x"))
+	if stderr.String() != "" {
+		t.Errorf("disabled level dumped %q", stderr.String())
+	}
+}
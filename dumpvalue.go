@@ -0,0 +1,234 @@
+package MyLog
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// maxDumpDepth limits the nesting rendered by DumpValue
+const maxDumpDepth = 10
+
+// DumpValue logs v with label as indented Go-like literal, with structs,
+// maps, slices and pointers expanded, as a single entry of level lv.
+// In color mode strings and numbers are colored. Struct fields tagged
+// `log:"-"` are left out and those tagged `log:"redact"` hidden; the
+// redactors of l apply to the result. The dump is only built if lv is
+// enabled, and is cut to the limit set with SetDumpLimit.
+func (l *Log) DumpValue(lv Level, label string, v interface{}) {
+	if !l.ready() || !l.Check(lv) {
+		return
+	}
+
+	d := valueDumper{seen: make(map[uintptr]bool)}
+	if l.modeHas(LgColor) {
+		d.str = painterFor([]color.Attribute{color.FgGreen})
+		d.num = painterFor([]color.Attribute{color.FgCyan})
+		d.nil = painterFor([]color.Attribute{color.Faint})
+	} else {
+		d.str, d.num, d.nil = plain, plain, plain
+	}
+
+	d.sb.WriteString(label + ": ")
+	d.dump(reflect.ValueOf(v), 0)
+
+	// cut at a line end to keep the colors intact
+	text := d.sb.String()
+	shown, rest := l.limitDump([]byte(text))
+	if rest > 0 {
+		if i := strings.LastIndexByte(string(shown), '\n'); i > 0 {
+			shown = shown[:i]
+		}
+		text = fmt.Sprintf("%s\n... %d more bytes", shown, len(text)-len(shown))
+	}
+	l.logLevel(lv, text)
+}
+
+// valueDumper renders values for DumpValue
+type valueDumper struct {
+	sb            strings.Builder
+	seen          map[uintptr]bool // pointers on the current path
+	str, num, nil paintFunc
+}
+
+func (d *valueDumper) indent(depth int) {
+	d.sb.WriteByte('\n')
+	d.sb.WriteString(strings.Repeat("  ", depth))
+}
+
+func (d *valueDumper) dump(v reflect.Value, depth int) {
+	if !v.IsValid() {
+		d.sb.WriteString(d.nil("nil"))
+		return
+	}
+	if depth > maxDumpDepth {
+		d.sb.WriteString("...")
+		return
+	}
+	if s, ok := stringer(v); ok {
+		d.sb.WriteString(d.str(strconv.Quote(s)))
+		return
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			d.sb.WriteString(d.nil("nil"))
+			return
+		}
+		if d.seen[v.Pointer()] {
+			d.sb.WriteString("<cycle " + v.Type().String() + ">")
+			return
+		}
+		d.seen[v.Pointer()] = true
+		defer delete(d.seen, v.Pointer())
+		d.sb.WriteByte('&')
+		d.dump(v.Elem(), depth)
+	case reflect.Interface:
+		d.dump(v.Elem(), depth)
+	case reflect.Struct:
+		d.dumpStruct(v, depth)
+	case reflect.Map:
+		d.dumpMap(v, depth)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			d.sb.WriteString(d.nil("nil"))
+			return
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			d.dumpBytes(v)
+			return
+		}
+		d.sb.WriteString(v.Type().String() + "{")
+		for i := 0; i < v.Len(); i++ {
+			d.indent(depth + 1)
+			d.dump(v.Index(i), depth+1)
+			d.sb.WriteByte(',')
+		}
+		d.close(v.Len() > 0, depth)
+	case reflect.String:
+		d.sb.WriteString(d.str(strconv.Quote(v.String())))
+	case reflect.Bool:
+		d.sb.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		d.sb.WriteString(d.num(strconv.FormatInt(v.Int(), 10)))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		d.sb.WriteString(d.num(strconv.FormatUint(v.Uint(), 10)))
+	case reflect.Float32, reflect.Float64:
+		d.sb.WriteString(d.num(strconv.FormatFloat(v.Float(), 'g', -1, 64)))
+	case reflect.Complex64, reflect.Complex128:
+		d.sb.WriteString(d.num(strconv.FormatComplex(v.Complex(), 'g', -1, 128)))
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		if v.IsNil() {
+			d.sb.WriteString(d.nil("nil"))
+		} else {
+			fmt.Fprintf(&d.sb, "%s(%#x)", v.Type(), v.Pointer())
+		}
+	default:
+		d.sb.WriteString(v.Type().String())
+	}
+}
+
+func (d *valueDumper) dumpStruct(v reflect.Value, depth int) {
+	t := v.Type()
+	d.sb.WriteString(t.String() + "{")
+	n := 0
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("log")
+		if tag == "-" {
+			continue
+		}
+
+		d.indent(depth + 1)
+		d.sb.WriteString(f.Name + ": ")
+		if tag == "redact" {
+			d.sb.WriteString(d.str(`"[REDACTED]"`))
+		} else {
+			d.dump(v.Field(i), depth+1)
+		}
+		d.sb.WriteByte(',')
+		n++
+	}
+	d.close(n > 0, depth)
+}
+
+func (d *valueDumper) dumpMap(v reflect.Value, depth int) {
+	if v.IsNil() {
+		d.sb.WriteString(d.nil("nil"))
+		return
+	}
+
+	keys := v.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = fmt.Sprint(k)
+	}
+	sort.Sort(byName{keys, names})
+
+	d.sb.WriteString(v.Type().String() + "{")
+	for _, k := range keys {
+		d.indent(depth + 1)
+		d.dump(k, depth+1)
+		d.sb.WriteString(": ")
+		d.dump(v.MapIndex(k), depth+1)
+		d.sb.WriteByte(',')
+	}
+	d.close(len(keys) > 0, depth)
+}
+
+// dumpBytes renders byte slices and arrays as string if printable,
+// otherwise in hex, cut to 64 bytes
+func (d *valueDumper) dumpBytes(v reflect.Value) {
+	b := make([]byte, v.Len())
+	reflect.Copy(reflect.ValueOf(b), v)
+
+	more := ""
+	if len(b) > 64 {
+		more = fmt.Sprintf("...(%d bytes)", len(b))
+		b = b[:64]
+	}
+	if strconv.CanBackquote(string(b)) {
+		d.sb.WriteString(v.Type().String() + "(" + d.str(strconv.Quote(string(b))) + ")" + more)
+	} else {
+		fmt.Fprintf(&d.sb, "%s{%s}%s", v.Type(), d.num(fmt.Sprintf("% x", b)), more)
+	}
+}
+
+func (d *valueDumper) close(multiline bool, depth int) {
+	if multiline {
+		d.indent(depth)
+	}
+	d.sb.WriteByte('}')
+}
+
+// stringer returns the text of errors and Stringers like time.Time
+func stringer(v reflect.Value) (string, bool) {
+	if !v.CanInterface() || (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) && v.IsNil() {
+		return "", false
+	}
+	switch t := v.Interface().(type) {
+	case error:
+		return t.Error(), true
+	case fmt.Stringer:
+		return t.String(), true
+	}
+	return "", false
+}
+
+// byName sorts map keys by their rendering
+type byName struct {
+	keys  []reflect.Value
+	names []string
+}
+
+func (s byName) Len() int           { return len(s.keys) }
+func (s byName) Less(i, j int) bool { return s.names[i] < s.names[j] }
+func (s byName) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.names[i], s.names[j] = s.names[j], s.names[i]
+}
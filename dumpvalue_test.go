@@ -0,0 +1,110 @@
+package MyLog
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+type dumpUser struct {
+	Name     string
+	Age      int
+	Tags     []string
+	Meta     map[string]interface{}
+	Password string `log:"redact"`
+	Internal string `log:"-"`
+	Err      error
+	Next     *dumpUser
+}
+
+func TestDumpValue(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	u := &dumpUser{
+		Name:     "alice",
+		Age:      42,
+		Tags:     []string{"admin"},
+		Meta:     map[string]interface{}{"b": 2.5, "a": nil},
+		Password: "hunter2",
+		Internal: "hidden",
+		Err:      errors.New("locked"),
+	}
+
+	l.DumpValue(LevelWarn, "user", u)
+	want := `WARN:  user: &MyLog.dumpUser{
+  Name: "alice",
+  Age: 42,
+  Tags: []string{
+    "admin",
+  },
+  Meta: map[string]interface {}{
+    "a": nil,
+    "b": 2.5,
+  },
+  Password: "[REDACTED]",
+  Err: "locked",
+  Next: nil,
+}
+`
+	if stdout.String() != want {
+		t.Errorf("dump =\n%s\nwant\n%s", stdout.String(), want)
+	}
+}
+
+func TestDumpValueValues(t *testing.T) {
+	tests := []struct {
+		v    interface{}
+		want string
+	}{
+		{nil, "nil"},
+		{[]int(nil), "nil"},
+		{[]int{}, "[]int{}"},
+		{[]byte("text"), `[]uint8("text")`},
+		{[]byte{0, 0xff}, "[]uint8{00 ff}"},
+		{struct{}{}, "struct {}{}"},
+		{true, "true"},
+		{uint8(7), "7"},
+		{complex(1, 2), "(1+2i)"},
+	}
+	for _, tt := range tests {
+		d := valueDumper{seen: make(map[uintptr]bool), str: plain, num: plain, nil: plain}
+		d.dump(reflect.ValueOf(tt.v), 0)
+		if got := d.sb.String(); got != tt.want {
+			t.Errorf("dump(%#v) = %q, want %q", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestDumpValueCycle(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	u := &dumpUser{Name: "loop"}
+	u.Next = u
+
+	l.DumpValue(LevelWarn, "user", u)
+	if !strings.Contains(stdout.String(), "Next: <cycle *MyLog.dumpUser>,") {
+		t.Errorf("cycle = %q", stdout.String())
+	}
+}
+
+func TestDumpValueLimit(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetDumpLimit(30)
+	l.AddRedactor(regexp.MustCompile(`alice`), "***")
+
+	l.DumpValue(LevelWarn, "tags", []string{"alice", "bob", "carol"})
+	want := "WARN:  tags: []string{\n  \"***\",\n... 22 more bytes\n"
+	if stdout.String() != want {
+		t.Errorf("dump = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestDumpValueColor(t *testing.T) {
+	l, out := coloredOutput(t)
+	l.DumpValue(LevelWarn, "v", []interface{}{"s", 1, nil})
+	for _, want := range []string{"\x1b[32m\"s\"", "\x1b[36m1", "\x1b[2mnil"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("%q not in %q", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package MyLog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// EncryptionConfig encrypts a log file set with SetFile using AES-GCM.
+// Each write becomes a record sealed with a random nonce. Every file,
+// including rotated ones, starts with the ID of its key, and a changed
+// key is recorded where it takes effect, so DecryptLog finds the right
+// key for each record.
+type EncryptionConfig struct {
+	KeyID string // identifies Key for DecryptLog, stored in the file
+	Key   []byte // 16, 24 or 32 bytes for AES-128, -192 or -256
+}
+
+// record types of encrypted files
+const (
+	recKey  = 'K' // payload is the ID of the key of the following records
+	recData = 'D' // payload is a nonce followed by the sealed data
+)
+
+// maxRecordData is the most plain text sealed in one record; longer
+// writes are split. DecryptLog rejects larger records.
+const maxRecordData = 1 << 20
+
+// maxRecordSize leaves room for the nonce and tag of a data record
+const maxRecordSize = maxRecordData + 64
+
+type fileCipher struct {
+	id   string
+	aead cipher.AEAD
+}
+
+func newFileCipher(cfg *EncryptionConfig) (*fileCipher, error) {
+	block, err := aes.NewCipher(cfg.Key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &fileCipher{id: cfg.KeyID, aead: aead}, nil
+}
+
+// seal appends the data records of p to b, preceded by the key record
+// if withKey is set
+func (c *fileCipher) seal(b, p []byte, withKey bool) ([]byte, error) {
+	if withKey {
+		b = appendRecord(b, recKey, []byte(c.id))
+	}
+
+	for len(p) > maxRecordData {
+		var err error
+		if b, err = c.sealRecord(b, p[:maxRecordData]); err != nil {
+			return b, err
+		}
+		p = p[maxRecordData:]
+	}
+	return c.sealRecord(b, p)
+}
+
+// sealRecord appends a single data record of p to b
+func (c *fileCipher) sealRecord(b, p []byte) ([]byte, error) {
+	ns := c.aead.NonceSize()
+	payload := make([]byte, ns, ns+len(p)+c.aead.Overhead())
+	if _, err := rand.Read(payload); err != nil {
+		return b, err
+	}
+	payload = c.aead.Seal(payload, payload[:ns], p, []byte(c.id))
+	return appendRecord(b, recData, payload), nil
+}
+
+func appendRecord(b []byte, typ byte, payload []byte) []byte {
+	b = append(b, typ)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(payload)))
+	return append(b, payload...)
+}
+
+// SetFileKey switches the encryption of the log file set with SetFile to
+// a new key, e.g. for key rotation. Records written before keep their
+// key. It fails if there is no log file.
+func (l *Log) SetFileKey(keyID string, key []byte) error {
+	if !l.ready() {
+		return nil
+	}
+	c, err := newFileCipher(&EncryptionConfig{KeyID: keyID, Key: key})
+	if err != nil {
+		return err
+	}
+
+	l.mu.RLock()
+	f := l.file
+	l.mu.RUnlock()
+	if f == nil {
+		return errors.New("no log file")
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cipher = c
+	f.keyWritten = false
+	return nil
+}
+
+// DecryptLog writes the plain text of an encrypted log file read from r
+// to w. keys maps the key IDs of EncryptionConfig to their keys. Gzipped
+// files of the rotation are decompressed first.
+func DecryptLog(w io.Writer, r io.Reader, keys map[string][]byte) error {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		br = bufio.NewReader(gz)
+	}
+
+	var c *fileCipher
+	for n := 1; ; n++ {
+		var hdr [5]byte
+		if _, err := io.ReadFull(br, hdr[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("record %d: %w", n, err)
+		}
+		size := binary.BigEndian.Uint32(hdr[1:])
+		if size > maxRecordSize {
+			return fmt.Errorf("record %d: size %d exceeds the limit", n, size)
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return fmt.Errorf("record %d: %w", n, err)
+		}
+
+		switch hdr[0] {
+		case recKey:
+			id := string(payload)
+			key, ok := keys[id]
+			if !ok {
+				return fmt.Errorf("record %d: unknown key %q", n, id)
+			}
+			var err error
+			if c, err = newFileCipher(&EncryptionConfig{KeyID: id, Key: key}); err != nil {
+				return fmt.Errorf("record %d: %w", n, err)
+			}
+		case recData:
+			if c == nil {
+				return fmt.Errorf("record %d: no key", n)
+			}
+			ns := c.aead.NonceSize()
+			if len(payload) < ns {
+				return fmt.Errorf("record %d: truncated", n)
+			}
+			plain, err := c.aead.Open(nil, payload[:ns], payload[ns:], []byte(c.id))
+			if err != nil {
+				return fmt.Errorf("record %d: %w", n, err)
+			}
+			if _, err := w.Write(plain); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("record %d: not an encrypted log", n)
+		}
+	}
+}
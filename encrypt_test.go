@@ -0,0 +1,138 @@
+package MyLog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	key1 = bytes.Repeat([]byte{1}, 32)
+	key2 = bytes.Repeat([]byte{2}, 16)
+)
+
+// decryptFile returns the plain text of the encrypted file at path
+func decryptFile(t *testing.T, path string, keys map[string][]byte) (string, error) {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out bytes.Buffer
+	err = DecryptLog(&out, f, keys)
+	return out.String(), err
+}
+
+func TestEncryptedFile(t *testing.T) {
+	l, _, _ := newTestLog()
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := l.SetFile(path, RotationConfig{Encryption: &EncryptionConfig{KeyID: "k1", Key: key1}}); err != nil {
+		t.Fatal(err)
+	}
+	l.StandardInfo("card holder alice")
+	if err := l.SetFileKey("k2", key2); err != nil {
+		t.Fatal(err)
+	}
+	l.Warn("rotated key")
+	l.Close()
+
+	data, _ := os.ReadFile(path)
+	if bytes.Contains(data, []byte("alice")) {
+		t.Error("the file holds plain text")
+	}
+	got, err := decryptFile(t, path, map[string][]byte{"k1": key1, "k2": key2})
+	if want := "INFO:  card holder alice\nWARN:  rotated key\n"; err != nil || got != want {
+		t.Errorf("DecryptLog() = %q, %v, want %q", got, err, want)
+	}
+
+	got, err = decryptFile(t, path, map[string][]byte{"k1": key1})
+	if err == nil || !strings.Contains(err.Error(), `unknown key "k2"`) || got != "INFO:  card holder alice\n" {
+		t.Errorf("DecryptLog() without k2 = %q, %v", got, err)
+	}
+	if _, err := decryptFile(t, path, map[string][]byte{"k1": key2, "k2": key2}); err == nil {
+		t.Error("DecryptLog() accepted the wrong key")
+	}
+}
+
+func TestSetFileKeyErrors(t *testing.T) {
+	l, _, _ := newTestLog()
+	if err := l.SetFileKey("k", key1); err == nil {
+		t.Error("SetFileKey() succeeded without a log file")
+	}
+	if err := l.SetFileKey("k", []byte("short")); err == nil {
+		t.Error("SetFileKey() accepted an invalid key")
+	}
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := l.SetFile(path, RotationConfig{Encryption: &EncryptionConfig{Key: []byte("short")}}); err == nil {
+		t.Error("SetFile() accepted an invalid key")
+	}
+}
+
+func TestEncryptedRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	keys := map[string][]byte{"k1": key1}
+	r, err := openRotatingFile(path, RotationConfig{
+		Interval:   time.Nanosecond,
+		Compress:   true,
+		Encryption: &EncryptionConfig{KeyID: "k1", Key: key1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeChunks(t, r, 3, 10)
+	r.cleanup()
+	r.Close()
+
+	files, _ := r.backups()
+	if len(files) != 2 {
+		t.Fatalf("%d backups, want 2", len(files))
+	}
+	// every file starts with its key, compressed or not
+	for _, p := range []string{files[0].path, files[1].path, path} {
+		if got, err := decryptFile(t, p, keys); err != nil || got != "xxxxxxxxx\n" {
+			t.Errorf("%s: %q, %v", filepath.Base(p), got, err)
+		}
+	}
+}
+
+func TestEncryptLargeWrite(t *testing.T) {
+	c, err := newFileCipher(&EncryptionConfig{KeyID: "k1", Key: key1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := bytes.Repeat([]byte("y"), 2*maxRecordData+10)
+	b, err := c.seal(nil, p, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := DecryptLog(&out, bytes.NewReader(b), map[string][]byte{"k1": key1}); err != nil || !bytes.Equal(out.Bytes(), p) {
+		t.Errorf("DecryptLog() = %d bytes, %v", out.Len(), err)
+	}
+}
+
+func TestDecryptLogMalformed(t *testing.T) {
+	keys := map[string][]byte{"k1": key1}
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"plain text", []byte("INFO:  hello\n"), "record 1: size 1313230650 exceeds the limit"},
+		{"unknown record", appendRecord(nil, 'X', nil), "record 1: not an encrypted log"},
+		{"no key", appendRecord(nil, recData, make([]byte, 40)), "record 1: no key"},
+		{"oversized", []byte{recData, 0xff, 0xff, 0xff, 0xff}, "record 1: size 4294967295 exceeds the limit"},
+		{"truncated", append(appendRecord(nil, recKey, []byte("k1")), recData, 0, 0, 0, 9, 1), "record 2: unexpected EOF"},
+	}
+	for _, tt := range tests {
+		err := DecryptLog(new(bytes.Buffer), bytes.NewReader(tt.data), keys)
+		if err == nil || err.Error() != tt.want {
+			t.Errorf("%s: DecryptLog() = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package MyLog
+
+import (
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is a single log event on its way to the writers and hooks
+type Entry struct {
+	Time    time.Time
+	Level   Level
+	Prefix  string // level label like "INFO", empty for standard messages
+	Name    string // logger name set with Named
+	Message string
+	Fields  Fields
+	Caller  string // "file.go:line func" if SetReportCaller is enabled
+	Stack   string // stack trace for Panic and ErrorWithStack
+	Indent  int    // group depth set with Group, indents text lines
+
+	prev time.Time // time of the previous entry, for TimeDelta
+
+	callerFile string // full path of the caller, for SetCallerLinks
+	callerLine int
+}
+
+// text renders the message followed by fields and caller
+func (e *Entry) text() string {
+	b := getBuf()
+	defer putBuf(b)
+	*b = e.appendText(*b)
+	return string(*b)
+}
+
+// appendText appends the rendering of text to b. Trailing line breaks
+// of the message are dropped, so that the fields follow its last line.
+func (e *Entry) appendText(b []byte) []byte {
+	if e.Name != "" {
+		b = append(b, '[')
+		b = append(b, e.Name...)
+		b = append(b, "] "...)
+	}
+	msg := strings.TrimRight(e.Message, "\r\n")
+	if e.Indent > 0 {
+		b = appendIndented(b, msg, e.Indent)
+	} else {
+		b = append(b, msg...)
+	}
+	b = e.Fields.appendTo(b)
+	if e.Caller != "" {
+		b = append(b, " ["...)
+		b = append(b, e.Caller...)
+		b = append(b, ']')
+	}
+	return b
+}
+
+// appendIndented appends msg with each of its lines indented by
+// two blanks per depth
+func appendIndented(b []byte, msg string, depth int) []byte {
+	for {
+		for i := 0; i < depth; i++ {
+			b = append(b, "  "...)
+		}
+		i := strings.IndexByte(msg, '\n')
+		if i < 0 {
+			return append(b, msg...)
+		}
+		b = append(b, msg[:i+1]...)
+		msg = msg[i+1:]
+	}
+}
+
+// stackSuffix returns the stack trace on its own lines, if any
+func (e *Entry) stackSuffix() string {
+	if e.Stack == "" {
+		return ""
+	}
+	return "\n" + strings.TrimRight(e.Stack, "\n")
+}
+
+func (l *Log) newEntry(lv Level, prefix, msg string) *Entry {
+	e := &Entry{Time: time.Now(), Level: lv, Prefix: prefix, Name: l.name, Message: l.prefix + msg, Fields: l.fields}
+
+	l.mu.RLock()
+	withCaller := l.reportCaller
+	e.Indent = l.indent
+	l.mu.RUnlock()
+
+	if withCaller {
+		f := callerFrame()
+		if f.File != "" {
+			e.Caller = filepath.Base(f.File) + ":" + strconv.Itoa(f.Line) + " " + shortFuncName(f.Function)
+			e.callerFile, e.callerLine = f.File, f.Line
+		}
+	}
+	l.redact(e)
+
+	return e
+}
+
+// SetReportCaller enables appending the file, line and function of
+// the calling code to every entry
+func (l *Log) SetReportCaller(b bool) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.reportCaller = b
+}
+
+// pkgPath is the import path of this package, used to skip its frames
+var pkgPath = reflect.TypeOf(Log{}).PkgPath()
+
+// callerFrame returns the first frame outside of this package and the
+// logging APIs adapted to it, or an empty frame
+func callerFrame() runtime.Frame {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+
+	for {
+		f, more := frames.Next()
+		if !isInternalFrame(f.Function) {
+			return f
+		}
+		if !more {
+			return runtime.Frame{}
+		}
+	}
+}
+
+// callerSkip lists the function prefixes of this package and of the
+// logging APIs adapted to it, whose frames are never reported as caller
+var callerSkip = []string{
+	pkgPath + ".",
+	pkgPath + "/logrsink.",
+	"log.",
+	"log/slog.",
+	"github.com/go-logr/logr.",
+}
+
+func isInternalFrame(fn string) bool {
+	for _, prefix := range callerSkip {
+		if strings.HasPrefix(fn, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// shortFuncName strips the directories of the package path,
+// e.g. "github.com/a/b.(*T).M" becomes "b.(*T).M"
+func shortFuncName(fn string) string {
+	if i := strings.LastIndex(fn, "/"); i >= 0 {
+		return fn[i+1:]
+	}
+	return fn
+}
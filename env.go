@@ -0,0 +1,60 @@
+package MyLog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ConfigureFromEnv applies the environment variables
+//
+//	MYLOG_LEVEL    a level like "debug", or module levels like "db=debug,*=info"
+//	MYLOG_COLOR    auto, always or never
+//	MYLOG_FORMAT   text, json or logfmt
+//	MYLOG_FILE     path of an additional log file
+//	NO_COLOR       disables colors unless MYLOG_COLOR is set
+//
+// Unset variables leave the current settings alone. Invalid values are
+// reported together, the valid ones are applied anyway.
+func (l *Log) ConfigureFromEnv() error {
+	if !l.ready() {
+		return nil
+	}
+	var errs []error
+	fail := func(name string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	if s, ok := os.LookupEnv("MYLOG_LEVEL"); ok && s != "" {
+		if strings.Contains(s, "=") {
+			fail("MYLOG_LEVEL", l.SetModuleLevels(s))
+		} else if lv, err := ParseLevel(s); err != nil {
+			fail("MYLOG_LEVEL", err)
+		} else {
+			l.SetLevel(lv)
+		}
+	}
+
+	if s, ok := os.LookupEnv("MYLOG_COLOR"); ok && s != "" {
+		fail("MYLOG_COLOR", l.setColorChoice(s))
+	} else if os.Getenv("NO_COLOR") != "" {
+		l.SetColor(false)
+	}
+
+	if s, ok := os.LookupEnv("MYLOG_FORMAT"); ok && s != "" {
+		if f, err := ParseFormat(s); err != nil {
+			fail("MYLOG_FORMAT", err)
+		} else {
+			l.SetFormat(f)
+		}
+	}
+
+	if s, ok := os.LookupEnv("MYLOG_FILE"); ok && s != "" {
+		fail("MYLOG_FILE", l.SetFile(s, RotationConfig{}))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,68 @@
+package MyLog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setEnv sets the variables read by ConfigureFromEnv, unset ones empty
+func setEnv(t *testing.T, vars map[string]string) {
+	for _, name := range []string{"MYLOG_LEVEL", "MYLOG_COLOR", "MYLOG_FORMAT", "MYLOG_FILE", "NO_COLOR"} {
+		t.Setenv(name, vars[name])
+	}
+}
+
+func TestConfigureFromEnv(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	setEnv(t, map[string]string{
+		"MYLOG_LEVEL":  "debug",
+		"MYLOG_COLOR":  "always",
+		"MYLOG_FORMAT": "logfmt",
+		"MYLOG_FILE":   path,
+		"NO_COLOR":     "1",
+	})
+
+	l, _, _ := newTestLog()
+	if err := l.ConfigureFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if l.GetLevel() != LevelDebug || !l.HasMode(LgColor) || l.GetFormat() != FormatLogfmt {
+		t.Errorf("level %v, mode %b, format %v", l.GetLevel(), l.GetMode(), l.GetFormat())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("log file not opened: %v", err)
+	}
+}
+
+func TestConfigureFromEnvModules(t *testing.T) {
+	setEnv(t, map[string]string{"MYLOG_LEVEL": "db=trace,*=warn", "NO_COLOR": "1"})
+
+	l, _, _ := newTestLog()
+	l.SetColor(true)
+	if err := l.ConfigureFromEnv(); err != nil {
+		t.Fatal(err)
+	}
+	if !l.Named("db").Enabled(LevelTrace) || l.Named("http").Enabled(LevelInfo) {
+		t.Error("module levels not applied")
+	}
+	if l.HasMode(LgColor) {
+		t.Error("NO_COLOR did not disable colors")
+	}
+}
+
+func TestConfigureFromEnvInvalid(t *testing.T) {
+	setEnv(t, map[string]string{"MYLOG_LEVEL": "loud", "MYLOG_COLOR": "sometimes", "MYLOG_FORMAT": "json"})
+
+	l, _, _ := newTestLog()
+	err := l.ConfigureFromEnv()
+	if err == nil || !strings.Contains(err.Error(), "MYLOG_LEVEL") || !strings.Contains(err.Error(), "MYLOG_COLOR") {
+		t.Errorf("ConfigureFromEnv() = %v, want both errors", err)
+	}
+	if l.GetFormat() != FormatJSON {
+		t.Error("the valid format was not applied")
+	}
+}
@@ -0,0 +1,125 @@
+package MyLog
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldsError is implemented by errors that carry structured fields,
+// which ErrorE adds to the entry
+type FieldsError interface {
+	error
+	Fields() map[string]interface{}
+}
+
+// ErrorE logs an error entry for err with the message followed by ": "
+// and the text of err. The causes found with errors.Unwrap follow on
+// their own indented lines, each with the part of its text not already
+// shown. The fields of all errors in the chain implementing FieldsError
+// are added to the entry, those of outer errors taking precedence.
+func (l *Log) ErrorE(err error, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if !l.Enabled(LevelError) {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(format, v...))
+	fields := make(Fields)
+	if err != nil {
+		var chain strings.Builder
+		writeChain(&chain, err, 0)
+		sb.WriteByte(':')
+		if !strings.HasPrefix(chain.String(), "\n") {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(chain.String())
+		collectFields(err, fields)
+	}
+
+	lf := l
+	if len(fields) > 0 {
+		lf = l.WithFields(fields)
+	}
+	lf.dumpRecorder()
+	lf.outputEntry(lf.errorVar, lf.painter(LevelError), lf.newEntry(LevelError, "ERROR", sb.String()))
+}
+
+// writeChain writes the own text of err and then its causes, indented by
+// depth
+func writeChain(sb *strings.Builder, err error, depth int) {
+	causes := unwrapAll(err)
+	msg := ownText(err, causes)
+	if msg == "" && len(causes) == 1 {
+		// a plain wrapper like fmt.Errorf("%w", err)
+		writeChain(sb, causes[0], depth)
+		return
+	}
+	sb.WriteString(msg)
+
+	for _, c := range causes {
+		sb.WriteByte('\n')
+		sb.WriteString(strings.Repeat("  ", depth+1))
+		sb.WriteString("caused by: ")
+		writeChain(sb, c, depth+1)
+	}
+}
+
+// unwrapAll returns the direct causes of err
+func unwrapAll(err error) []error {
+	switch u := err.(type) {
+	case interface{ Unwrap() error }:
+		if c := u.Unwrap(); c != nil {
+			return []error{c}
+		}
+	case interface{ Unwrap() []error }:
+		return u.Unwrap()
+	}
+	return nil
+}
+
+// ownText returns the text of err without the text of its only cause,
+// as appended by fmt.Errorf("...: %w", cause). Joined errors only show
+// their causes.
+func ownText(err error, causes []error) string {
+	msg := err.Error()
+	switch len(causes) {
+	case 0:
+		return msg
+	case 1:
+		c := causes[0].Error()
+		if msg == c {
+			return ""
+		}
+		if s, ok := strings.CutSuffix(msg, ": "+c); ok {
+			return s
+		}
+		return msg
+	}
+	if msg == errors.Join(causes...).Error() {
+		return ""
+	}
+	return msg
+}
+
+// collectFields adds the fields of err and its causes implementing
+// FieldsError, the causes first so that the fields of err take precedence
+func collectFields(err error, fields Fields) {
+	for _, c := range unwrapAll(err) {
+		collectFields(c, fields)
+	}
+
+	var f map[string]interface{}
+	switch t := err.(type) {
+	case FieldsError:
+		f = t.Fields()
+	case interface{ Fields() Fields }:
+		f = t.Fields()
+	}
+	for k, v := range f {
+		fields[k] = v
+	}
+}
@@ -0,0 +1,64 @@
+package MyLog
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fieldsErr is an error with structured fields
+type fieldsErr struct {
+	err    error
+	fields map[string]interface{}
+}
+
+func (e fieldsErr) Error() string                  { return e.err.Error() }
+func (e fieldsErr) Unwrap() error                  { return e.err }
+func (e fieldsErr) Fields() map[string]interface{} { return e.fields }
+
+func TestErrorE(t *testing.T) {
+	base := errors.New("permission denied")
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil", nil, "ERROR: load failed\n"},
+		{"plain", base, "ERROR: load failed: permission denied\n"},
+		{"wrapped", fmt.Errorf("open config: %w", base),
+			"ERROR: load failed: open config\n  caused by: permission denied\n"},
+		{"bare wrapper", fmt.Errorf("%w", fmt.Errorf("read: %w", base)),
+			"ERROR: load failed: read\n  caused by: permission denied\n"},
+		{"own text", fmt.Errorf("%w (retrying)", base),
+			"ERROR: load failed: permission denied (retrying)\n  caused by: permission denied\n"},
+		{"joined", errors.Join(errors.New("a"), fmt.Errorf("b: %w", base)),
+			"ERROR: load failed:\n  caused by: a\n  caused by: b\n    caused by: permission denied\n"},
+	}
+	for _, tt := range tests {
+		l, _, stderr := newTestLog()
+		l.ErrorE(tt.err, "load %s", "failed")
+		if stderr.String() != tt.want {
+			t.Errorf("%s: %q, want %q", tt.name, stderr.String(), tt.want)
+		}
+	}
+}
+
+func TestErrorEFields(t *testing.T) {
+	l, _, stderr := newTestLog()
+	inner := fieldsErr{errors.New("timeout"), map[string]interface{}{"host": "db1", "try": 1}}
+	outer := fieldsErr{fmt.Errorf("query: %w", inner), map[string]interface{}{"try": 3}}
+
+	l.ErrorE(outer, "failed")
+	if want := "ERROR: failed: query\n  caused by: timeout host=db1 try=3\n"; stderr.String() != want {
+		t.Errorf("%q, want %q", stderr.String(), want)
+	}
+}
+
+func TestErrorEDisabled(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetLevel(LevelFatal)
+	l.ErrorE(errors.New("x"), "hidden")
+	if stderr.String() != "" {
+		t.Errorf("disabled level logged %q", stderr.String())
+	}
+}
@@ -0,0 +1,34 @@
+package MyLog
+
+import "expvar"
+
+// PublishExpvar exports the Stats, mode flags and buffer size of l as
+// expvar variables named prefix+".stats", ".mode" and ".buffer_size",
+// shown at /debug/vars. The prefix defaults to "mylog". Names already
+// published are left alone, so it is safe to call more than once.
+func (l *Log) PublishExpvar(prefix string) {
+	if !l.ready() {
+		return
+	}
+	if prefix == "" {
+		prefix = "mylog"
+	}
+
+	publish := func(name string, fn func() interface{}) {
+		if expvar.Get(prefix+name) == nil {
+			expvar.Publish(prefix+name, expvar.Func(fn))
+		}
+	}
+
+	publish(".stats", func() interface{} { return l.Stats() })
+	publish(".mode", func() interface{} {
+		return map[string]interface{}{
+			"verbose": l.HasMode(LgVerbose),
+			"debug":   l.HasMode(LgDebug),
+			"color":   l.HasMode(LgColor),
+			"buffer":  l.HasMode(LgBuffer),
+			"level":   l.GetLevel().String(),
+		}
+	})
+	publish(".buffer_size", func() interface{} { return l.BufferLen() })
+}
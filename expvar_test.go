@@ -0,0 +1,31 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"expvar"
+	"testing"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.EnableBuffer()
+	l.SetMode(LgDebug)
+	l.Warn("one")
+	l.PublishExpvar("test_expvar")
+	l.PublishExpvar("test_expvar") // names already published are kept
+
+	var stats Stats
+	if err := json.Unmarshal([]byte(expvar.Get("test_expvar.stats").String()), &stats); err != nil || stats.Count(LevelWarn) != 1 {
+		t.Errorf("stats = %+v, %v", stats, err)
+	}
+
+	var mode map[string]interface{}
+	if err := json.Unmarshal([]byte(expvar.Get("test_expvar.mode").String()), &mode); err != nil || mode["debug"] != true || mode["level"] != "info" {
+		t.Errorf("mode = %v, %v", mode, err)
+	}
+
+	l.Warn("two")
+	if got := expvar.Get("test_expvar.buffer_size").String(); got != "2" {
+		t.Errorf("buffer_size = %s, want 2", got)
+	}
+}
@@ -0,0 +1,76 @@
+package MyLog
+
+import (
+	"io"
+	"os"
+)
+
+// exitFunc terminates the process after a fatal entry
+var exitFunc = os.Exit
+
+// RegisterExitHook adds a function that is run by Close, and by Fatal
+// before the process exits. Hooks run in the order they were registered.
+func (l *Log) RegisterExitHook(hook func()) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.exitHooks = append(l.exitHooks, hook)
+}
+
+// Fatal logs the message with a FATAL prefix, flushes the writers,
+// runs all registered exit hooks and exits with status 1
+func (l *Log) Fatal(format string, v ...interface{}) {
+	if !l.ready() {
+		exitFunc(1)
+		return
+	}
+	l.dumpRecorder()
+	l.output(l.fatalVar, LevelFatal, "FATAL", l.painter(LevelFatal), format, v...)
+	l.Flush()
+	l.runExitHooks()
+	exitFunc(1)
+}
+
+func (l *Log) runExitHooks() {
+	l.mu.RLock()
+	hooks := make([]func(), len(l.exitHooks))
+	copy(hooks, l.exitHooks)
+	l.mu.RUnlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// syncer is implemented by writers that buffer data, e.g. *os.File
+type syncer interface {
+	Sync() error
+}
+
+// flush syncs all distinct writers, including the log file
+func (l *Log) flush() {
+	l.mu.RLock()
+	writers := []io.Writer{l.stdOut, l.stdErr, l.panicOut}
+	for _, w := range l.levelOut {
+		writers = append(writers, w)
+	}
+	if l.file != nil {
+		writers = append(writers, l.file)
+	}
+	l.mu.RUnlock()
+
+	seen := make(map[io.Writer]bool)
+	for _, w := range writers {
+		if seen[w] {
+			continue
+		}
+		seen[w] = true
+
+		if s, ok := w.(syncer); ok {
+			// terminals and pipes report errors on sync, nothing to do about it
+			_ = s.Sync()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package MyLog
+
+import "testing"
+
+// stubExit replaces exitFunc for the duration of the test and returns
+// a pointer to the last exit code, -1 if there was no exit
+func stubExit(t *testing.T) *int {
+	code := -1
+	old := exitFunc
+	exitFunc = func(c int) { code = c }
+	t.Cleanup(func() { exitFunc = old })
+	return &code
+}
+
+func TestFatal(t *testing.T) {
+	code := stubExit(t)
+	l, _, stderr := newTestLog()
+
+	var order []int
+	l.RegisterExitHook(func() { order = append(order, 1) })
+	l.RegisterExitHook(func() { order = append(order, 2) })
+	l.Fatal("cannot %s", "start")
+
+	if *code != 1 {
+		t.Errorf("exit code = %d, want 1", *code)
+	}
+	if len(order) != 2 || order[0] != 1 || order[1] != 2 {
+		t.Errorf("exit hooks ran as %v, want [1 2]", order)
+	}
+	if got, want := stderr.String(), "FATAL: cannot start\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestFatalNilLog(t *testing.T) {
+	code := stubExit(t)
+	var l *Log
+	l.Fatal("gone")
+	if *code != 1 {
+		t.Errorf("exit code = %d, want 1", *code)
+	}
+}
+
+func TestCloseRunsExitHooks(t *testing.T) {
+	l, _, _ := newTestLog()
+	ran := false
+	l.RegisterExitHook(func() { ran = true })
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !ran {
+		t.Error("Close did not run the exit hook")
+	}
+}
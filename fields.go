@@ -0,0 +1,90 @@
+package MyLog
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Fields is a set of key/value pairs attached to log entries
+type Fields map[string]interface{}
+
+// WithField returns a Log that appends the given key/value pair to every entry.
+// The returned Log shares outputs, modes and buffer with its parent.
+func (l *Log) WithField(key string, value interface{}) *Log {
+	if !l.ready() {
+		return nil
+	}
+	return l.WithFields(Fields{key: value})
+}
+
+// WithFields returns a Log that appends the given fields to every entry.
+// Fields already present on l are kept unless overwritten by f.
+func (l *Log) WithFields(f Fields) *Log {
+	if !l.ready() {
+		return nil
+	}
+	merged := make(Fields, len(l.fields)+len(f))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	for k, v := range f {
+		merged[k] = v
+	}
+
+	c := *l
+	c.fields = merged
+	return &c
+}
+
+// String renders the fields as " key=value" pairs sorted by key
+func (f Fields) String() string {
+	if len(f) == 0 {
+		return ""
+	}
+
+	return string(f.appendTo(nil))
+}
+
+// appendTo appends the rendering of String to b
+func (f Fields) appendTo(b []byte) []byte {
+	if len(f) == 0 {
+		return b
+	}
+
+	var buf [8]string
+	keys := buf[:0]
+	for k := range f {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		b = append(b, ' ')
+		b = append(b, k...)
+		b = append(b, '=')
+		b = append(b, fieldValue(f[k])...)
+	}
+
+	return b
+}
+
+// fieldValue formats a single value, quoting it if needed
+func fieldValue(v interface{}) string {
+	var s string
+	switch t := v.(type) {
+	case string:
+		s = t
+	case error:
+		s = t.Error()
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if s == "" || strings.ContainsAny(s, " \t\r\n\"=") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
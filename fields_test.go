@@ -0,0 +1,58 @@
+package MyLog
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWithFields(t *testing.T) {
+	l, stdout, _ := newTestLog()
+
+	base := l.WithField("user", "alice")
+	req := base.WithFields(Fields{"id": 7, "user": "bob"})
+	req.StandardInfo("served")
+	base.StandardInfo("done")
+	l.StandardInfo("plain")
+
+	want := []string{"INFO:  served id=7 user=bob", "INFO:  done user=alice", "INFO:  plain"}
+	got := stdout.lines()
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFieldsString(t *testing.T) {
+	tests := []struct {
+		f    Fields
+		want string
+	}{
+		{nil, ""},
+		{Fields{"b": 2, "a": 1}, " a=1 b=2"},
+		{Fields{"msg": "two words"}, ` msg="two words"`},
+		{Fields{"empty": ""}, ` empty=""`},
+		{Fields{"eq": "a=b"}, ` eq="a=b"`},
+		{Fields{"err": errors.New("failed")}, " err=failed"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.want {
+			t.Errorf("%v.String() = %q, want %q", map[string]interface{}(tt.f), got, tt.want)
+		}
+	}
+}
+
+func TestWithFieldsSharesCore(t *testing.T) {
+	l, _, stderr := newTestLog()
+	child := l.WithField("k", "v")
+
+	l.SetMode(LgDebug)
+	child.Debug("visible")
+
+	if got, want := stderr.String(), "DEBUG: visible k=v\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,185 @@
+package MyLog
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FlagSet registers the logging flags of a CLI on fs, or on
+// flag.CommandLine if fs is nil. They take effect while parsing:
+//
+//	-verbose      more details, may be repeated or given a number
+//	-debug        debug messages
+//	-quiet        warnings and errors only
+//	-color        auto, always or never
+//	-log-file     additionally write to the given file
+//	-log-format   text, json or logfmt
+func (l *Log) FlagSet(fs *flag.FlagSet) {
+	if !l.ready() {
+		return
+	}
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+
+	fs.Var(verbosityFlag{l}, "verbose", "more details, may be repeated or given a number")
+	fs.Var(modeFlag{l, LgDebug}, "debug", "show debug messages")
+	fs.Var(quietFlag{l}, "quiet", "show warnings and errors only")
+	fs.Var(colorFlag{l}, "color", "`when` to color the output: auto, always or never")
+	fs.Var(fileFlag{l}, "log-file", "additionally write the log to `file`")
+	fs.Var(formatFlag{l}, "log-format", "log `format`: text, json or logfmt")
+}
+
+// verbosityFlag counts its occurrences, or takes a number or an
+// increment like "+1"
+type verbosityFlag struct{ l *Log }
+
+func (f verbosityFlag) String() string {
+	if f.l == nil || f.l.core == nil {
+		return "0"
+	}
+	return strconv.Itoa(f.l.GetVerbosity())
+}
+
+func (f verbosityFlag) Set(s string) error {
+	if strings.HasPrefix(s, "+") {
+		n, err := strconv.Atoi(s[1:])
+		if err != nil {
+			return fmt.Errorf("invalid verbosity %q", s)
+		}
+		f.l.SetVerbosity(f.l.GetVerbosity() + n)
+		return nil
+	}
+
+	// numbers first, ParseBool would take "1" and "0" for true and false
+	if n, err := strconv.Atoi(s); err == nil {
+		f.l.SetVerbosity(n)
+		return nil
+	}
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("invalid verbosity %q", s)
+	}
+	if b {
+		f.l.SetVerbosity(f.l.GetVerbosity() + 1)
+	} else {
+		f.l.SetVerbosity(0)
+	}
+	return nil
+}
+
+func (f verbosityFlag) IsBoolFlag() bool { return true }
+func (f verbosityFlag) Type() string     { return "count" }
+
+// modeFlag sets or clears a mode bit
+type modeFlag struct {
+	l    *Log
+	mode BitSet
+}
+
+func (f modeFlag) String() string {
+	if f.l == nil || f.l.core == nil {
+		return "false"
+	}
+	return strconv.FormatBool(f.l.HasMode(f.mode))
+}
+
+func (f modeFlag) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	f.l.SetModeBool(f.mode, b)
+	return nil
+}
+
+func (f modeFlag) IsBoolFlag() bool { return true }
+func (f modeFlag) Type() string     { return "bool" }
+
+// quietFlag limits the output to warnings, see SetQuiet
+type quietFlag struct{ l *Log }
+
+func (f quietFlag) String() string {
+	if f.l == nil || f.l.core == nil {
+		return "false"
+	}
+	return strconv.FormatBool(f.l.IsQuiet())
+}
+
+func (f quietFlag) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	f.l.SetQuiet(b)
+	return nil
+}
+
+func (f quietFlag) IsBoolFlag() bool { return true }
+func (f quietFlag) Type() string     { return "bool" }
+
+// colorFlag selects auto, always or never
+type colorFlag struct{ l *Log }
+
+func (f colorFlag) String() string { return "auto" }
+func (f colorFlag) Type() string   { return "string" }
+
+func (f colorFlag) Set(s string) error {
+	return f.l.setColorChoice(s)
+}
+
+// setColorChoice applies a color setting like "auto", "always" or "never"
+func (l *Log) setColorChoice(s string) error {
+	apply, err := parseColorChoice(s)
+	if err != nil {
+		return err
+	}
+	apply(l)
+	return nil
+}
+
+// parseColorChoice returns the func applying a color setting
+func parseColorChoice(s string) (func(l *Log), error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "auto":
+		return (*Log).SetColorAuto, nil
+	case "always", "true", "on", "yes":
+		return func(l *Log) { l.SetColor(true) }, nil
+	case "never", "false", "off", "no":
+		return func(l *Log) { l.SetColor(false) }, nil
+	}
+	return nil, fmt.Errorf("invalid color setting %q", s)
+}
+
+// fileFlag opens a log file without rotation
+type fileFlag struct{ l *Log }
+
+func (f fileFlag) String() string { return "" }
+func (f fileFlag) Type() string   { return "string" }
+
+func (f fileFlag) Set(s string) error {
+	return f.l.SetFile(s, RotationConfig{})
+}
+
+// formatFlag selects the output format
+type formatFlag struct{ l *Log }
+
+func (f formatFlag) String() string {
+	if f.l == nil || f.l.core == nil {
+		return "text"
+	}
+	return f.l.GetFormat().String()
+}
+
+func (f formatFlag) Type() string { return "string" }
+
+func (f formatFlag) Set(s string) error {
+	format, err := ParseFormat(s)
+	if err != nil {
+		return err
+	}
+	f.l.SetFormat(format)
+	return nil
+}
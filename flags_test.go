@@ -0,0 +1,80 @@
+package MyLog
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func parseFlags(l *Log, args ...string) error {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	l.FlagSet(fs)
+	return fs.Parse(args)
+}
+
+func TestFlagSet(t *testing.T) {
+	l, _, _ := newTestLog()
+	path := filepath.Join(t.TempDir(), "app.log")
+	err := parseFlags(l, "-verbose", "-verbose", "-debug", "-log-format", "json", "-color", "never", "-log-file", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	if l.GetVerbosity() != 2 || !l.HasMode(LgDebug) || l.HasMode(LgColor) || l.GetFormat() != FormatJSON {
+		t.Errorf("verbosity %d, mode %b, format %v", l.GetVerbosity(), l.GetMode(), l.GetFormat())
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("log file not opened: %v", err)
+	}
+}
+
+func TestVerbosityFlag(t *testing.T) {
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"-verbose=3"}, 3},
+		{[]string{"-verbose=1"}, 1},
+		{[]string{"-verbose=0"}, 0},
+		{[]string{"-verbose=2", "-verbose=+2"}, 4},
+		{[]string{"-verbose=3", "-verbose=false"}, 0},
+		{[]string{"-verbose=true", "-verbose"}, 2},
+	}
+	for _, tt := range tests {
+		l, _, _ := newTestLog()
+		if err := parseFlags(l, tt.args...); err != nil || l.GetVerbosity() != tt.want {
+			t.Errorf("%q: verbosity %d, %v, want %d", tt.args, l.GetVerbosity(), err, tt.want)
+		}
+	}
+}
+
+func TestFlagSetInvalid(t *testing.T) {
+	for _, args := range [][]string{
+		{"-verbose=loud"},
+		{"-verbose=+x"},
+		{"-color=sometimes"},
+		{"-log-format=xml"},
+		{"-quiet=maybe"},
+	} {
+		l, _, _ := newTestLog()
+		if err := parseFlags(l, args...); err == nil {
+			t.Errorf("%q accepted", args)
+		}
+	}
+}
+
+func TestQuietFlag(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	if err := parseFlags(l, "-quiet"); err != nil {
+		t.Fatal(err)
+	}
+	l.StandardInfo("hidden")
+	l.Warn("shown")
+	if stdout.String() != "WARN:  shown\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
@@ -0,0 +1,175 @@
+// Package fluent ships MyLog entries to Fluentd or Fluent Bit using the
+// forward protocol (MessagePack over TCP).
+package fluent
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Options configures a Forwarder
+type Options struct {
+	Addr       string                  // host:port of the forward input, default localhost:24224
+	Tag        string                  // default "mylog"
+	TagLevel   bool                    // append the level to the tag, e.g. "mylog.error"
+	Timeout    time.Duration           // dial and write timeout, default 5s
+	Batch      MyLog.BatchOptions      // used by Attach, MaxSize defaults to 512 and MaxPending to 8192
+	Resilience MyLog.ResilienceOptions // retries and circuit breaker used by Attach
+}
+
+// Forwarder is a MyLog.Sink sending each batch of entries as forward
+// mode messages. The connection is established with the first write
+// and again after it broke.
+type Forwarder struct {
+	opts Options
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// New returns a Forwarder. It sends synchronously, see Attach for
+// batching and retries.
+func New(opts Options) *Forwarder {
+	if opts.Addr == "" {
+		opts.Addr = "localhost:24224"
+	}
+	if opts.Tag == "" {
+		opts.Tag = "mylog"
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	if opts.Batch.MaxSize <= 0 {
+		opts.Batch.MaxSize = 512
+	}
+	if opts.Batch.MaxPending <= 0 {
+		opts.Batch.MaxPending = max(8192, opts.Batch.MaxSize)
+	}
+	return &Forwarder{opts: opts}
+}
+
+// Attach adds a Forwarder for all entries of l as a sink, batched and
+// with retries, so dialing never blocks the logging goroutine. l.Flush
+// and l.Close send the queued entries.
+func Attach(l *MyLog.Log, opts Options) *MyLog.BatchSink {
+	f := New(opts)
+	b := MyLog.Batch(MyLog.NewResilientSink(f, f.opts.Resilience), f.opts.Batch)
+	l.AddSink(b)
+	return b
+}
+
+// WriteEntries sends entries, dialing first if there is no connection.
+// A broken connection is closed, so that the next write dials again.
+func (f *Forwarder) WriteEntries(entries []MyLog.Entry) error {
+	msg := f.encode(entries)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn == nil {
+		conn, err := net.DialTimeout("tcp", f.opts.Addr, f.opts.Timeout)
+		if err != nil {
+			return err
+		}
+		f.conn = conn
+	}
+
+	f.conn.SetWriteDeadline(time.Now().Add(f.opts.Timeout))
+	if _, err := f.conn.Write(msg); err != nil {
+		f.conn.Close()
+		f.conn = nil
+		return err
+	}
+	return nil
+}
+
+// Close closes the connection
+func (f *Forwarder) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.conn == nil {
+		return nil
+	}
+	err := f.conn.Close()
+	f.conn = nil
+	return err
+}
+
+// encode renders entries as forward mode messages, one per tag:
+// [tag, [[time, record], ...]]
+func (f *Forwarder) encode(entries []MyLog.Entry) []byte {
+	var tags []string
+	byTag := map[string][]MyLog.Entry{}
+	for _, e := range entries {
+		tag := f.opts.Tag
+		if f.opts.TagLevel {
+			tag += "." + e.Level.String()
+		}
+		if _, ok := byTag[tag]; !ok {
+			tags = append(tags, tag)
+		}
+		byTag[tag] = append(byTag[tag], e)
+	}
+
+	var b []byte
+	for _, tag := range tags {
+		b = appendArrayHeader(b, 2)
+		b = appendString(b, tag)
+		b = appendArrayHeader(b, len(byTag[tag]))
+		for _, e := range byTag[tag] {
+			b = appendArrayHeader(b, 2)
+			b = appendEventTime(b, e.Time)
+			b = appendRecord(b, e)
+		}
+	}
+	return b
+}
+
+// appendRecord appends the record map of e; fields are merged into it
+// unless they collide with the standard keys
+func appendRecord(b []byte, e MyLog.Entry) []byte {
+	std := [][2]string{
+		{"level", e.Level.String()},
+		{"message", e.Message},
+		{"logger", e.Name},
+		{"caller", e.Caller},
+		{"stack", e.Stack},
+	}
+
+	n := 0
+	for i, kv := range std {
+		if i < 2 || kv[1] != "" {
+			n++
+		}
+	}
+	for k := range e.Fields {
+		if !reserved(k) {
+			n++
+		}
+	}
+
+	b = appendMapHeader(b, n)
+	for i, kv := range std {
+		if i < 2 || kv[1] != "" {
+			b = appendString(b, kv[0])
+			b = appendString(b, kv[1])
+		}
+	}
+	for k, v := range e.Fields {
+		if !reserved(k) {
+			b = appendString(b, k)
+			b = appendValue(b, v)
+		}
+	}
+	return b
+}
+
+func reserved(k string) bool {
+	switch k {
+	case "level", "message", "logger", "caller", "stack":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package fluent
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestEncode(t *testing.T) {
+	f := New(Options{Tag: "app", TagLevel: true})
+	ts := time.Unix(1, 2)
+	got := f.encode([]MyLog.Entry{
+		{Time: ts, Level: MyLog.LevelInfo, Message: "a", Fields: MyLog.Fields{"n": 1, "level": "hidden"}},
+		{Time: ts, Level: MyLog.LevelError, Message: "b", Name: "db"},
+		{Time: ts, Level: MyLog.LevelInfo, Message: "c"},
+	})
+
+	var want []byte
+	// app.info with entries a and c, then app.error with b
+	want = appendArrayHeader(want, 2)
+	want = appendString(want, "app.info")
+	want = appendArrayHeader(want, 2)
+	want = appendArrayHeader(want, 2)
+	want = appendEventTime(want, ts)
+	want = appendMapHeader(want, 3)
+	want = appendString(appendString(want, "level"), "info")
+	want = appendString(appendString(want, "message"), "a")
+	want = appendValue(appendString(want, "n"), 1)
+	want = appendArrayHeader(want, 2)
+	want = appendEventTime(want, ts)
+	want = appendMapHeader(want, 2)
+	want = appendString(appendString(want, "level"), "info")
+	want = appendString(appendString(want, "message"), "c")
+	want = appendArrayHeader(want, 2)
+	want = appendString(want, "app.error")
+	want = appendArrayHeader(want, 1)
+	want = appendArrayHeader(want, 2)
+	want = appendEventTime(want, ts)
+	want = appendMapHeader(want, 3)
+	want = appendString(appendString(want, "level"), "error")
+	want = appendString(appendString(want, "message"), "b")
+	want = appendString(appendString(want, "logger"), "db")
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("encode =\n% x\nwant\n% x", got, want)
+	}
+}
+
+func TestForwarder(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+
+	// the first connection is dropped after one read, the second kept
+	received := make(chan []byte, 2)
+	go func() {
+		for i := 0; i < 2; i++ {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			buf := make([]byte, 4096)
+			n, _ := conn.Read(buf)
+			received <- buf[:n]
+			if i == 0 {
+				conn.Close()
+			} else {
+				io.Copy(io.Discard, conn)
+			}
+		}
+	}()
+
+	f := New(Options{Addr: ln.Addr().String(), Timeout: time.Second})
+	defer f.Close()
+	entries := []MyLog.Entry{{Time: time.Unix(1, 0), Level: MyLog.LevelWarn, Message: "x"}}
+
+	if err := f.WriteEntries(entries); err != nil {
+		t.Fatal(err)
+	}
+	if got := <-received; !bytes.Equal(got, f.encode(entries)) {
+		t.Errorf("received % x", got)
+	}
+
+	// writes to the closed connection fail until it is dialed again
+	deadline := time.Now().Add(5 * time.Second)
+	for f.WriteEntries(entries) == nil && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := f.WriteEntries(entries); err != nil {
+		t.Fatalf("no reconnect: %v", err)
+	}
+	if got := <-received; !bytes.Equal(got, f.encode(entries)) {
+		t.Errorf("received % x after reconnect", got)
+	}
+}
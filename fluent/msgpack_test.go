@@ -0,0 +1,72 @@
+package fluent
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendValue(t *testing.T) {
+	var nilPtr *int
+	n := 5
+	tests := []struct {
+		v    interface{}
+		want []byte
+	}{
+		{nil, []byte{0xc0}},
+		{true, []byte{0xc3}},
+		{false, []byte{0xc2}},
+		{0, []byte{0x00}},
+		{127, []byte{0x7f}},
+		{200, []byte{0xcc, 0xc8}},
+		{65535, []byte{0xcd, 0xff, 0xff}},
+		{1 << 20, []byte{0xce, 0x00, 0x10, 0x00, 0x00}},
+		{int64(1) << 40, []byte{0xcf, 0, 0, 0x01, 0, 0, 0, 0, 0}},
+		{-1, []byte{0xff}},
+		{-32, []byte{0xe0}},
+		{-100, []byte{0xd0, 0x9c}},
+		{-1000, []byte{0xd1, 0xfc, 0x18}},
+		{-100000, []byte{0xd2, 0xff, 0xfe, 0x79, 0x60}},
+		{uint8(7), []byte{0x07}},
+		{1.5, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"ab", []byte{0xa2, 'a', 'b'}},
+		{errors.New("x"), []byte{0xa1, 'x'}},
+		{time.Second, []byte{0xa2, '1', 's'}},
+		{[]byte("hi"), []byte{0xa2, 'h', 'i'}},
+		{[]int{1, 2}, []byte{0x92, 0x01, 0x02}},
+		{map[int]bool{3: true}, []byte{0x81, 0xa1, '3', 0xc3}},
+		{&n, []byte{0x05}},
+		{nilPtr, []byte{0xc0}},
+		{struct{}{}, []byte{0xa2, '{', '}'}},
+	}
+	for _, tt := range tests {
+		if got := appendValue(nil, tt.v); !bytes.Equal(got, tt.want) {
+			t.Errorf("appendValue(%#v) = % x, want % x", tt.v, got, tt.want)
+		}
+	}
+}
+
+func TestAppendHeaders(t *testing.T) {
+	tests := []struct {
+		got, want []byte
+	}{
+		{appendString(nil, strings.Repeat("x", 31))[:1], []byte{0xbf}},
+		{appendString(nil, strings.Repeat("x", 32))[:2], []byte{0xd9, 32}},
+		{appendString(nil, strings.Repeat("x", 256))[:3], []byte{0xda, 0x01, 0x00}},
+		{appendString(nil, strings.Repeat("x", 65536))[:5], []byte{0xdb, 0, 0x01, 0, 0}},
+		{appendArrayHeader(nil, 15), []byte{0x9f}},
+		{appendArrayHeader(nil, 16), []byte{0xdc, 0, 16}},
+		{appendArrayHeader(nil, 65536), []byte{0xdd, 0, 0x01, 0, 0}},
+		{appendMapHeader(nil, 15), []byte{0x8f}},
+		{appendMapHeader(nil, 16), []byte{0xde, 0, 16}},
+		{appendMapHeader(nil, 65536), []byte{0xdf, 0, 0x01, 0, 0}},
+		{appendEventTime(nil, time.Unix(1, 2)), []byte{0xd7, 0x00, 0, 0, 0, 1, 0, 0, 0, 2}},
+	}
+	for i, tt := range tests {
+		if !bytes.Equal(tt.got, tt.want) {
+			t.Errorf("%d: % x, want % x", i, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// Format selects how entries are rendered
+type Format uint8
+
+const (
+	FormatText   Format = iota // plain text with prefixes (default)
+	FormatJSON                 // one JSON object per line
+	FormatLogfmt               // key=value pairs, one entry per line
+)
+
+var formatNames = []string{"text", "json", "logfmt"}
+
+func (f Format) String() string {
+	if int(f) < len(formatNames) {
+		return formatNames[f]
+	}
+	return "unknown"
+}
+
+// ParseFormat returns the format for a name like "text" or "JSON"
+func ParseFormat(name string) (Format, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for i, n := range formatNames {
+		if n == name {
+			return Format(i), nil
+		}
+	}
+	return FormatText, fmt.Errorf("unknown log format %q", name)
+}
+
+// SetFormat sets the output format for all levels
+func (l *Log) SetFormat(f Format) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = f
+}
+
+// GetFormat returns the current output format
+func (l *Log) GetFormat() Format {
+	if !l.ready() {
+		return FormatText
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.format
+}
+
+type jsonEntry struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Prefix    string `json:"prefix,omitempty"`
+	Logger    string `json:"logger,omitempty"`
+	Message   string `json:"message"`
+	Fields    Fields `json:"fields,omitempty"`
+	Caller    string `json:"caller,omitempty"`
+	Stack     string `json:"stack,omitempty"`
+}
+
+// MarshalJSON renders e like FormatJSON does. Field values that cannot
+// be marshaled are rendered as strings.
+func (e Entry) MarshalJSON() ([]byte, error) {
+	je := jsonEntry{
+		Timestamp: e.Time.Format(time.RFC3339Nano),
+		Level:     e.Level.String(),
+		Prefix:    e.Prefix,
+		Logger:    e.Name,
+		Message:   e.Message,
+		Fields:    jsonFields(e.Fields, false),
+		Caller:    e.Caller,
+		Stack:     e.Stack,
+	}
+
+	data, err := json.Marshal(je)
+	if err != nil {
+		// some field value is not marshalable, fall back to strings
+		je.Fields = jsonFields(e.Fields, true)
+		data, err = json.Marshal(je)
+	}
+	return data, err
+}
+
+// jsonFields prepares fields for marshaling. Errors are always
+// rendered by their message, all other values only if force is set.
+func jsonFields(f Fields, force bool) Fields {
+	if len(f) == 0 {
+		return nil
+	}
+
+	out := make(Fields, len(f))
+	for k, v := range f {
+		switch t := v.(type) {
+		case error:
+			out[k] = t.Error()
+		default:
+			if force {
+				out[k] = fmt.Sprint(v)
+			} else {
+				out[k] = v
+			}
+		}
+	}
+
+	return out
+}
+
+// formatLine renders msg like lg would, but with the timestamp t
+// formatted by tf
+func formatLine(lg *log.Logger, tf timeFormat, t time.Time, msg string) []byte {
+	return appendLine(nil, lg.Flags(), lg.Prefix(), tf, t, msg)
+}
+
+// appendLine appends msg rendered like a log.Logger with the given
+// flags and prefix would, but with the timestamp t formatted by tf.
+// The message is the concatenation of msg.
+func appendLine(b []byte, flags int, prefix string, tf timeFormat, t time.Time, msg ...string) []byte {
+	if flags&log.Lmsgprefix == 0 {
+		b = append(b, prefix...)
+	}
+	b = tf.appendTime(b, flags, t)
+	if flags&log.Lmsgprefix != 0 {
+		b = append(b, prefix...)
+	}
+
+	start := len(b)
+	for _, s := range msg {
+		b = append(b, s...)
+	}
+	if len(b) == start || b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+
+	return b
+}
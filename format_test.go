@@ -0,0 +1,64 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFormat(t *testing.T) {
+	for _, name := range []string{"text", "JSON", " logfmt "} {
+		f, err := ParseFormat(name)
+		if err != nil || f.String() != strings.ToLower(strings.TrimSpace(name)) {
+			t.Errorf("ParseFormat(%q) = %v, %v", name, f, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("ParseFormat(xml) succeeded")
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetFormat(FormatJSON)
+
+	l.WithFields(Fields{"n": 3, "err": errors.New("boom")}).Warn("disk %s", "full")
+	l.Error("failed")
+
+	var e map[string]interface{}
+	if err := json.Unmarshal([]byte(stdout.String()), &e); err != nil {
+		t.Fatalf("stdout is not JSON: %v: %q", err, stdout.String())
+	}
+	if e["level"] != "warn" || e["message"] != "disk full" || e["prefix"] != "WARN" {
+		t.Errorf("entry = %v", e)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, e["timestamp"].(string)); err != nil {
+		t.Errorf("timestamp: %v", err)
+	}
+	fields, _ := e["fields"].(map[string]interface{})
+	if fields["n"] != 3.0 || fields["err"] != "boom" {
+		t.Errorf("fields = %v", fields)
+	}
+
+	if err := json.Unmarshal([]byte(stderr.String()), &e); err != nil || e["level"] != "error" {
+		t.Errorf("stderr = %q, %v", stderr.String(), err)
+	}
+}
+
+func TestMarshalJSONFallback(t *testing.T) {
+	e := Entry{Level: LevelInfo, Message: "m", Fields: Fields{"nan": math.NaN(), "ch": make(chan int)}}
+	data, err := e.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct{ Fields map[string]string }
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Fields["nan"] != "NaN" || !strings.HasPrefix(got.Fields["ch"], "0x") {
+		t.Errorf("fields = %v", got.Fields)
+	}
+}
@@ -0,0 +1,186 @@
+package MyLog
+
+import (
+	"io"
+	"strings"
+)
+
+// Formatter renders an entry as one or more complete lines, including
+// the trailing newline
+type Formatter interface {
+	Format(e Entry) ([]byte, error)
+}
+
+// FormatterFunc adapts a function to a Formatter
+type FormatterFunc func(e Entry) ([]byte, error)
+
+func (f FormatterFunc) Format(e Entry) ([]byte, error) {
+	return f(e)
+}
+
+// entryAppender is implemented by the formatters of this package to
+// render into pooled buffers
+type entryAppender interface {
+	appendEntry(b []byte, e *Entry) ([]byte, error)
+}
+
+// JSONFormatter renders entries like FormatJSON
+type JSONFormatter struct{}
+
+func (JSONFormatter) Format(e Entry) ([]byte, error) {
+	return JSONFormatter{}.appendEntry(nil, &e)
+}
+
+func (JSONFormatter) appendEntry(b []byte, e *Entry) ([]byte, error) {
+	data, err := e.MarshalJSON()
+	if err != nil {
+		return b, err
+	}
+	return append(append(b, data...), '\n'), nil
+}
+
+// LogfmtFormatter renders entries like FormatLogfmt
+type LogfmtFormatter struct{}
+
+func (LogfmtFormatter) Format(e Entry) ([]byte, error) {
+	return appendLogfmt(nil, &e), nil
+}
+
+func (LogfmtFormatter) appendEntry(b []byte, e *Entry) ([]byte, error) {
+	return appendLogfmt(b, e), nil
+}
+
+// TextFormatter renders entries like FormatText with the default
+// prefixes and without color
+type TextFormatter struct {
+	Flags      int    // timestamp flags of the log package, e.g. log.LstdFlags
+	TimeFormat string // replaces the date and time flags, see SetTimeFormat
+	UTC        bool
+	Multiline  Multiline
+}
+
+func (f TextFormatter) Format(e Entry) ([]byte, error) {
+	return f.appendEntry(nil, &e)
+}
+
+func (f TextFormatter) appendEntry(b []byte, e *Entry) ([]byte, error) {
+	prefix := "       "
+	if e.Prefix != "" {
+		prefix = defaultPrefix(labelLevel(e.Level))
+	}
+	lf := lineFormat{flags: f.Flags, prefix: prefix, tf: timeFormat{layout: f.TimeFormat, utc: f.UTC}, paint: plain, multiline: f.Multiline}
+	return lf.appendEntry(b, e)
+}
+
+// lineFormat is the text rendering of a Log: the prefix and flags of a
+// level logger, the time format, the painter of the level, the
+// handling of multi-line messages and the width to wrap lines at
+type lineFormat struct {
+	flags     int
+	prefix    string
+	tf        timeFormat
+	paint     paintFunc
+	multiline Multiline
+	wrap      int // 0 for no wrapping
+}
+
+func (f lineFormat) Format(e Entry) ([]byte, error) {
+	return f.appendEntry(nil, &e)
+}
+
+func (f lineFormat) appendEntry(b []byte, e *Entry) ([]byte, error) {
+	text := e.text()
+	multi := f.multiline != MultilineRaw && strings.IndexByte(text, '\n') >= 0
+	if multi && f.multiline == MultilineEscape {
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r", `\r`), "\n", `\n`)
+		multi = false
+	}
+	if !multi && f.wrap <= 0 {
+		return appendLine(b, f.flags, f.prefix, f.tf, e.Time, f.paint(text), e.stackSuffix()), nil
+	}
+
+	start := len(b)
+	b = appendLine(b, f.flags, f.prefix, f.tf, e.Time)
+	b = b[:len(b)-1]
+	head := string(b[start:])
+	indent := strings.Repeat(" ", visibleWidth(head))
+	cont := ""
+	switch f.multiline {
+	case MultilineIndent:
+		cont = indent
+	case MultilinePrefix:
+		cont = head
+	}
+
+	for i, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		width := len(indent)
+		if i > 0 {
+			b = append(b, '\n')
+			b = append(b, cont...)
+			width = visibleWidth(cont)
+		}
+		for j, seg := range wrapText(line, f.wrap-width) {
+			if j > 0 {
+				b = append(b, '\n')
+				b = append(b, indent...)
+			}
+			b = append(b, f.paint(seg)...)
+		}
+	}
+	b = append(b, e.stackSuffix()...)
+	return append(b, '\n'), nil
+}
+
+// formatter returns the Formatter of f, nil for FormatText
+func (f Format) formatter() Formatter {
+	switch f {
+	case FormatJSON:
+		return JSONFormatter{}
+	case FormatLogfmt:
+		return LogfmtFormatter{}
+	}
+	return nil
+}
+
+// SetFormatter renders all entries with f, in place of the format set
+// with SetFormat. Entries f fails to render are written as JSON. A nil
+// Formatter restores the format.
+func (l *Log) SetFormatter(f Formatter) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.formatter = f
+}
+
+// lineFormatter returns the Formatter replacing the text lines of l,
+// nil for text; l.mu must be held
+func (l *Log) lineFormatter() Formatter {
+	if l.formatter != nil {
+		return l.formatter
+	}
+	return l.format.formatter()
+}
+
+// writeFormatted renders e with f to w, falling back to JSON if f fails
+func writeFormatted(w io.Writer, f Formatter, e *Entry) error {
+	b := getBuf()
+	defer putBuf(b)
+
+	var err error
+	if a, ok := f.(entryAppender); ok {
+		*b, err = a.appendEntry(*b, e)
+	} else {
+		var data []byte
+		if data, err = f.Format(*e); err == nil {
+			*b = append(*b, data...)
+		}
+	}
+	if err != nil {
+		*b, _ = JSONFormatter{}.appendEntry((*b)[:0], e)
+	}
+
+	_, err = w.Write(*b)
+	return err
+}
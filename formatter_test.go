@@ -0,0 +1,80 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"testing"
+	"time"
+)
+
+// upper renders entries as "LEVEL message"
+var upper = FormatterFunc(func(e Entry) ([]byte, error) {
+	return []byte(e.Level.String() + " " + e.Message + "\n"), nil
+})
+
+func TestSetFormatter(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetFormatter(upper)
+
+	l.StandardInfo("hello")
+	l.Error("failed")
+	if stdout.String() != "info hello\n" || stderr.String() != "error failed\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	l.SetFormatter(nil)
+	l.StandardInfo("text")
+	if got := stdout.lines(); got[len(got)-1] != "INFO:  text" {
+		t.Errorf("after SetFormatter(nil) = %q", got[len(got)-1])
+	}
+}
+
+func TestFormatterFallback(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetFormatter(FormatterFunc(func(Entry) ([]byte, error) { return nil, errors.New("broken") }))
+
+	l.StandardInfo("hello")
+	var e struct{ Message string }
+	if err := json.Unmarshal([]byte(stdout.String()), &e); err != nil || e.Message != "hello" {
+		t.Errorf("fallback output = %q, %v", stdout.String(), err)
+	}
+}
+
+func TestOutputFormatter(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	out := new(syncBuffer)
+	l.AddOutput(out, OutputOptions{Format: FormatJSON, Formatter: upper})
+
+	l.Warn("disk")
+	if out.String() != "warn disk\n" || stdout.String() != "WARN:  disk\n" {
+		t.Errorf("output = %q, stdout = %q", out.String(), stdout.String())
+	}
+}
+
+func TestTextFormatter(t *testing.T) {
+	e := Entry{Time: time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC), Level: LevelWarn, Prefix: "WARN", Message: "disk"}
+	b, err := TextFormatter{Flags: log.LstdFlags, TimeFormat: "15:04", UTC: true}.Format(e)
+	if err != nil || string(b) != "WARN:  15:04 disk\n" {
+		t.Errorf("Format() = %q, %v", b, err)
+	}
+
+	e.Prefix = ""
+	if b, _ := (TextFormatter{}).Format(e); string(b) != "       disk\n" {
+		t.Errorf("standard entry = %q", b)
+	}
+}
+
+func TestBuiltinFormatters(t *testing.T) {
+	e := Entry{Level: LevelInfo, Message: "m"}
+	for _, f := range []Formatter{JSONFormatter{}, LogfmtFormatter{}} {
+		a, err := f.Format(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, _ := f.(entryAppender).appendEntry(nil, &e)
+		if string(a) != string(b) || a[len(a)-1] != '\n' {
+			t.Errorf("%T: Format() = %q, appendEntry() = %q", f, a, b)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package gcplog writes MyLog entries as structured JSON lines in the
+// format read by the Google Cloud Logging agents of GKE and Cloud Run,
+// so that severities, labels and trace correlation show up in the Logs
+// Explorer. The plain output of the Log should then be discarded, e.g.
+// with l.Init(io.Discard, io.Discard).
+package gcplog
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Field names the trace context is read from, as set by the otlp package
+const (
+	TraceIDField = "trace_id"
+	SpanIDField  = "span_id"
+)
+
+// Options configures a Writer
+type Options struct {
+	Output    io.Writer         // default os.Stdout
+	ProjectID string            // for trace correlation, default $GOOGLE_CLOUD_PROJECT
+	Labels    map[string]string // labels added to every entry
+}
+
+// Writer renders entries as Cloud Logging structured JSON lines
+type Writer struct {
+	opts   Options
+	labels map[string]string
+
+	mu sync.Mutex
+}
+
+// New returns a Writer. On Cloud Run the service and revision are
+// added to the labels.
+func New(opts Options) *Writer {
+	if opts.Output == nil {
+		opts.Output = os.Stdout
+	}
+	if opts.ProjectID == "" {
+		opts.ProjectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+
+	labels := map[string]string{}
+	for env, label := range map[string]string{"K_SERVICE": "service", "K_REVISION": "revision"} {
+		if v := os.Getenv(env); v != "" {
+			labels[label] = v
+		}
+	}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+
+	return &Writer{opts: opts, labels: labels}
+}
+
+// Attach creates a Writer for all entries of l
+func Attach(l *MyLog.Log, opts Options) *Writer {
+	w := New(opts)
+	l.AddHook(w.Hook)
+	return w
+}
+
+// Hook writes e as a single JSON line
+func (w *Writer) Hook(e MyLog.Entry) error {
+	b, err := json.Marshal(w.payload(e))
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.opts.Output.Write(append(b, '\n'))
+	return err
+}
+
+type sourceLocation struct {
+	File     string `json:"file"`
+	Line     string `json:"line"`
+	Function string `json:"function,omitempty"`
+}
+
+// payload returns the structured payload of e. Fields become top level
+// keys of jsonPayload, the special keys are read by the agent.
+func (w *Writer) payload(e MyLog.Entry) map[string]interface{} {
+	m := make(map[string]interface{}, len(e.Fields)+8)
+	for k, v := range e.Fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		m[k] = v
+	}
+
+	m["severity"] = Severity(e.Level)
+	m["time"] = e.Time
+	m["message"] = e.Message
+	if e.Stack != "" {
+		// a trace in the message is picked up by Error Reporting
+		m["message"] = e.Message + "\n" + e.Stack
+	}
+	if e.Name != "" {
+		m["logger"] = e.Name
+	}
+	if len(w.labels) > 0 {
+		m["logging.googleapis.com/labels"] = w.labels
+	}
+	if loc, ok := parseCaller(e.Caller); ok {
+		m["logging.googleapis.com/sourceLocation"] = loc
+	}
+
+	if id, ok := e.Fields[TraceIDField].(string); ok && w.opts.ProjectID != "" {
+		m["logging.googleapis.com/trace"] = "projects/" + w.opts.ProjectID + "/traces/" + id
+		delete(m, TraceIDField)
+		if span, ok := e.Fields[SpanIDField].(string); ok {
+			m["logging.googleapis.com/spanId"] = span
+			delete(m, SpanIDField)
+		}
+	}
+
+	return m
+}
+
+// parseCaller splits a caller like "file.go:12 pkg.Func"
+func parseCaller(c string) (sourceLocation, bool) {
+	pos, fn, _ := strings.Cut(c, " ")
+	i := strings.LastIndexByte(pos, ':')
+	if i < 0 {
+		return sourceLocation{}, false
+	}
+	if _, err := strconv.Atoi(pos[i+1:]); err != nil {
+		return sourceLocation{}, false
+	}
+	return sourceLocation{File: pos[:i], Line: pos[i+1:], Function: fn}, true
+}
+
+// Severity returns the Cloud Logging severity of a MyLog level
+func Severity(lv MyLog.Level) string {
+	switch lv {
+	case MyLog.LevelTrace, MyLog.LevelDebug:
+		return "DEBUG"
+	case MyLog.LevelVerbose, MyLog.LevelInfo:
+		return "INFO"
+	case MyLog.LevelWarn:
+		return "WARNING"
+	case MyLog.LevelError:
+		return "ERROR"
+	case MyLog.LevelPanic:
+		return "CRITICAL"
+	case MyLog.LevelFatal:
+		return "ALERT"
+	}
+	return "DEFAULT"
+}
@@ -0,0 +1,89 @@
+package gcplog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestHook(t *testing.T) {
+	t.Setenv("K_SERVICE", "api")
+	t.Setenv("K_REVISION", "")
+	var b bytes.Buffer
+	w := New(Options{Output: &b, ProjectID: "proj", Labels: map[string]string{"team": "core"}})
+
+	err := w.Hook(MyLog.Entry{
+		Time:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Level:   MyLog.LevelWarn,
+		Name:    "db",
+		Message: "slow query",
+		Caller:  "db.go:42 db.Query",
+		Fields:  MyLog.Fields{"ms": 900, "err": errors.New("timeout"), TraceIDField: "abc", SpanIDField: "def"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"severity":                      "WARNING",
+		"time":                          "2024-01-02T03:04:05Z",
+		"message":                       "slow query",
+		"logger":                        "db",
+		"ms":                            900.0,
+		"err":                           "timeout",
+		"logging.googleapis.com/trace":  "projects/proj/traces/abc",
+		"logging.googleapis.com/spanId": "def",
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Errorf("%s = %v, want %v", k, m[k], v)
+		}
+	}
+	if _, ok := m[TraceIDField]; ok {
+		t.Error("trace_id kept in the payload")
+	}
+	labels, _ := m["logging.googleapis.com/labels"].(map[string]interface{})
+	if labels["service"] != "api" || labels["team"] != "core" || len(labels) != 2 {
+		t.Errorf("labels = %v", labels)
+	}
+	loc, _ := m["logging.googleapis.com/sourceLocation"].(map[string]interface{})
+	if loc["file"] != "db.go" || loc["line"] != "42" || loc["function"] != "db.Query" {
+		t.Errorf("sourceLocation = %v", loc)
+	}
+}
+
+func TestAttach(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "")
+	var b bytes.Buffer
+	l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+	Attach(l, Options{Output: &b})
+	l.ErrorWithStack(nil, "failed")
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b.Bytes(), &m); err != nil {
+		t.Fatal(err)
+	}
+	if msg, _ := m["message"].(string); m["severity"] != "ERROR" || len(msg) <= len("failed\n") || msg[:7] != "failed\n" {
+		t.Errorf("payload = %v, want the stack in the message", m)
+	}
+	if _, ok := m["logging.googleapis.com/trace"]; ok {
+		t.Error("trace without project")
+	}
+}
+
+func TestParseCaller(t *testing.T) {
+	for _, c := range []string{"", "file.go", "file.go:x fn"} {
+		if _, ok := parseCaller(c); ok {
+			t.Errorf("parseCaller(%q) succeeded", c)
+		}
+	}
+}
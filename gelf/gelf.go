@@ -0,0 +1,243 @@
+// Package gelf sends MyLog entries to Graylog in the GELF format, over
+// UDP with chunking and optional compression, or over TCP.
+package gelf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// maxChunks is the limit of the GELF chunking protocol
+const maxChunks = 128
+
+var errTooLarge = errors.New("gelf: message too large")
+
+// Options configures a Writer
+type Options struct {
+	Addr       string                  // host:port of the GELF input
+	Network    string                  // "udp" (default) or "tcp"
+	Host       string                  // source host, default os.Hostname
+	Compress   bool                    // zlib compress UDP messages
+	ChunkSize  int                     // UDP datagram size, default 1420
+	Fields     map[string]interface{}  // static fields added to every message
+	Timeout    time.Duration           // dial and TCP write timeout, default 5s
+	Batch      MyLog.BatchOptions      // used by Attach
+	Resilience MyLog.ResilienceOptions // retries and circuit breaker used by Attach
+}
+
+// Writer is a MyLog.Sink sending entries to a GELF input. The
+// connection is established with the first write and again after it
+// broke.
+type Writer struct {
+	opts Options
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// New returns a Writer for opts.Addr. It sends synchronously, see
+// Attach for batching and retries.
+func New(opts Options) (*Writer, error) {
+	if opts.Network == "" {
+		opts.Network = "udp"
+	}
+	if opts.Network != "udp" && opts.Network != "tcp" {
+		return nil, fmt.Errorf("gelf: unsupported network %q", opts.Network)
+	}
+	if opts.Host == "" {
+		opts.Host, _ = os.Hostname()
+	}
+	if opts.ChunkSize <= 12 {
+		opts.ChunkSize = 1420
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	return &Writer{opts: opts}, nil
+}
+
+// Attach adds a Writer for all entries of l as a sink, batched and
+// with retries, so dialing never blocks the logging goroutine. l.Flush
+// and l.Close send the queued entries.
+func Attach(l *MyLog.Log, opts Options) (*MyLog.BatchSink, error) {
+	w, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+	b := MyLog.Batch(MyLog.NewResilientSink(w, w.opts.Resilience), w.opts.Batch)
+	l.AddSink(b)
+	return b, nil
+}
+
+// WriteEntries sends entries, over TCP in a single write. A broken
+// connection is closed, so that the next write dials again. Entries
+// too large for UDP are skipped and reported with MyLog.Skipped once
+// the others are sent.
+func (w *Writer) WriteEntries(entries []MyLog.Entry) error {
+	var msgs [][]byte
+	var sent, skipped []int // indexes of the entries of msgs and the skipped ones
+	var errs []error
+	for i, e := range entries {
+		msg, err := json.Marshal(w.message(e))
+		if err != nil {
+			skipped, errs = append(skipped, i), append(errs, err)
+			continue
+		}
+		msgs, sent = append(msgs, msg), append(sent, i)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		conn, err := net.DialTimeout(w.opts.Network, w.opts.Addr, w.opts.Timeout)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+
+	if w.opts.Network == "tcp" {
+		var buf []byte
+		for _, msg := range msgs {
+			buf = append(append(buf, msg...), 0)
+		}
+		w.conn.SetWriteDeadline(time.Now().Add(w.opts.Timeout))
+		if _, err := w.conn.Write(buf); err != nil {
+			w.conn.Close()
+			w.conn = nil
+			return err
+		}
+		return MyLog.Skipped(errors.Join(errs...), skipped)
+	}
+
+	for j, msg := range msgs {
+		if err := w.sendUDP(msg); errors.Is(err, errTooLarge) {
+			skipped, errs = append(skipped, sent[j]), append(errs, err)
+		} else if err != nil {
+			return err
+		}
+	}
+	sort.Ints(skipped)
+	return MyLog.Skipped(errors.Join(errs...), skipped)
+}
+
+// Close closes the connection
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+// sendUDP writes msg as a single datagram or in chunks
+func (w *Writer) sendUDP(msg []byte) error {
+	if w.opts.Compress {
+		var buf bytes.Buffer
+		zw := zlib.NewWriter(&buf)
+		zw.Write(msg)
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		msg = buf.Bytes()
+	}
+
+	if len(msg) <= w.opts.ChunkSize {
+		_, err := w.conn.Write(msg)
+		return err
+	}
+
+	// chunk header: magic bytes, message id, sequence number and count
+	size := w.opts.ChunkSize - 12
+	count := (len(msg) + size - 1) / size
+	if count > maxChunks {
+		return errTooLarge
+	}
+
+	var id [8]byte
+	rand.Read(id[:])
+
+	chunk := make([]byte, 0, w.opts.ChunkSize)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*size, len(msg))
+		chunk = append(chunk[:0], 0x1e, 0x0f)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, msg[i*size:end]...)
+		if _, err := w.conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// message converts e into a GELF 1.1 message
+func (w *Writer) message(e MyLog.Entry) map[string]interface{} {
+	m := map[string]interface{}{
+		"version":       "1.1",
+		"host":          w.opts.Host,
+		"short_message": e.Message,
+		"timestamp":     float64(e.Time.UnixMicro()) / 1e6,
+		"level":         severity(e.Level),
+		"_level_name":   e.Level.String(),
+	}
+	if e.Stack != "" {
+		m["full_message"] = e.Message + "\n" + e.Stack
+	}
+	if e.Name != "" {
+		m["_logger"] = e.Name
+	}
+	if e.Caller != "" {
+		m["_caller"] = e.Caller
+	}
+
+	for k, v := range w.opts.Fields {
+		m[fieldName(k)] = v
+	}
+	for k, v := range e.Fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		m[fieldName(k)] = v
+	}
+	return m
+}
+
+// fieldName returns the GELF name of an additional field; "id" is reserved
+func fieldName(k string) string {
+	k = strings.TrimPrefix(k, "_")
+	if k == "id" {
+		k = "id_"
+	}
+	return "_" + k
+}
+
+// severity maps a MyLog level onto a syslog severity
+func severity(lv MyLog.Level) int {
+	switch {
+	case lv >= MyLog.LevelPanic:
+		return 2
+	case lv == MyLog.LevelError:
+		return 3
+	case lv == MyLog.LevelWarn:
+		return 4
+	case lv == MyLog.LevelInfo:
+		return 6
+	}
+	return 7
+}
@@ -0,0 +1,173 @@
+package gelf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func listenUDP(t *testing.T) *net.UDPConn {
+	c, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Skip(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	c.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return c
+}
+
+func readDatagram(t *testing.T, c *net.UDPConn) []byte {
+	buf := make([]byte, 65536)
+	n, err := c.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf[:n]
+}
+
+func decode(t *testing.T, msg []byte) map[string]interface{} {
+	var m map[string]interface{}
+	if err := json.Unmarshal(msg, &m); err != nil {
+		t.Fatalf("%q: %v", msg, err)
+	}
+	return m
+}
+
+var entry = MyLog.Entry{
+	Time:    time.Unix(1700000000, 250000000),
+	Level:   MyLog.LevelWarn,
+	Name:    "db",
+	Message: "careful",
+	Fields:  MyLog.Fields{"id": 7, "err": errors.New("timeout")},
+}
+
+func TestUDP(t *testing.T) {
+	c := listenUDP(t)
+	w, err := New(Options{Addr: c.LocalAddr().String(), Host: "web1", Fields: map[string]interface{}{"env": "test"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	if err := w.WriteEntries([]MyLog.Entry{entry}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := decode(t, readDatagram(t, c))
+	want := map[string]interface{}{
+		"version": "1.1", "host": "web1", "short_message": "careful", "timestamp": 1700000000.25,
+		"level": 4.0, "_level_name": "warn", "_logger": "db", "_id_": 7.0, "_err": "timeout", "_env": "test",
+	}
+	for k, v := range want {
+		if m[k] != v {
+			t.Errorf("%s = %v, want %v", k, m[k], v)
+		}
+	}
+}
+
+func TestUDPChunked(t *testing.T) {
+	c := listenUDP(t)
+	w, _ := New(Options{Addr: c.LocalAddr().String(), Compress: true, ChunkSize: 40})
+	defer w.Close()
+
+	e := entry
+	e.Message = strings.Repeat("long message ", 20)
+	if err := w.WriteEntries([]MyLog.Entry{e}); err != nil {
+		t.Fatal(err)
+	}
+
+	var data []byte
+	for i, count := 0, 1; i < count; i++ {
+		chunk := readDatagram(t, c)
+		if chunk[0] != 0x1e || chunk[1] != 0x0f || int(chunk[10]) != i {
+			t.Fatalf("chunk %d header % x", i, chunk[:12])
+		}
+		count = int(chunk[11])
+		data = append(data, chunk[12:]...)
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, _ := io.ReadAll(zr)
+	if m := decode(t, msg); m["short_message"] != e.Message {
+		t.Errorf("short_message = %v", m["short_message"])
+	}
+}
+
+func TestUDPTooLarge(t *testing.T) {
+	c := listenUDP(t)
+	w, _ := New(Options{Addr: c.LocalAddr().String()})
+	defer w.Close()
+
+	// more than 128 chunks of the default size
+	e := entry
+	e.Message = strings.Repeat("x", 200000)
+	err := w.WriteEntries([]MyLog.Entry{entry, e})
+	if !errors.Is(err, errTooLarge) {
+		t.Errorf("WriteEntries() = %v, want %v", err, errTooLarge)
+	}
+	var s *MyLog.SkippedError
+	if !errors.As(err, &s) || len(s.Indexes) != 1 || s.Indexes[0] != 1 {
+		t.Errorf("WriteEntries() = %#v, want the large entry skipped", err)
+	}
+	if m := decode(t, readDatagram(t, c)); m["short_message"] != entry.Message {
+		t.Errorf("short_message = %v", m["short_message"])
+	}
+}
+
+func TestTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer ln.Close()
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := bufio.NewReader(conn)
+		var msgs []string
+		for len(msgs) < 2 {
+			msg, err := r.ReadString(0)
+			if err != nil {
+				break
+			}
+			msgs = append(msgs, strings.TrimSuffix(msg, "\x00"))
+		}
+		received <- msgs
+	}()
+
+	w, err := New(Options{Addr: ln.Addr().String(), Network: "tcp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	second := entry
+	second.Message = "second"
+	if err := w.WriteEntries([]MyLog.Entry{entry, second}); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := <-received
+	if len(msgs) != 2 || decode(t, []byte(msgs[1]))["short_message"] != "second" {
+		t.Errorf("received %q", msgs)
+	}
+}
+
+func TestNewUnsupportedNetwork(t *testing.T) {
+	if _, err := New(Options{Network: "unix"}); err == nil {
+		t.Error("New accepted unix")
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/hleinders/MyLog
 
-go 1.17
+go 1.21
 
 require github.com/fatih/color v1.13.0
 
@@ -0,0 +1,20 @@
+module github.com/hleinders/MyLog/gormlog
+
+go 1.21
+
+require (
+	github.com/hleinders/MyLog v0.0.0
+	gorm.io/gorm v1.30.0
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/jinzhu/inflection v1.0.0 // indirect
+	github.com/jinzhu/now v1.1.5 // indirect
+	github.com/mattn/go-colorable v0.1.9 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
+	golang.org/x/text v0.20.0 // indirect
+)
+
+replace github.com/hleinders/MyLog => ../
@@ -0,0 +1,130 @@
+// Package gormlog provides a gorm logger.Interface writing through a
+// MyLog.Log. Failed queries are logged as errors and slow queries as
+// warnings, with the SQL, affected rows and duration as fields.
+package gormlog
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Options configures the logger
+type Options struct {
+	// SlowThreshold makes slower queries warnings, default 200ms,
+	// negative to disable
+	SlowThreshold time.Duration
+
+	// IgnoreRecordNotFound does not log gorm.ErrRecordNotFound
+	IgnoreRecordNotFound bool
+
+	// Level is the initial gorm level, default logger.Warn. At
+	// logger.Info every query is logged as a debug entry.
+	Level logger.LogLevel
+}
+
+// Logger implements logger.Interface
+type Logger struct {
+	l    *MyLog.Log
+	opts Options
+}
+
+// New returns a Logger writing to l, use it as gorm.Config.Logger
+func New(l *MyLog.Log, opts Options) *Logger {
+	if opts.SlowThreshold == 0 {
+		opts.SlowThreshold = 200 * time.Millisecond
+	}
+	if opts.Level == 0 {
+		opts.Level = logger.Warn
+	}
+	return &Logger{l: l, opts: opts}
+}
+
+// LogMode returns a copy of g with the given level
+func (g *Logger) LogMode(lv logger.LogLevel) logger.Interface {
+	c := *g
+	c.opts.Level = lv
+	return &c
+}
+
+// Info logs an info message of gorm
+func (g *Logger) Info(_ context.Context, format string, v ...interface{}) {
+	if g.opts.Level >= logger.Info {
+		g.l.StandardInfo(format, v...)
+	}
+}
+
+// Warn logs a warning of gorm
+func (g *Logger) Warn(_ context.Context, format string, v ...interface{}) {
+	if g.opts.Level >= logger.Warn {
+		g.l.Warn(format, v...)
+	}
+}
+
+// Error logs an error of gorm
+func (g *Logger) Error(_ context.Context, format string, v ...interface{}) {
+	if g.opts.Level >= logger.Error {
+		g.l.Error(format, v...)
+	}
+}
+
+// Trace logs a finished query
+func (g *Logger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if g.opts.Level <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	slow := g.opts.SlowThreshold > 0 && elapsed > g.opts.SlowThreshold
+	failed := err != nil && !(g.opts.IgnoreRecordNotFound && errors.Is(err, gorm.ErrRecordNotFound))
+
+	switch {
+	case failed && g.opts.Level >= logger.Error:
+		g.fields(fc, elapsed).WithField("error", err).Error("query failed")
+	case slow && g.opts.Level >= logger.Warn:
+		g.fields(fc, elapsed).Warn("slow query, over %s", g.opts.SlowThreshold)
+	case g.opts.Level >= logger.Info:
+		g.fields(fc, elapsed).Debug("query")
+	}
+}
+
+// fields returns the Log with the fields of a query
+func (g *Logger) fields(fc func() (string, int64), elapsed time.Duration) *MyLog.Log {
+	sql, rows := fc()
+	f := MyLog.Fields{"sql": sql, "duration": elapsed, "source": source()}
+	if rows >= 0 {
+		f["rows"] = rows
+	}
+	return g.l.WithFields(f)
+}
+
+// pkgDir is the source directory of this package
+var pkgDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}()
+
+// source returns the file and line of the query, the first caller
+// outside of gorm and this package
+func source() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		f, more := frames.Next()
+		dir := filepath.Dir(f.File)
+		if !strings.Contains(filepath.ToSlash(dir), "gorm.io/") && dir != pkgDir {
+			return f.File + ":" + strconv.Itoa(f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package gormlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newLogger(opts Options) (*Logger, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	l := MyLog.New()
+	l.SetOutput(stdout, stderr)
+	l.SetFlags(0)
+	l.SetMode(MyLog.LgDebug)
+	return New(l, opts), stdout, stderr
+}
+
+func query(sql string, rows int64) func() (string, int64) {
+	return func() (string, int64) { return sql, rows }
+}
+
+func TestTrace(t *testing.T) {
+	g, stdout, stderr := newLogger(Options{SlowThreshold: 10 * time.Millisecond})
+	ctx := context.Background()
+
+	g.Trace(ctx, time.Now(), query("SELECT 1", 1), nil)
+	if stdout.String() != "" || stderr.String() != "" {
+		t.Fatalf("fast query logged at the warn level: %q %q", stdout.String(), stderr.String())
+	}
+
+	g.Trace(ctx, time.Now().Add(-time.Second), query("SELECT slow", -1), nil)
+	out := stdout.String()
+	if !strings.HasPrefix(out, "WARN:  slow query, over 10ms ") || !strings.Contains(out, `sql="SELECT slow"`) || strings.Contains(out, "rows=") {
+		t.Errorf("slow query = %q", out)
+	}
+	if !strings.Contains(out, "source=") {
+		t.Errorf("slow query without source: %q", out)
+	}
+
+	g.Trace(ctx, time.Now(), query("DELETE x", 0), errors.New("locked"))
+	if got := stderr.String(); !strings.HasPrefix(got, "ERROR: query failed ") || !strings.Contains(got, "error=locked") || !strings.Contains(got, "rows=0") {
+		t.Errorf("failed query = %q", got)
+	}
+}
+
+func TestRecordNotFound(t *testing.T) {
+	g, _, stderr := newLogger(Options{IgnoreRecordNotFound: true})
+	g.Trace(context.Background(), time.Now(), query("SELECT", 0), gorm.ErrRecordNotFound)
+	if stderr.String() != "" {
+		t.Errorf("ignored error logged: %q", stderr.String())
+	}
+
+	g, _, stderr = newLogger(Options{})
+	g.Trace(context.Background(), time.Now(), query("SELECT", 0), gorm.ErrRecordNotFound)
+	if !strings.Contains(stderr.String(), "record not found") {
+		t.Errorf("not found error = %q", stderr.String())
+	}
+}
+
+func TestLogMode(t *testing.T) {
+	g, stdout, stderr := newLogger(Options{})
+	ctx := context.Background()
+
+	info := g.LogMode(logger.Info)
+	info.Trace(ctx, time.Now(), query("SELECT 1", 1), nil)
+	info.Info(ctx, "migrating %s", "users")
+	if !strings.HasPrefix(stderr.String(), "DEBUG: query ") || stdout.String() != "INFO:  migrating users\n" {
+		t.Errorf("info level: stderr = %q, stdout = %q", stderr.String(), stdout.String())
+	}
+
+	// the original keeps its level
+	stdout.Reset()
+	g.Info(ctx, "hidden")
+	g.Warn(ctx, "shown")
+	if stdout.String() != "WARN:  shown\n" {
+		t.Errorf("warn level = %q", stdout.String())
+	}
+
+	stderr.Reset()
+	silent := g.LogMode(logger.Silent)
+	silent.Error(ctx, "hidden")
+	silent.Trace(ctx, time.Now(), query("SELECT", 0), errors.New("failed"))
+	if stderr.String() != "" {
+		t.Errorf("silent level = %q", stderr.String())
+	}
+}
@@ -0,0 +1,26 @@
+package MyLog
+
+// Group indents the text of all following messages by two more
+// blanks, including their continuation lines and buffered copies,
+// until the matching EndGroup. Groups nest and are shared by the
+// loggers derived from l.
+func (l *Log) Group() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.indent++
+}
+
+// EndGroup ends the innermost group started with Group
+func (l *Log) EndGroup() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.indent > 0 {
+		l.indent--
+	}
+}
@@ -0,0 +1,54 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.EnableBuffer()
+
+	l.StandardInfo("outer")
+	l.Group()
+	l.Warn("inner")
+	l.WithField("k", 1).Group()
+	l.Error("nested")
+	l.EndGroup()
+	l.EndGroup()
+	l.EndGroup()
+	l.StandardInfo("back")
+
+	if want := "INFO:  outer\nWARN:    inner\nINFO:  back\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if want := "ERROR:     nested\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+	if got := l.GetBufferEntries(); len(got) != 4 || got[1].Msg != "  inner" || got[2].Msg != "    nested" {
+		t.Errorf("buffer = %+v, want indented copies", got)
+	}
+}
+
+func TestGroupMultiline(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetMultiline(MultilineIndent)
+
+	l.Group()
+	l.StandardInfo("first\nsecond")
+	if want := "INFO:    first\n         second\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestGroupJSON(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetFormat(FormatJSON)
+
+	l.Group()
+	l.StandardInfo("inner")
+	var e struct{ Message string }
+	if err := json.Unmarshal([]byte(stdout.String()), &e); err != nil || e.Message != "inner" {
+		t.Errorf("JSON entry = %q, %v, want the message unindented", stdout.String(), err)
+	}
+}
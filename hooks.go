@@ -0,0 +1,93 @@
+package MyLog
+
+import (
+	"fmt"
+	"os"
+)
+
+// Hook is called for every entry written by a Log
+type Hook func(e Entry) error
+
+// HookErrorPolicy selects what happens if a hook returns an error
+type HookErrorPolicy uint8
+
+const (
+	HookErrorReport  HookErrorPolicy = iota // write the error to os.Stderr (default)
+	HookErrorIgnore                         // drop the error silently
+	HookErrorDisable                        // report the error and remove the hook
+)
+
+type hook struct {
+	fn       Hook
+	levels   uint16 // bit set of levels the hook is called for, 0 for all
+	disabled bool
+}
+
+// AddHook registers fn to be called for every entry of the given levels,
+// or of all levels if none are given. Hooks are called synchronously in
+// the order they were added; they must not modify the entry's Fields.
+func (l *Log) AddHook(fn Hook, levels ...Level) {
+	if !l.ready() {
+		return
+	}
+	h := &hook{fn: fn}
+	for _, lv := range levels {
+		h.levels |= 1 << lv
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = append(l.hooks, h)
+}
+
+// RemoveHooks removes all hooks
+func (l *Log) RemoveHooks() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hooks = nil
+}
+
+// SetHookErrorPolicy sets how errors returned by hooks are handled
+func (l *Log) SetHookErrorPolicy(p HookErrorPolicy) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hookPolicy = p
+}
+
+// fireHooks calls all hooks registered for the level of e
+func (l *Log) fireHooks(e *Entry) {
+	l.mu.RLock()
+	hooks, policy := l.hooks, l.hookPolicy
+	l.mu.RUnlock()
+
+	for _, h := range hooks {
+		if h.levels != 0 && h.levels&(1<<e.Level) == 0 {
+			continue
+		}
+
+		l.mu.RLock()
+		disabled := h.disabled
+		l.mu.RUnlock()
+		if disabled {
+			continue
+		}
+
+		err := h.fn(*e)
+		if err == nil || policy == HookErrorIgnore {
+			continue
+		}
+
+		fmt.Fprintf(os.Stderr, "MyLog: hook failed: %v\n", err)
+		if policy == HookErrorDisable {
+			l.mu.Lock()
+			h.disabled = true
+			l.mu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package MyLog
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+// captureStderr returns what fn writes to os.Stderr
+func captureStderr(t *testing.T, fn func()) string {
+	f, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	old := os.Stderr
+	os.Stderr = f
+	defer func() { os.Stderr = old }()
+	fn()
+
+	b, err := os.ReadFile(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestAddHook(t *testing.T) {
+	l, _, _ := newTestLog()
+	var all, errs []Entry
+	l.AddHook(func(e Entry) error { all = append(all, e); return nil })
+	l.AddHook(func(e Entry) error { errs = append(errs, e); return nil }, LevelError)
+
+	l.WithField("k", 1).Warn("careful")
+	l.Debug("disabled")
+	l.Error("failed %d", 2)
+
+	if len(all) != 2 || all[0].Message != "careful" || all[0].Fields["k"] != 1 || all[0].Level != LevelWarn {
+		t.Errorf("all = %+v", all)
+	}
+	if len(errs) != 1 || errs[0].Message != "failed 2" || errs[0].Prefix != "ERROR" {
+		t.Errorf("errors = %+v", errs)
+	}
+
+	l.RemoveHooks()
+	l.Error("unhooked")
+	if len(all) != 2 {
+		t.Error("RemoveHooks kept a hook")
+	}
+}
+
+func TestHookErrorPolicy(t *testing.T) {
+	tests := []struct {
+		policy   HookErrorPolicy
+		calls    int
+		reported bool
+	}{
+		{HookErrorReport, 2, true},
+		{HookErrorIgnore, 2, false},
+		{HookErrorDisable, 1, true},
+	}
+	for _, tt := range tests {
+		l, _, _ := newTestLog()
+		l.SetHookErrorPolicy(tt.policy)
+		calls := 0
+		l.AddHook(func(Entry) error { calls++; return errors.New("unreachable") })
+
+		out := captureStderr(t, func() {
+			l.Warn("one")
+			l.Warn("two")
+		})
+		if calls != tt.calls || (out != "") != tt.reported {
+			t.Errorf("policy %d: %d calls, stderr %q", tt.policy, calls, out)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package MyLog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// dumpRedactedHeaders are the headers whose values DumpRequest and
+// DumpResponse hide
+var dumpRedactedHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// DumpRequest logs the request line and headers of r at debug level,
+// followed by the body if body is set. The values of credential and
+// cookie headers are hidden and the body is cut to the limit set with
+// SetDumpLimit. The body is replaced so that it can be read again. Both
+// client and server requests can be dumped.
+func (l *Log) DumpRequest(r *http.Request, body bool) {
+	if !l.ready() || r == nil || !l.Check(LevelDebug) {
+		return
+	}
+
+	uri := r.RequestURI
+	if uri == "" && r.URL != nil {
+		uri = r.URL.RequestURI()
+	}
+	host := r.Host
+	if host == "" && r.URL != nil {
+		host = r.URL.Host
+	}
+	proto := r.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s %s\n", r.Method, uri, proto)
+	if host != "" && r.Header.Get("Host") == "" {
+		fmt.Fprintf(&sb, "Host: %s\n", host)
+	}
+	writeHeaders(&sb, r.Header)
+	if body {
+		r.Body = l.dumpBody(&sb, r.Body, r.ContentLength)
+	}
+	l.logLevel(LevelDebug, strings.TrimRight(sb.String(), "\n"))
+}
+
+// DumpResponse logs the status line and headers of resp at debug level,
+// followed by the body if body is set, like DumpRequest
+func (l *Log) DumpResponse(resp *http.Response, body bool) {
+	if !l.ready() || resp == nil || !l.Check(LevelDebug) {
+		return
+	}
+
+	proto := resp.Proto
+	if proto == "" {
+		proto = "HTTP/1.1"
+	}
+	status := resp.Status
+	if status == "" {
+		status = fmt.Sprintf("%d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s\n", proto, status)
+	writeHeaders(&sb, resp.Header)
+	if body {
+		resp.Body = l.dumpBody(&sb, resp.Body, resp.ContentLength)
+	}
+	l.logLevel(LevelDebug, strings.TrimRight(sb.String(), "\n"))
+}
+
+// writeHeaders writes h sorted by name, with the values of
+// dumpRedactedHeaders hidden
+func writeHeaders(sb *strings.Builder, h http.Header) {
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, v := range h[name] {
+			if dumpRedactedHeaders[http.CanonicalHeaderKey(name)] {
+				v = "[REDACTED]"
+			}
+			fmt.Fprintf(sb, "%s: %s\n", name, v)
+		}
+	}
+}
+
+// dumpBody writes up to the dump limit of body to sb, as hex dump if it
+// is not text, and returns a reader for the complete body. Only the
+// dumped part is read ahead.
+func (l *Log) dumpBody(sb *strings.Builder, body io.ReadCloser, size int64) io.ReadCloser {
+	if body == nil || body == http.NoBody {
+		return body
+	}
+
+	n := l.dumpMax()
+	var r io.Reader = body
+	if n >= 0 {
+		r = io.LimitReader(body, int64(n)+1)
+	}
+	data, err := io.ReadAll(r)
+
+	shown := data
+	if n >= 0 && len(data) > n {
+		shown = data[:n]
+	}
+
+	sb.WriteByte('\n')
+	if utf8.Valid(shown) {
+		sb.WriteString(strings.TrimRight(string(shown), "\n"))
+	} else {
+		sb.WriteString(strings.TrimRight(hex.Dump(shown), "\n"))
+	}
+	switch {
+	case err != nil:
+		fmt.Fprintf(sb, "\n... error reading body: %v", err)
+	case len(shown) < len(data) && size > 0:
+		fmt.Fprintf(sb, "\n... %d more bytes", size-int64(len(shown)))
+	case len(shown) < len(data):
+		sb.WriteString("\n... more bytes")
+	}
+
+	return readCloser{io.MultiReader(bytes.NewReader(data), body), body}
+}
+
+// readCloser reads from the body read ahead and closes the original
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
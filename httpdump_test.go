@@ -0,0 +1,109 @@
+package MyLog
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDumpRequest(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetLevel(LevelDebug)
+
+	r := httptest.NewRequest(http.MethodPost, "/login?next=/", strings.NewReader("user=alice\n"))
+	r.Host = "example.com"
+	r.Header.Set("Content-Type", "text/plain")
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Add("Cookie", "a=1")
+
+	l.DumpRequest(r, true)
+	want := "DEBUG: POST /login?next=/ HTTP/1.1\n" +
+		"Host: example.com\n" +
+		"Authorization: [REDACTED]\n" +
+		"Content-Type: text/plain\n" +
+		"Cookie: [REDACTED]\n" +
+		"\n" +
+		"user=alice\n"
+	if stderr.String() != want {
+		t.Errorf("dump =\n%s\nwant\n%s", stderr.String(), want)
+	}
+
+	// the body can still be read
+	if b, _ := io.ReadAll(r.Body); string(b) != "user=alice\n" {
+		t.Errorf("body after the dump = %q", b)
+	}
+}
+
+func TestDumpClientRequest(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetLevel(LevelDebug)
+
+	r, _ := http.NewRequest(http.MethodGet, "https://example.com/a?b=c", nil)
+	l.DumpRequest(r, true)
+	if stderr.String() != "DEBUG: GET /a?b=c HTTP/1.1\nHost: example.com\n" {
+		t.Errorf("client request = %q", stderr.String())
+	}
+}
+
+func TestDumpResponse(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetLevel(LevelDebug)
+	l.SetDumpLimit(4)
+
+	resp := &http.Response{
+		StatusCode:    http.StatusNotFound,
+		Header:        http.Header{"Set-Cookie": {"id=1"}},
+		Body:          io.NopCloser(strings.NewReader("not found")),
+		ContentLength: 9,
+	}
+	l.DumpResponse(resp, true)
+	want := "DEBUG: HTTP/1.1 404 Not Found\nSet-Cookie: [REDACTED]\n\nnot \n... 5 more bytes\n"
+	if stderr.String() != want {
+		t.Errorf("dump = %q, want %q", stderr.String(), want)
+	}
+	if b, _ := io.ReadAll(resp.Body); string(b) != "not found" {
+		t.Errorf("body after the dump = %q", b)
+	}
+}
+
+func TestDumpBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body io.Reader
+		size int64
+		want string
+	}{
+		{"binary", strings.NewReader("\xff\xfe"), 2, "\n00000000  ff fe                                             |..|"},
+		{"unknown size", strings.NewReader("0123456789"), -1, "\n01234567\n... more bytes"},
+		{"error", io.MultiReader(strings.NewReader("ab"), errReader{}), -1, "\nab\n... error reading body: broken"},
+	}
+	for _, tt := range tests {
+		l := New()
+		l.SetDumpLimit(8)
+		var sb strings.Builder
+		l.dumpBody(&sb, io.NopCloser(tt.body), tt.size)
+		if sb.String() != tt.want {
+			t.Errorf("%s: body = %q, want %q", tt.name, sb.String(), tt.want)
+		}
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("broken") }
+
+func TestDumpRequestDisabled(t *testing.T) {
+	l, _, stderr := newTestLog()
+	r := httptest.NewRequest(http.MethodGet, "/", strings.NewReader("body"))
+	l.DumpRequest(r, true)
+	l.DumpResponse(&http.Response{StatusCode: 200}, false)
+	if stderr.String() != "" {
+		t.Errorf("dumped below the level: %q", stderr.String())
+	}
+	if b, _ := io.ReadAll(r.Body); string(b) != "body" {
+		t.Errorf("body = %q", b)
+	}
+}
@@ -0,0 +1,220 @@
+// Package httplog provides an HTTP middleware writing one MyLog entry
+// per request, either with structured fields or in the Apache combined
+// log format. Server errors are logged as errors and client errors as
+// warnings, all other requests as info entries.
+package httplog
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Format selects how requests are rendered
+type Format uint8
+
+const (
+	FormatFields   Format = iota // message "GET /path 200" with fields (default)
+	FormatCombined               // Apache combined log format as message
+)
+
+// Options configures the middleware
+type Options struct {
+	Format          Format
+	RequestIDHeader string                     // default "X-Request-Id"
+	Skip            func(r *http.Request) bool // e.g. for health checks
+}
+
+// Middleware logs each request through l with the default options
+func Middleware(l *MyLog.Log) func(http.Handler) http.Handler {
+	return MiddlewareWith(l, Options{})
+}
+
+// MiddlewareWith logs each request through l. The request ID is taken
+// from the request header, or generated, and set on the response. The
+// handler finds a Log with the request_id field in the request context,
+// see MyLog.FromContext. A request whose handler panics is logged as
+// server error with status 500.
+func MiddlewareWith(l *MyLog.Log, opts Options) func(http.Handler) http.Handler {
+	if opts.RequestIDHeader == "" {
+		opts.RequestIDHeader = "X-Request-Id"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if opts.Skip != nil && opts.Skip(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			id := r.Header.Get(opts.RequestIDHeader)
+			if id == "" {
+				id = newRequestID()
+			}
+			w.Header().Set(opts.RequestIDHeader, id)
+
+			rl := l.WithField("request_id", id)
+			ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+			r = r.WithContext(MyLog.IntoContext(ctx, rl))
+
+			start := time.Now()
+			rw := &responseWriter{ResponseWriter: w}
+			completed := false
+			defer func() {
+				// a panicking handler is logged as server error, while
+				// the panic goes on to the server or Recover
+				if !completed {
+					rw.status = http.StatusInternalServerError
+				}
+				logRequest(rl, opts.Format, r, rw, start)
+			}()
+			next.ServeHTTP(rw, r)
+			completed = true
+		})
+	}
+}
+
+type requestIDKey struct{}
+
+// RequestID returns the request ID set by the middleware, or ""
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func logRequest(l *MyLog.Log, format Format, r *http.Request, rw *responseWriter, start time.Time) {
+	status := rw.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	var msg string
+	if format == FormatCombined {
+		msg = combined(r, status, rw.bytes, start)
+	} else {
+		msg = r.Method + " " + r.URL.RequestURI() + " " + strconv.Itoa(status)
+		l = l.WithFields(MyLog.Fields{
+			"method":   r.Method,
+			"path":     r.URL.Path,
+			"status":   status,
+			"bytes":    rw.bytes,
+			"duration": time.Since(start),
+			"remote":   remoteHost(r),
+		})
+	}
+
+	switch {
+	case status >= 500:
+		l.Error("%s", msg)
+	case status >= 400:
+		l.Warn("%s", msg)
+	default:
+		l.StandardInfo("%s", msg)
+	}
+}
+
+// combined renders r in the Apache combined log format
+func combined(r *http.Request, status, bytes int, start time.Time) string {
+	user := "-"
+	if u, _, ok := r.BasicAuth(); ok && u != "" {
+		user = u
+	}
+	size := "-"
+	if bytes > 0 {
+		size = strconv.Itoa(bytes)
+	}
+
+	b := make([]byte, 0, 256)
+	b = append(b, remoteHost(r)...)
+	b = append(b, " - "...)
+	b = append(b, user...)
+	b = append(b, " ["...)
+	b = start.AppendFormat(b, "02/Jan/2006:15:04:05 -0700")
+	b = append(b, "] "...)
+	b = strconv.AppendQuote(b, r.Method+" "+r.URL.RequestURI()+" "+r.Proto)
+	b = append(b, ' ')
+	b = strconv.AppendInt(b, int64(status), 10)
+	b = append(b, ' ')
+	b = append(b, size...)
+	b = append(b, ' ')
+	b = strconv.AppendQuote(b, orDash(r.Referer()))
+	b = append(b, ' ')
+	b = strconv.AppendQuote(b, orDash(r.UserAgent()))
+	return string(b)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// remoteHost returns the host of r.RemoteAddr without the port
+func remoteHost(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
+
+func newRequestID() string {
+	var b [8]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// responseWriter records the status and the number of bytes written
+type responseWriter struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += n
+	return n, err
+}
+
+// Flush supports streaming handlers
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack supports websocket upgrades and other protocol switches
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil && w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return conn, rw, err
+}
+
+// Unwrap gives http.ResponseController access to the original writer
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
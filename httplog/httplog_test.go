@@ -0,0 +1,172 @@
+package httplog
+
+import (
+	"bufio"
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// syncBuffer is a bytes.Buffer safe for the server goroutines
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.String()
+}
+
+func newLog() (*MyLog.Log, *syncBuffer, *syncBuffer) {
+	stdout, stderr := new(syncBuffer), new(syncBuffer)
+	l := MyLog.New()
+	l.SetOutput(stdout, stderr)
+	l.SetFlags(0)
+	return l, stdout, stderr
+}
+
+func serve(h http.Handler, method, target string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(method, target, nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestMiddleware(t *testing.T) {
+	l, stdout, stderr := newLog()
+	var ctxID string
+	h := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctxID = RequestID(r.Context())
+		MyLog.FromContext(r.Context()).Warn("inside")
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/broken":
+			w.WriteHeader(http.StatusBadGateway)
+		}
+		w.Write([]byte("hello"))
+	}))
+
+	w := serve(h, "GET", "/ok?x=1")
+	id := w.Header().Get("X-Request-Id")
+	if len(id) != 16 || id != ctxID {
+		t.Errorf("request ID %q, in the context %q", id, ctxID)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "WARN:  inside request_id="+id) {
+		t.Errorf("handler log = %q", out)
+	}
+	for _, f := range []string{"INFO:  GET /ok?x=1 200 ", "bytes=5 ", "method=GET ", "path=/ok ", "remote=192.0.2.1 ", "status=200"} {
+		if !strings.Contains(out, f) {
+			t.Errorf("request log misses %q: %q", f, out)
+		}
+	}
+
+	serve(h, "GET", "/missing")
+	if !strings.Contains(stdout.String(), "WARN:  GET /missing 404 ") {
+		t.Errorf("client error not logged as warning: %q", stdout.String())
+	}
+	serve(h, "GET", "/broken")
+	if !strings.Contains(stderr.String(), "ERROR: GET /broken 502 ") {
+		t.Errorf("server error not logged as error: %q", stderr.String())
+	}
+}
+
+func TestMiddlewareOptions(t *testing.T) {
+	l, stdout, _ := newLog()
+	h := MiddlewareWith(l, Options{
+		Format:          FormatCombined,
+		RequestIDHeader: "X-Trace",
+		Skip:            func(r *http.Request) bool { return r.URL.Path == "/health" },
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	serve(h, "GET", "/health")
+	if stdout.String() != "" {
+		t.Errorf("skipped request logged: %q", stdout.String())
+	}
+
+	r := httptest.NewRequest("POST", "/form", nil)
+	r.RemoteAddr = "192.0.2.1:1234"
+	r.Header.Set("X-Trace", "abc")
+	r.Header.Set("User-Agent", "curl")
+	r.SetBasicAuth("bob", "pw")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if w.Header().Get("X-Trace") != "abc" {
+		t.Errorf("request ID header = %q, want it kept", w.Header().Get("X-Trace"))
+	}
+	out := stdout.String()
+	if !strings.HasPrefix(out, "INFO:  192.0.2.1 - bob [") ||
+		!strings.HasSuffix(out, `] "POST /form HTTP/1.1" 200 - "-" "curl" request_id=abc`+"\n") {
+		t.Errorf("combined log = %q", out)
+	}
+}
+
+func TestMiddlewarePanic(t *testing.T) {
+	l, _, stderr := newLog()
+	h := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	defer func() {
+		if rec := recover(); rec != "boom" {
+			t.Errorf("recovered %v, want the panic passed on", rec)
+		}
+		if !strings.Contains(stderr.String(), "ERROR: GET /p 500 ") {
+			t.Errorf("panicking request logged as %q", stderr.String())
+		}
+	}()
+	serve(h, "GET", "/p")
+}
+
+// hijackRecorder is a ResponseRecorder supporting Hijack
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+}
+
+func (hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	c, _ := net.Pipe()
+	return c, nil, nil
+}
+
+func TestMiddlewareHijack(t *testing.T) {
+	l, stdout, _ := newLog()
+	h := Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		c.Close()
+	}))
+
+	r := httptest.NewRequest("GET", "/ws", nil)
+	h.ServeHTTP(hijackRecorder{httptest.NewRecorder()}, r)
+	if !strings.Contains(stdout.String(), "GET /ws 101 ") {
+		t.Errorf("hijacked request logged as %q", stdout.String())
+	}
+
+	// a writer without Hijack reports it as not supported
+	h = Middleware(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := w.(http.Hijacker).Hijack(); err != http.ErrNotSupported {
+			t.Errorf("Hijack() = %v", err)
+		}
+	}))
+	serve(h, "GET", "/")
+}
@@ -0,0 +1,66 @@
+package httplog
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Recover returns a middleware recovering from panics of the handler.
+// The panic is logged through l with the stack trace, followed by the
+// buffered entries of the request, and the client receives a 500 if
+// nothing was written yet. Inside Middleware, the Log and request ID of
+// the request are used and the buffered entries are those carrying its
+// request_id field; enable the buffer, and debug mode or the flight
+// recorder, to keep them. http.ErrAbortHandler is passed on.
+func Recover(l *MyLog.Log) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw, ok := w.(*responseWriter)
+			if !ok {
+				rw = &responseWriter{ResponseWriter: w}
+			}
+
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				rl, id := l, RequestID(r.Context())
+				if id != "" {
+					rl = MyLog.FromContext(r.Context())
+				}
+				msg := fmt.Sprintf("recovered: %v in %s %s", rec, r.Method, r.URL.RequestURI())
+				if buf := requestBuffer(rl, id); buf != "" {
+					msg += "\nbuffer contents:\n" + buf
+				}
+				rl.Panic("%s", msg)
+
+				if rw.status == 0 {
+					http.Error(rw, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// requestBuffer returns the buffered messages of the request with the
+// given ID, or all of them without an ID
+func requestBuffer(l *MyLog.Log, id string) string {
+	mark := MyLog.Fields{"request_id": id}.String() + " "
+
+	var msgs []string
+	for _, e := range l.GetBufferEntries() {
+		if id == "" || strings.Contains(e.Msg+" ", mark) {
+			msgs = append(msgs, e.Msg)
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
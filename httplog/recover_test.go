@@ -0,0 +1,75 @@
+package httplog
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestRecover(t *testing.T) {
+	l, _, stderr := newLog()
+	l.EnableBuffer()
+	l.SetMode(MyLog.LgDebug | MyLog.LgBuffer)
+	l.AddBuffer("unrelated")
+
+	h := Recover(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+	w := serve(h, "GET", "/p?q=1")
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", w.Code)
+	}
+	out := stderr.String()
+	if !strings.Contains(out, "recovered: boom in GET /p?q=1\nbuffer contents:\nunrelated") {
+		t.Errorf("panic log = %q", out)
+	}
+	if !strings.Contains(out, "recover_test.go") {
+		t.Errorf("panic log without the stack trace: %q", out)
+	}
+}
+
+func TestRecoverInMiddleware(t *testing.T) {
+	l, stdout, stderr := newLog()
+	l.EnableBuffer()
+	l.SetMode(MyLog.LgDebug | MyLog.LgBuffer)
+	l.WithField("request_id", "other").Debug("not this request")
+
+	h := Middleware(l)(Recover(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		MyLog.FromContext(r.Context()).Debug("loading cart")
+		w.WriteHeader(http.StatusAccepted)
+		panic("late")
+	})))
+	r := serve(h, "GET", "/cart")
+	id := r.Header().Get("X-Request-Id")
+
+	if r.Code != http.StatusAccepted {
+		t.Errorf("status = %d, want the status already sent", r.Code)
+	}
+	_, dump, _ := strings.Cut(stderr.String(), "buffer contents:\n")
+	if !strings.HasPrefix(dump, "loading cart request_id="+id) || strings.Contains(dump, "not this request") {
+		t.Errorf("buffer dump = %q, want the entries of the request only", dump)
+	}
+	if !strings.Contains(stdout.String(), "GET /cart 202 ") {
+		t.Errorf("request log = %q", stdout.String())
+	}
+}
+
+func TestRecoverAbort(t *testing.T) {
+	l, _, stderr := newLog()
+	h := Recover(l)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler passed on", rec)
+		}
+		if stderr.String() != "" {
+			t.Errorf("aborted handler logged %q", stderr.String())
+		}
+	}()
+	serve(h, "GET", "/")
+}
@@ -0,0 +1,51 @@
+package MyLog
+
+import (
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Link formats for SetCallerLinks
+const (
+	FileLinks   = "file://{path}"
+	VSCodeLinks = "vscode://file{path}:{line}"
+)
+
+// SetCallerLinks renders the file and line reported with
+// SetReportCaller as OSC 8 hyperlink on terminals, so that clicking it
+// opens the code. format is the URL with the placeholders {path} for the
+// absolute file path and {line}, like FileLinks or VSCodeLinks; an empty
+// format disables the links. Other writers get the plain location.
+func (l *Log) SetCallerLinks(format string) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.callerLinks = format
+}
+
+// linkCaller returns e with its caller as hyperlink if links are set
+// and lg writes entries of e to a terminal; l.mu must be held
+func (l *Log) linkCaller(lg *log.Logger, e *Entry) *Entry {
+	if l.callerLinks == "" || e.Caller == "" || !filepath.IsAbs(e.callerFile) ||
+		os.Getenv("TERM") == "dumb" || !isTerminal(l.targetOut(lg, e.Level)) {
+		return e
+	}
+
+	path := filepath.ToSlash(e.callerFile)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path // Windows drive letters
+	}
+	line := strconv.Itoa(e.callerLine)
+	link := strings.NewReplacer("{path}", (&url.URL{Path: path}).EscapedPath(), "{line}", line).Replace(l.callerLinks)
+
+	loc, fn, _ := strings.Cut(e.Caller, " ")
+	c := *e
+	c.Caller = "\x1b]8;;" + link + "\x1b\\" + loc + "\x1b]8;;\x1b\\ " + fn
+	return &c
+}
@@ -0,0 +1,54 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCallerLinks(t *testing.T) {
+	t.Setenv("TERM", "xterm")
+	_, tty := openPTY(t)
+	l, _, _ := newTestLog()
+	l.SetOutput(tty, tty)
+
+	e := &Entry{Level: LevelWarn, Caller: "main.go:12 main.run", callerFile: "/src/my app/main.go", callerLine: 12}
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "main.go:12 main.run"},
+		{FileLinks, "\x1b]8;;file:///src/my%20app/main.go\x1b\\main.go:12\x1b]8;;\x1b\\ main.run"},
+		{VSCodeLinks, "\x1b]8;;vscode://file/src/my%20app/main.go:12\x1b\\main.go:12\x1b]8;;\x1b\\ main.run"},
+	}
+	for _, tt := range tests {
+		l.SetCallerLinks(tt.format)
+		l.mu.RLock()
+		got := l.linkCaller(l.warningVar, e).Caller
+		l.mu.RUnlock()
+		if got != tt.want {
+			t.Errorf("%q: caller = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+	if e.Caller != "main.go:12 main.run" {
+		t.Errorf("linkCaller changed the entry: %q", e.Caller)
+	}
+
+	t.Setenv("TERM", "dumb")
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if got := l.linkCaller(l.warningVar, e); got != e {
+		t.Errorf("dumb terminal got a link: %q", got.Caller)
+	}
+}
+
+func TestCallerLinksPlain(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetReportCaller(true)
+	l.SetCallerLinks(FileLinks)
+
+	// frames of this package are skipped, the caller is the test runner
+	l.Warn("no terminal")
+	if got := stdout.String(); strings.Contains(got, "\x1b]8;") || !strings.Contains(got, " [testing.go:") {
+		t.Errorf("output = %q", got)
+	}
+}
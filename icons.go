@@ -0,0 +1,122 @@
+package MyLog
+
+import (
+	"os"
+	"runtime"
+	"strings"
+)
+
+// IconMode selects whether level prefixes show icons
+type IconMode uint8
+
+const (
+	IconsOff       IconMode = iota // text prefixes only (default)
+	IconsReplace                   // icons instead of text prefixes
+	IconsAlongside                 // icons followed by the text prefixes
+)
+
+// glyph is a symbol for terminal output with its ASCII fallback
+type glyph [2]string
+
+// String returns the symbol, or the fallback if the terminal does not
+// support Unicode
+func (g glyph) String() string {
+	if unicodeSupported() {
+		return g[0]
+	}
+	return g[1]
+}
+
+// icons are the glyphs of the levels
+var icons = map[Level]glyph{
+	LevelTrace: {"…", "."},
+	LevelDebug: {"⚙", "#"},
+	LevelInfo:  {"ℹ", "i"},
+	LevelWarn:  {"⚠", "!"},
+	LevelError: {"✖", "x"},
+	LevelPanic: {"✖", "X"},
+	LevelFatal: {"✖", "X"},
+}
+
+// glyphs of tasks, sections and widgets; failures use the error icon
+var (
+	iconSuccess  = glyph{"✓", "+"}
+	iconSkipped  = glyph{"–", "-"}
+	sectionGlyph = glyph{"─", "-"}
+	bannerGlyph  = glyph{"═", "="}
+)
+
+// spinnerFrames are the glyphs of a Spinner, with an ASCII fallback
+var spinnerFrames = [2][]string{
+	{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"},
+	{"|", "/", "-", "\\"},
+}
+
+// SetIcons shows icons instead of the text prefixes, or removes them
+func (l *Log) SetIcons(b bool) {
+	if !l.ready() {
+		return
+	}
+	if b {
+		l.SetIconMode(IconsReplace)
+	} else {
+		l.SetIconMode(IconsOff)
+	}
+}
+
+// SetIconMode selects how icons are shown. ASCII fallbacks are used if
+// the terminal does not support Unicode, see unicodeSupported.
+func (l *Log) SetIconMode(m IconMode) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	l.iconMode = m
+	colored := l.coloredPrefix
+	l.mu.Unlock()
+
+	if colored {
+		l.stdVar.SetPrefix(l.stdPrefix())
+		l.SetColorPrefix()
+	} else {
+		l.setPlainPrefix()
+	}
+}
+
+// withIcon returns the text prefix p of lv according to the icon mode.
+// Standard messages get a blank in place of the icon.
+func (l *Log) withIcon(lv Level, p string, standard bool) string {
+	l.mu.RLock()
+	mode := l.iconMode
+	l.mu.RUnlock()
+
+	if mode == IconsOff {
+		return p
+	}
+
+	icon := " "
+	if !standard {
+		icon = icons[lv].String()
+	}
+
+	if mode == IconsReplace {
+		return icon + " "
+	}
+	return icon + " " + p
+}
+
+// unicodeSupported guesses from the locale whether the terminal can
+// show Unicode glyphs. Windows Terminal sets WT_SESSION.
+func unicodeSupported() bool {
+	if runtime.GOOS == "windows" {
+		return os.Getenv("WT_SESSION") != ""
+	}
+
+	for _, v := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if s := os.Getenv(v); s != "" {
+			s = strings.ToUpper(s)
+			return strings.Contains(s, "UTF-8") || strings.Contains(s, "UTF8")
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package MyLog
+
+import (
+	"runtime"
+	"testing"
+)
+
+// setLocale sets the locale variables read by unicodeSupported
+func setLocale(t *testing.T, lang string) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+	t.Setenv("LANG", lang)
+}
+
+func TestUnicodeSupported(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("decided by WT_SESSION")
+	}
+	tests := map[string]bool{
+		"en_US.UTF-8": true,
+		"de_DE.utf8":  true,
+		"C":           false,
+		"":            false,
+	}
+	for lang, want := range tests {
+		setLocale(t, lang)
+		if got := unicodeSupported(); got != want {
+			t.Errorf("LANG=%q: unicodeSupported() = %v, want %v", lang, got, want)
+		}
+	}
+}
+
+func TestSetIconMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("decided by WT_SESSION")
+	}
+	setLocale(t, "C")
+	l, stdout, stderr := newTestLog()
+
+	l.SetIconMode(IconsAlongside)
+	l.Warn("alongside")
+	l.Standard("standard")
+	l.SetIcons(true)
+	l.Warn("replaced")
+	l.SetIcons(false)
+	l.Warn("off")
+
+	want := "! WARN:  alongside\n         standard\n! replaced\nWARN:  off\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+
+	setLocale(t, "en_US.UTF-8")
+	l.SetIcons(true)
+	l.Error("failed")
+	if stderr.String() != "✖ failed\n" {
+		t.Errorf("stderr = %q, want the Unicode icon", stderr.String())
+	}
+}
@@ -0,0 +1,162 @@
+// Package journald sends MyLog entries to the systemd journal using its
+// native protocol, with PRIORITY set from the level and fields passed as
+// journal fields, so that e.g. journalctl -p err filters properly.
+package journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// DefaultSocket is the native protocol socket of systemd-journald
+const DefaultSocket = "/run/systemd/journal/socket"
+
+// Options configures a Writer
+type Options struct {
+	Identifier string            // SYSLOG_IDENTIFIER, default the executable name
+	Socket     string            // default DefaultSocket
+	Fields     map[string]string // static fields added to every entry
+}
+
+// Attach creates a Writer for all entries of l
+func Attach(l *MyLog.Log, opts Options) (*Writer, error) {
+	w, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+	l.AddHook(w.Hook)
+	return w, nil
+}
+
+// Available reports whether the journal socket exists, e.g. to choose
+// between journald and plain output when running as a service
+func Available() bool {
+	_, err := os.Stat(DefaultSocket)
+	return err == nil
+}
+
+// Hook sends e to the journal
+func (w *Writer) Hook(e MyLog.Entry) error {
+	return w.send(w.encode(e))
+}
+
+// encode renders e in the native journal format
+func (w *Writer) encode(e MyLog.Entry) []byte {
+	var b bytes.Buffer
+
+	msg := e.Message
+	if e.Name != "" {
+		msg = "[" + e.Name + "] " + msg
+	}
+	appendField(&b, "MESSAGE", msg)
+	appendField(&b, "PRIORITY", strconv.Itoa(priority(e.Level)))
+	appendField(&b, "SYSLOG_IDENTIFIER", w.opts.Identifier)
+	if e.Name != "" {
+		appendField(&b, "LOGGER", e.Name)
+	}
+	if e.Stack != "" {
+		appendField(&b, "STACK", e.Stack)
+	}
+	if pos, fn, _ := strings.Cut(e.Caller, " "); pos != "" {
+		if i := strings.LastIndexByte(pos, ':'); i > 0 {
+			appendField(&b, "CODE_FILE", pos[:i])
+			appendField(&b, "CODE_LINE", pos[i+1:])
+		}
+		if fn != "" {
+			appendField(&b, "CODE_FUNC", fn)
+		}
+	}
+
+	for k, v := range w.opts.Fields {
+		if name := fieldName(k); name != "" {
+			appendField(&b, name, v)
+		}
+	}
+	for k, v := range e.Fields {
+		if name := fieldName(k); name != "" {
+			appendField(&b, name, fmt.Sprint(v))
+		}
+	}
+
+	return b.Bytes()
+}
+
+// appendField appends one field; values with newlines use the binary
+// form with an explicit length
+func appendField(b *bytes.Buffer, name, value string) {
+	b.WriteString(name)
+	if strings.IndexByte(value, '\n') < 0 {
+		b.WriteByte('=')
+		b.WriteString(value)
+		b.WriteByte('\n')
+		return
+	}
+
+	b.WriteByte('\n')
+	binary.Write(b, binary.LittleEndian, uint64(len(value)))
+	b.WriteString(value)
+	b.WriteByte('\n')
+}
+
+// fieldName converts a field key into a journal field name: upper case
+// letters, digits and underscores, not starting with an underscore
+// or digit. Names of trusted or standard fields are prefixed.
+func fieldName(k string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(k) {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+
+	name := strings.TrimLeft(sb.String(), "_")
+	if name == "" {
+		return ""
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		name = "F_" + name
+	}
+	switch name {
+	case "MESSAGE", "PRIORITY", "SYSLOG_IDENTIFIER", "LOGGER", "STACK", "CODE_FILE", "CODE_LINE", "CODE_FUNC":
+		name = "F_" + name
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// priority maps a MyLog level onto a syslog severity
+func priority(lv MyLog.Level) int {
+	switch {
+	case lv >= MyLog.LevelPanic:
+		return 2
+	case lv == MyLog.LevelError:
+		return 3
+	case lv == MyLog.LevelWarn:
+		return 4
+	case lv == MyLog.LevelInfo:
+		return 6
+	}
+	return 7
+}
+
+func defaults(opts Options) Options {
+	if opts.Identifier == "" {
+		opts.Identifier = filepath.Base(os.Args[0])
+	}
+	if opts.Socket == "" {
+		opts.Socket = DefaultSocket
+	}
+	return opts
+}
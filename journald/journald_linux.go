@@ -0,0 +1,64 @@
+package journald
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"syscall"
+)
+
+// Writer sends entries to the journal socket
+type Writer struct {
+	opts Options
+
+	mu   sync.Mutex
+	conn *net.UnixConn // unconnected, to allow passing file descriptors
+	addr *net.UnixAddr
+}
+
+// New returns a Writer for the journal socket
+func New(opts Options) (*Writer, error) {
+	opts = defaults(opts)
+
+	if _, err := os.Stat(opts.Socket); err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Net: "unixgram"})
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{opts: opts, conn: conn, addr: &net.UnixAddr{Name: opts.Socket, Net: "unixgram"}}, nil
+}
+
+// Close closes the connection to the journal
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.conn.Close()
+}
+
+// send writes msg as a datagram. Messages too large for a datagram are
+// passed as a file descriptor of an unlinked temporary file.
+func (w *Writer) send(msg []byte) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	_, err := w.conn.WriteToUnix(msg, w.addr)
+	if !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+
+	f, err := os.CreateTemp("/dev/shm", "mylog-journal-")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	os.Remove(f.Name())
+
+	if _, err := f.Write(msg); err != nil {
+		return err
+	}
+	_, _, err = w.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), w.addr)
+	return err
+}
@@ -0,0 +1,45 @@
+package journald
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestWriter(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+	journal, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Skip(err)
+	}
+	defer journal.Close()
+
+	l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+	w, err := Attach(l, Options{Identifier: "app", Socket: socket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Close()
+	l.Warn("careful")
+
+	journal.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 4096)
+	n, err := journal.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); !strings.HasPrefix(got, "MESSAGE=careful\nPRIORITY=4\nSYSLOG_IDENTIFIER=app\n") {
+		t.Errorf("datagram = %q", got)
+	}
+}
+
+func TestNewMissingSocket(t *testing.T) {
+	if _, err := New(Options{Socket: filepath.Join(t.TempDir(), "missing")}); err == nil {
+		t.Error("New succeeded without socket")
+	}
+}
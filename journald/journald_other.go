@@ -0,0 +1,24 @@
+//go:build !linux
+
+package journald
+
+import "errors"
+
+// Writer sends entries to the journal socket
+type Writer struct {
+	opts Options
+}
+
+// New fails, the journal is only available on Linux
+func New(opts Options) (*Writer, error) {
+	return nil, errors.New("journald is not supported on this platform")
+}
+
+// Close does nothing
+func (w *Writer) Close() error {
+	return nil
+}
+
+func (w *Writer) send(msg []byte) error {
+	return errors.New("journald is not supported on this platform")
+}
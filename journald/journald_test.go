@@ -0,0 +1,66 @@
+package journald
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestEncode(t *testing.T) {
+	w := &Writer{opts: Options{Identifier: "app", Fields: map[string]string{"unit": "web"}}}
+	got := string(w.encode(MyLog.Entry{
+		Level:   MyLog.LevelError,
+		Name:    "db",
+		Message: "query failed",
+		Caller:  "db.go:42 db.Query",
+		Fields:  MyLog.Fields{"user-id": 7},
+	}))
+
+	for _, field := range []string{
+		"MESSAGE=[db] query failed\n",
+		"PRIORITY=3\n",
+		"SYSLOG_IDENTIFIER=app\n",
+		"LOGGER=db\n",
+		"CODE_FILE=db.go\n",
+		"CODE_LINE=42\n",
+		"CODE_FUNC=db.Query\n",
+		"UNIT=web\n",
+		"USER_ID=7\n",
+	} {
+		if !strings.Contains(got, field) {
+			t.Errorf("missing %q in %q", field, got)
+		}
+	}
+}
+
+func TestAppendFieldMultiline(t *testing.T) {
+	var b bytes.Buffer
+	appendField(&b, "STACK", "a\nb")
+
+	want := []byte("STACK\n")
+	want = binary.LittleEndian.AppendUint64(want, 3)
+	want = append(want, "a\nb\n"...)
+	if !bytes.Equal(b.Bytes(), want) {
+		t.Errorf("got %q, want %q", b.Bytes(), want)
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	tests := map[string]string{
+		"user":                  "USER",
+		"request.id":            "REQUEST_ID",
+		"_private":              "PRIVATE",
+		"9lives":                "F_9LIVES",
+		"message":               "F_MESSAGE",
+		"___":                   "",
+		strings.Repeat("x", 70): strings.Repeat("X", 64),
+	}
+	for k, want := range tests {
+		if got := fieldName(k); got != want {
+			t.Errorf("fieldName(%q) = %q, want %q", k, got, want)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/hleinders/MyLog/kafkasink
+
+go 1.21
+
+require (
+	github.com/hleinders/MyLog v0.0.0
+	github.com/segmentio/kafka-go v0.4.48
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/mattn/go-colorable v0.1.9 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/pierrec/lz4/v4 v4.1.15 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+)
+
+replace github.com/hleinders/MyLog => ../
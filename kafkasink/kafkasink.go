@@ -0,0 +1,126 @@
+// Package kafkasink streams MyLog entries as JSON messages into a Kafka
+// topic.
+package kafkasink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+	"github.com/segmentio/kafka-go"
+)
+
+// Options configures a Producer
+type Options struct {
+	Brokers     []string                // e.g. "localhost:9092"
+	Topic       string                  // topic all entries are written to
+	KeyField    string                  // field used as message key, empty for the level
+	Compression string                  // "gzip", "snappy", "lz4", "zstd" or empty for none
+	Batch       MyLog.BatchOptions      // used by Attach, also sets the messages per request
+	Resilience  MyLog.ResilienceOptions // retries and circuit breaker used by Attach
+}
+
+// Producer is a MyLog.Sink writing each batch of entries to Kafka.
+// Messages with the same key go to the same partition, so entries of
+// one level (or one value of KeyField) keep their order.
+type Producer struct {
+	opts Options
+	w    *kafka.Writer
+}
+
+// New returns a Producer for opts.Topic. It writes synchronously, see
+// Attach for batching and retries.
+func New(opts Options) (*Producer, error) {
+	if len(opts.Brokers) == 0 || opts.Topic == "" {
+		return nil, fmt.Errorf("kafkasink: brokers and topic are required")
+	}
+	if opts.Batch.MaxSize <= 0 {
+		opts.Batch.MaxSize = 100
+	}
+
+	var codec kafka.Compression
+	switch strings.ToLower(opts.Compression) {
+	case "":
+	case "gzip":
+		codec = kafka.Gzip
+	case "snappy":
+		codec = kafka.Snappy
+	case "lz4":
+		codec = kafka.Lz4
+	case "zstd":
+		codec = kafka.Zstd
+	default:
+		return nil, fmt.Errorf("kafkasink: unknown compression %q", opts.Compression)
+	}
+
+	// batching and retries are left to MyLog, a short timeout sends
+	// the messages of one call without waiting for more
+	w := &kafka.Writer{
+		Addr:         kafka.TCP(opts.Brokers...),
+		Topic:        opts.Topic,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    opts.Batch.MaxSize,
+		BatchTimeout: time.Millisecond,
+		MaxAttempts:  1,
+		Compression:  codec,
+	}
+	return &Producer{opts: opts, w: w}, nil
+}
+
+// Attach adds a Producer for all entries of l as a sink, batched and
+// with retries. l.Flush and l.Close write the queued entries.
+func Attach(l *MyLog.Log, opts Options) (*MyLog.BatchSink, error) {
+	p, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+	b := MyLog.Batch(MyLog.NewResilientSink(p, p.opts.Resilience), p.opts.Batch)
+	l.AddSink(b)
+	return b, nil
+}
+
+// WriteEntries writes entries as JSON messages. Entries that cannot be
+// marshalled are skipped and reported with MyLog.Skipped once the
+// others are written.
+func (p *Producer) WriteEntries(entries []MyLog.Entry) error {
+	msgs := make([]kafka.Message, 0, len(entries))
+	var skipped []int
+	var errs []error
+	for i, e := range entries {
+		msg, err := p.message(e)
+		if err != nil {
+			skipped, errs = append(skipped, i), append(errs, err)
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+
+	if err := p.w.WriteMessages(context.Background(), msgs...); err != nil {
+		return err
+	}
+	return MyLog.Skipped(errors.Join(errs...), skipped)
+}
+
+// message converts e into a message keyed by its level or KeyField
+func (p *Producer) message(e MyLog.Entry) (kafka.Message, error) {
+	value, err := e.MarshalJSON()
+	if err != nil {
+		return kafka.Message{}, err
+	}
+
+	key := e.Level.String()
+	if p.opts.KeyField != "" {
+		if v, ok := e.Fields[p.opts.KeyField]; ok {
+			key = fmt.Sprint(v)
+		}
+	}
+	return kafka.Message{Key: []byte(key), Value: value, Time: e.Time}, nil
+}
+
+// Close closes the connections
+func (p *Producer) Close() error {
+	return p.w.Close()
+}
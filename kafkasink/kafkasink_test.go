@@ -0,0 +1,54 @@
+package kafkasink
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+	"github.com/segmentio/kafka-go"
+)
+
+func TestNew(t *testing.T) {
+	p, err := New(Options{Brokers: []string{"localhost:9092"}, Topic: "logs", Compression: "ZSTD"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	if p.w.Compression != kafka.Zstd || p.w.BatchSize != 100 || p.w.MaxAttempts != 1 {
+		t.Errorf("writer = %+v", p.w)
+	}
+
+	for _, opts := range []Options{
+		{Topic: "logs"},
+		{Brokers: []string{"localhost:9092"}},
+		{Brokers: []string{"localhost:9092"}, Topic: "logs", Compression: "brotli"},
+	} {
+		if _, err := New(opts); err == nil {
+			t.Errorf("New(%+v) succeeded", opts)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	p, _ := New(Options{Brokers: []string{"localhost:9092"}, Topic: "logs", KeyField: "user"})
+	defer p.Close()
+	ts := time.Unix(1700000000, 0)
+
+	msg, err := p.message(MyLog.Entry{Time: ts, Level: MyLog.LevelWarn, Message: "careful", Fields: MyLog.Fields{"user": 42}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v map[string]interface{}
+	if err := json.Unmarshal(msg.Value, &v); err != nil || v["message"] != "careful" {
+		t.Errorf("value = %s, %v", msg.Value, err)
+	}
+	if string(msg.Key) != "42" || !msg.Time.Equal(ts) {
+		t.Errorf("key %q, time %v", msg.Key, msg.Time)
+	}
+
+	msg, _ = p.message(MyLog.Entry{Time: ts, Level: MyLog.LevelError, Message: "no user"})
+	if string(msg.Key) != "error" {
+		t.Errorf("key %q, want the level", msg.Key)
+	}
+}
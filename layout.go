@@ -0,0 +1,102 @@
+package MyLog
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// LayoutData holds the values available to layout templates
+type LayoutData struct {
+	Time      string    // timestamp as set with SetTimeFormat and SetTimeMode
+	Timestamp time.Time // for custom formatting, like {{.Timestamp.Format "15:04"}}
+	Level     string    // level label like "WARN"
+	Prefix    string    // level label, empty for standard messages
+	Name      string    // logger name set with Named
+	Message   string
+	Fields    Fields // renders as " key=value" pairs
+	Caller    string
+	Stack     string
+}
+
+// layoutFuncs are available to layout templates in addition to the
+// builtin functions
+var layoutFuncs = template.FuncMap{
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+	"pad":   func(n int, s string) string { return fmt.Sprintf("%-*s", n, s) },
+}
+
+// SetLayout renders text lines with a text/template using the fields
+// of LayoutData, e.g. "{{.Time}} [{{.Level}}] {{.Caller}} {{.Message}}".
+// The functions upper, lower and pad, like {{pad 5 .Level}}, are
+// available as well. A newline is added unless the layout renders one.
+// The layout replaces the format like SetFormatter; an empty layout
+// restores the format.
+func (l *Log) SetLayout(layout string) error {
+	if !l.ready() {
+		return nil
+	}
+	f, err := l.parseLayout(layout)
+	if err != nil {
+		return err
+	}
+	l.SetFormatter(f)
+	return nil
+}
+
+// parseLayout returns the Formatter of layout, nil if it is empty
+func (l *Log) parseLayout(layout string) (Formatter, error) {
+	if layout == "" {
+		return nil, nil
+	}
+	t, err := template.New("layout").Funcs(layoutFuncs).Parse(layout)
+	if err != nil {
+		return nil, err
+	}
+	return layoutFormatter{t: t, l: l}, nil
+}
+
+type layoutFormatter struct {
+	t *template.Template
+	l *Log // for the time format
+}
+
+func (f layoutFormatter) Format(e Entry) ([]byte, error) {
+	return f.appendEntry(nil, &e)
+}
+
+func (f layoutFormatter) appendEntry(b []byte, e *Entry) ([]byte, error) {
+	ts := f.l.timeFormat(e).appendTime(nil, log.LstdFlags, e.Time)
+
+	data := LayoutData{
+		Time:      strings.TrimSuffix(string(ts), " "),
+		Timestamp: e.Time,
+		Level:     strings.ToUpper(labelLevel(e.Level).String()),
+		Prefix:    e.Prefix,
+		Name:      e.Name,
+		Message:   e.Message,
+		Fields:    e.Fields,
+		Caller:    e.Caller,
+		Stack:     e.Stack,
+	}
+
+	w := bytesWriter{b}
+	if err := f.t.Execute(&w, data); err != nil {
+		return b, err
+	}
+	if b = w.b; len(b) == 0 || b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+	return b, nil
+}
+
+// bytesWriter appends to a byte slice
+type bytesWriter struct{ b []byte }
+
+func (w *bytesWriter) Write(p []byte) (int, error) {
+	w.b = append(w.b, p...)
+	return len(p), nil
+}
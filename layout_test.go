@@ -0,0 +1,52 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSetLayout(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetTimeFormat("15:04")
+	if err := l.SetLayout(`{{.Time}} [{{pad 5 .Level}}] {{.Name}}: {{.Message}}{{.Fields}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	l.Named("db").WithField("n", 3).Warn("slow")
+	l.Error("failed")
+
+	out := stdout.String()
+	if len(out) < 6 || out[2] != ':' || !strings.HasSuffix(out, " [WARN ] db: slow n=3\n") {
+		t.Errorf("stdout = %q", out)
+	}
+	if !strings.HasSuffix(stderr.String(), " [ERROR] : failed\n") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestLayoutFuncs(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	if err := l.SetLayout("{{lower .Level}}|{{upper .Message}}|{{.Prefix}}\n"); err != nil {
+		t.Fatal(err)
+	}
+
+	l.Standard("plain")
+	l.StandardInfo("info")
+	if got, want := stdout.String(), "info|PLAIN|\ninfo|INFO|INFO\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+func TestLayoutReset(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	if err := l.SetLayout("{{.Message"); err == nil {
+		t.Error("SetLayout accepted a broken template")
+	}
+	l.SetLayout("<{{.Message}}>")
+	l.SetLayout("")
+
+	l.StandardInfo("text")
+	if stdout.String() != "INFO:  text\n" {
+		t.Errorf("stdout after an empty layout = %q", stdout.String())
+	}
+}
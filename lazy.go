@@ -0,0 +1,54 @@
+package MyLog
+
+// LazyFunc returns the format and arguments of a message. It is only
+// called if the message is actually logged or recorded.
+type LazyFunc func() (format string, v []interface{})
+
+// Check reports whether an entry of level lv would be written, or kept
+// by the flight recorder. Use it to guard expensive argument construction:
+//
+//	if l.Check(MyLog.LevelDebug) {
+//		l.Debug("state: %s", dump(state))
+//	}
+func (l *Log) Check(lv Level) bool {
+	if !l.ready() {
+		return false
+	}
+	if l.Enabled(lv) {
+		return true
+	}
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return lv < LevelInfo && l.recordable(lv)
+}
+
+func (l *Log) TraceLazy(fn LazyFunc) {
+	if !l.ready() {
+		return
+	}
+	if l.Check(LevelTrace) {
+		format, v := fn()
+		l.Trace(format, v...)
+	}
+}
+
+func (l *Log) DebugLazy(fn LazyFunc) {
+	if !l.ready() {
+		return
+	}
+	if l.Check(LevelDebug) {
+		format, v := fn()
+		l.Debug(format, v...)
+	}
+}
+
+func (l *Log) VerboseLazy(fn LazyFunc) {
+	if !l.ready() {
+		return
+	}
+	if l.Check(LevelVerbose) {
+		format, v := fn()
+		l.Verbose(format, v...)
+	}
+}
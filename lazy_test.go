@@ -0,0 +1,41 @@
+package MyLog
+
+import "testing"
+
+func TestLazy(t *testing.T) {
+	l, _, stderr := newTestLog()
+	calls := 0
+	fn := func() (string, []interface{}) {
+		calls++
+		return "state %d", []interface{}{calls}
+	}
+
+	l.DebugLazy(fn)
+	l.TraceLazy(fn)
+	l.VerboseLazy(fn)
+	if calls != 0 {
+		t.Fatalf("disabled levels evaluated the message %d times", calls)
+	}
+
+	l.SetMode(LgDebug)
+	l.DebugLazy(fn)
+	if calls != 1 || stderr.String() != "DEBUG: state 1\n" {
+		t.Errorf("%d calls, stderr = %q", calls, stderr.String())
+	}
+}
+
+func TestCheck(t *testing.T) {
+	l, _, _ := newTestLog()
+	if l.Check(LevelDebug) || !l.Check(LevelInfo) {
+		t.Error("Check does not follow the level")
+	}
+	l.SetMode(LgDebug)
+	if !l.Check(LevelDebug) || l.Check(LevelTrace) {
+		t.Error("Check does not follow the debug mode")
+	}
+
+	l.EnableFlightRecorder(10)
+	if !l.Check(LevelTrace) {
+		t.Error("Check ignores entries kept by the flight recorder")
+	}
+}
@@ -0,0 +1,162 @@
+package MyLog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Level is the severity of a log entry. Levels are ordered, so that
+// a threshold set with SetLevel suppresses everything below it.
+type Level uint8
+
+const (
+	LevelTrace Level = iota
+	LevelDebug
+	LevelVerbose
+	LevelInfo
+	LevelWarn
+	LevelError
+	LevelPanic
+	LevelFatal
+)
+
+var levelNames = []string{"trace", "debug", "verbose", "info", "warn", "error", "panic", "fatal"}
+
+func (lv Level) String() string {
+	if int(lv) < len(levelNames) {
+		return levelNames[lv]
+	}
+	return "unknown"
+}
+
+// ParseLevel returns the level for a name like "debug" or "WARN"
+func ParseLevel(name string) (Level, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "warning" {
+		return LevelWarn, nil
+	}
+
+	for i, n := range levelNames {
+		if n == name {
+			return Level(i), nil
+		}
+	}
+
+	return LevelInfo, fmt.Errorf("unknown log level %q", name)
+}
+
+// SetLevel sets the minimum level of entries that are written.
+// The default is LevelInfo.
+func (l *Log) SetLevel(lv Level) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.level = lv
+}
+
+// GetLevel returns the current level threshold
+func (l *Log) GetLevel() Level {
+	if !l.ready() {
+		return LevelInfo
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.level
+}
+
+// Enabled reports whether entries of the given level are written.
+// The threshold is taken from the module levels for the name of l, if
+// any, otherwise from SetLevel. LgDebug and LgVerbose still enable their level,
+// unless overridden by SetQuiet or Suppress.
+func (l *Log) Enabled(lv Level) bool {
+	if !l.ready() {
+		return false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	switch {
+	case l.suppressed&(1<<lv) != 0, l.quiet && lv < LevelWarn:
+		return false
+	case lv >= l.threshold():
+		return true
+	case lv == LevelDebug:
+		return l.modeRegister&LgDebug != 0
+	case lv == LevelVerbose:
+		return l.modeRegister&LgVerbose != 0
+	}
+
+	return false
+}
+
+// SetModuleLevels sets thresholds for named loggers from a spec like
+// "db=debug,http=warn,*=info". A module also applies to its children,
+// so "db" covers "db.pool" unless that has its own entry. "*" is used
+// for all loggers without a matching module. An empty spec removes
+// all module levels.
+func (l *Log) SetModuleLevels(spec string) error {
+	if !l.ready() {
+		return nil
+	}
+	levels, err := parseModuleLevels(spec)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.moduleLevels = levels
+
+	return nil
+}
+
+// parseModuleLevels parses a spec of SetModuleLevels
+func parseModuleLevels(spec string) (map[string]Level, error) {
+	levels := make(map[string]Level)
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		module, name, ok := strings.Cut(item, "=")
+		module = strings.TrimSpace(module)
+		if !ok || module == "" {
+			return nil, fmt.Errorf("invalid module level %q", item)
+		}
+
+		lv, err := ParseLevel(name)
+		if err != nil {
+			return nil, err
+		}
+		levels[module] = lv
+	}
+	return levels, nil
+}
+
+// threshold returns the level threshold for the name of l.
+// The caller must hold l.mu.
+func (l *Log) threshold() Level {
+	if len(l.moduleLevels) == 0 {
+		return l.level
+	}
+
+	for name := l.name; name != ""; {
+		if lv, ok := l.moduleLevels[name]; ok {
+			return lv
+		}
+
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	if lv, ok := l.moduleLevels["*"]; ok {
+		return lv
+	}
+	return l.level
+}
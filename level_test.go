@@ -0,0 +1,116 @@
+package MyLog
+
+import "testing"
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		want Level
+	}{
+		{"trace", LevelTrace},
+		{"DEBUG", LevelDebug},
+		{" info ", LevelInfo},
+		{"warning", LevelWarn},
+		{"warn", LevelWarn},
+		{"fatal", LevelFatal},
+	}
+	for _, tt := range tests {
+		if got, err := ParseLevel(tt.name); err != nil || got != tt.want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", tt.name, got, err, tt.want)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel(loud) succeeded")
+	}
+	if got := Level(42).String(); got != "unknown" {
+		t.Errorf("Level(42).String() = %q", got)
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	if l.GetLevel() != LevelInfo {
+		t.Fatalf("default level = %v, want info", l.GetLevel())
+	}
+
+	l.SetLevel(LevelWarn)
+	l.StandardInfo("dropped")
+	l.Warn("kept")
+	l.Error("kept")
+	if got, want := stdout.String(), "WARN:  kept\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+	if got, want := stderr.String(), "ERROR: kept\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.SetLevel(LevelError)
+
+	if l.Enabled(LevelWarn) || !l.Enabled(LevelError) || !l.Enabled(LevelFatal) {
+		t.Error("threshold not applied")
+	}
+	// the mode bits still enable their level below the threshold
+	l.SetMode(LgDebug | LgVerbose)
+	if !l.Enabled(LevelDebug) || !l.Enabled(LevelVerbose) || l.Enabled(LevelTrace) {
+		t.Error("mode bits not applied")
+	}
+
+	var nilLog *Log
+	if nilLog.Enabled(LevelFatal) {
+		t.Error("a nil Log is enabled")
+	}
+}
+
+func TestSetModuleLevels(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	if err := l.SetModuleLevels("db=debug, http=warn, *=error"); err != nil {
+		t.Fatal(err)
+	}
+	db, pool, http, other := l.Named("db"), l.Named("db").Named("pool"), l.Named("http"), l.Named("cache")
+
+	tests := []struct {
+		l    *Log
+		lv   Level
+		want bool
+	}{
+		{db, LevelDebug, true},
+		{db, LevelTrace, false},
+		{pool, LevelDebug, true},
+		{http, LevelInfo, false},
+		{http, LevelWarn, true},
+		{other, LevelWarn, false},
+		{other, LevelError, true},
+		{l, LevelWarn, false},
+	}
+	for _, tt := range tests {
+		if got := tt.l.Enabled(tt.lv); got != tt.want {
+			t.Errorf("%q.Enabled(%v) = %v, want %v", tt.l.Name(), tt.lv, got, tt.want)
+		}
+	}
+
+	pool.Debug("checkout")
+	http.StandardInfo("hidden")
+	if stderr.String() != "DEBUG: [db.pool] checkout\n" || stdout.String() != "" {
+		t.Errorf("stderr = %q, stdout = %q", stderr.String(), stdout.String())
+	}
+
+	if err := l.SetModuleLevels(""); err != nil || !http.Enabled(LevelInfo) {
+		t.Errorf("empty spec kept module levels (%v)", err)
+	}
+}
+
+func TestSetModuleLevelsInvalid(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.SetModuleLevels("db=debug")
+	for _, spec := range []string{"db", "=debug", "db=loud"} {
+		if err := l.SetModuleLevels(spec); err == nil {
+			t.Errorf("SetModuleLevels(%q) succeeded", spec)
+		}
+	}
+	if !l.Named("db").Enabled(LevelDebug) {
+		t.Error("an invalid spec replaced the module levels")
+	}
+}
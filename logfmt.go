@@ -0,0 +1,85 @@
+package MyLog
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// appendLogfmt appends the logfmt line of e to b. Fields follow the
+// standard keys, sorted by key.
+func appendLogfmt(b []byte, e *Entry) []byte {
+	b = append(b, "ts="...)
+	b = e.Time.AppendFormat(b, time.RFC3339Nano)
+	b = append(b, " level="...)
+	b = append(b, e.Level.String()...)
+	if e.Name != "" {
+		b = appendLogfmtPair(b, "logger", e.Name)
+	}
+	b = appendLogfmtPair(b, "msg", e.Message)
+
+	keys := make([]string, 0, len(e.Fields))
+	for k := range e.Fields {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		var s string
+		switch v := e.Fields[k].(type) {
+		case string:
+			s = v
+		case error:
+			s = v.Error()
+		default:
+			s = fmt.Sprint(v)
+		}
+		b = appendLogfmtPair(b, logfmtKey(k), s)
+	}
+
+	if e.Caller != "" {
+		b = appendLogfmtPair(b, "caller", e.Caller)
+	}
+	if e.Stack != "" {
+		b = appendLogfmtPair(b, "stack", e.Stack)
+	}
+	return append(b, '\n')
+}
+
+func appendLogfmtPair(b []byte, key, value string) []byte {
+	b = append(b, ' ')
+	b = append(b, key...)
+	b = append(b, '=')
+	if needsQuote(value) {
+		return strconv.AppendQuote(b, value)
+	}
+	return append(b, value...)
+}
+
+// needsQuote reports whether a logfmt value must be quoted
+func needsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || r == 0x7f {
+			return true
+		}
+	}
+	return false
+}
+
+// logfmtKey replaces the characters not allowed in logfmt keys
+func logfmtKey(k string) string {
+	if k == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == 0x7f {
+			return '_'
+		}
+		return r
+	}, k)
+}
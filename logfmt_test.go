@@ -0,0 +1,60 @@
+package MyLog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendLogfmt(t *testing.T) {
+	e := Entry{
+		Time:    time.Date(2024, 1, 2, 15, 4, 5, 0, time.UTC),
+		Level:   LevelWarn,
+		Name:    "db",
+		Message: "disk full",
+		Fields:  Fields{"port": 8080, "err": errors.New("no space"), "a b": "x=y", "empty": ""},
+		Caller:  "main.go:9 main.run",
+	}
+	want := `ts=2024-01-02T15:04:05Z level=warn logger=db msg="disk full" a_b="x=y" empty="" err="no space" port=8080 caller="main.go:9 main.run"` + "\n"
+	if got := string(appendLogfmt(nil, &e)); got != want {
+		t.Errorf("appendLogfmt() =\n%q, want\n%q", got, want)
+	}
+}
+
+func TestLogfmtQuoting(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"plain", "plain"},
+		{"", `""`},
+		{`say "hi"`, `"say \"hi\""`},
+		{"line\nbreak", `"line\nbreak"`},
+		{`back\slash`, `"back\\slash"`},
+		{"ünïcode", "ünïcode"},
+	}
+	for _, tt := range tests {
+		if got := string(appendLogfmtPair(nil, "k", tt.in)); got != " k="+tt.want {
+			t.Errorf("value %q rendered as %q, want %q", tt.in, got, " k="+tt.want)
+		}
+	}
+	if got := logfmtKey(""); got != "_" {
+		t.Errorf("logfmtKey(\"\") = %q", got)
+	}
+}
+
+func TestLogfmtFormat(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetFormat(FormatLogfmt)
+	lf := new(syncBuffer)
+	l.AddOutput(lf, OutputOptions{Format: FormatLogfmt})
+	l.SetFormat(FormatText)
+
+	l.WithField("user", "alice").StandardInfo("login")
+
+	if got := stdout.String(); got != "INFO:  login user=alice\n" {
+		t.Errorf("text output = %q", got)
+	}
+	got := lf.String()
+	if !strings.HasPrefix(got, "ts=") || !strings.HasSuffix(got, " level=info msg=login user=alice\n") {
+		t.Errorf("logfmt output = %q", got)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"io"
 	"log"
 	"os"
-	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/fatih/color"
 )
@@ -21,128 +23,452 @@ const (
 )
 
 // color funcs
-var bold = color.New(color.Bold).SprintFunc()
-var red = color.New(color.FgRed).SprintFunc()
-var yellow = color.New(color.FgYellow).SprintFunc()
-var green = color.New(color.FgGreen).SprintFunc()
-
-// Log is a type for structured message logging
+var bold = painterFor([]color.Attribute{color.Bold})
+var plain = func(s string) string { return s }
+
+// paintFunc colors its argument, like the funcs above
+type paintFunc func(s string) string
+
+// core holds the state shared by a Log and all loggers derived from it
+type core struct {
+	mu             sync.RWMutex // guards all fields below except the level loggers
+	wmu            sync.Mutex   // serializes writes to the underlying writers
+	live           liveLine     // line of a Spinner or ProgressBar, guarded by wmu
+	status         *statusLine  // set with SetStatus, guarded by wmu
+	stdVar         *log.Logger
+	infoVar        *log.Logger
+	debugVar       *log.Logger
+	warningVar     *log.Logger
+	errorVar       *log.Logger
+	panicVar       *log.Logger
+	fatalVar       *log.Logger
+	traceVar       *log.Logger
+	bufferData     ring
+	recorder       *ring      // flight recorder, nil if disabled
+	collector      *collector // tally for Summary, nil if disabled
+	modeRegister   BitSet
+	level          Level
+	quiet          bool             // only warnings and above, see SetQuiet
+	warnErrors     bool             // log warnings as errors
+	suppressed     uint16           // bit set of levels silenced with Suppress
+	moduleLevels   map[string]Level // thresholds of named loggers
+	format         Format
+	formatter      Formatter // set with SetFormatter, replaces format
+	exitHooks      []func()
+	onceSeen       map[string]struct{} // level and format of the Once variants
+	stdOut         io.Writer           // writers as passed to Init or SetOutput
+	stdErr         io.Writer
+	panicOut       io.Writer
+	file           *rotatingFile
+	syslog         syslogConn
+	sharedFile     bool // file and syslog belong to the Log cloned from
+	sharedSyslog   bool
+	levelOut       map[Level]io.Writer // writers set with SetLevelOutput
+	levelWriters   map[Level]io.Writer // levelOut prepared by applyOutput
+	outputs        []output            // additional outputs set with AddOutput
+	tty            bool                // stdOut and stdErr are terminals
+	colorAuto      bool                // LgColor follows tty and NO_COLOR
+	coloredPrefix  bool                // level loggers use colored prefixes
+	colorScope     ColorScope          // colored parts of entries
+	painters       map[Level]paintFunc // color funcs of the theme
+	levelPrefixes  map[Level]string    // set with SetLevelPrefix
+	iconMode       IconMode
+	indent         int       // group depth, see Group
+	multiline      Multiline // see SetMultiline
+	wrap           bool      // see SetWrap
+	dumpLimit      int       // see SetDumpLimit
+	sectionRule    string    // rule characters of Section and Banner
+	bannerRule     string
+	ruleWidth      int // width of rules, 0 for the terminal width
+	verbosity      int
+	configFiles    []*sharedCloser // files of the outputs added by ApplyConfig
+	watcher        *configWatcher
+	signals        *signalHandler
+	signalDumpPath string
+	reportCaller   bool
+	callerLinks    string // URL format of caller hyperlinks, see SetCallerLinks
+	stackDepth     int    // maximum number of frames in stack traces, 0 for all
+	includeStack   bool   // stack traces go to the buffer and JSON entries
+	ctxExtractor   ContextExtractor
+	hooks          []*hook
+	redactors      []redactor
+	sinks          []*addedSink // closed by Close
+	hookPolicy     HookErrorPolicy
+	async          *asyncQueue
+	stats          counters
+	sampler        *sampler
+	dedup          *deduper
+	rateLimits     map[Level]*rateLimiter
+	timeFmt        timeFormat
+	lastEntry      atomic.Int64 // UnixNano of the latest entry written
+}
+
+// Log is a type for structured message logging.
+// Once initialized, all methods except Init are safe for concurrent use.
+// A zero Log initializes itself to write to os.Stderr on first use, which
+// must not happen concurrently. All methods of a nil Log do nothing.
 type Log struct {
-	stdVar       *log.Logger
-	infoVar      *log.Logger
-	debugVar     *log.Logger
-	warningVar   *log.Logger
-	errorVar     *log.Logger
-	panicVar     *log.Logger
-	bufferData   []string
-	modeRegister BitSet
+	*core
+	fields Fields
+	name   string // set with Named
+	prefix string // set with WithPrefix
 }
 
 // LogInit is a member function for Log
 // Inits all logging to given file handle except panic
 // Default mode is "silent" and "no color"
 func (l *Log) Init(stdOut, stdErr io.Writer) {
+	if l == nil {
+		return
+	}
+	if l.core == nil {
+		l.core = new(core)
+	}
+
 	stdFlags := log.Ldate | log.Ltime | log.Lmsgprefix
 
-	l.stdVar = log.New(stdOut, "       ", stdFlags)
-	l.infoVar = log.New(stdOut, "INFO:  ", stdFlags)
-	l.warningVar = log.New(stdOut, "WARN:  ", stdFlags)
-	l.debugVar = log.New(stdErr, "DEBUG: ", stdFlags)
-	l.errorVar = log.New(stdErr, "ERROR: ", stdFlags)
-	l.panicVar = log.New(os.Stderr, "PANIC: ", stdFlags)
+	l.stdVar = log.New(io.Discard, "       ", stdFlags)
+	l.infoVar = log.New(io.Discard, "INFO:  ", stdFlags)
+	l.warningVar = log.New(io.Discard, "WARN:  ", stdFlags)
+	l.debugVar = log.New(io.Discard, "DEBUG: ", stdFlags)
+	l.traceVar = log.New(io.Discard, "TRACE: ", stdFlags)
+	l.errorVar = log.New(io.Discard, "ERROR: ", stdFlags)
+	l.panicVar = log.New(io.Discard, "PANIC: ", stdFlags)
+	l.fatalVar = log.New(io.Discard, "FATAL: ", stdFlags)
 
+	l.setOutput(stdOut, stdErr, os.Stderr)
+
+	l.mu.Lock()
 	l.modeRegister = LgStandard
+	l.level = LevelInfo
+	l.verbosity = 0
+	l.colorAuto = false
+	l.coloredPrefix = false
+	l.levelPrefixes = nil
+	l.iconMode = IconsOff
+	l.timeFmt = timeFormat{start: time.Now()}
+	l.mu.Unlock()
+	l.ResetStats()
+
+	l.SetTheme(ThemeDark)
+}
+
+// initMu serializes the initialization of zero Logs
+var initMu sync.Mutex
+
+// ready initializes a zero Log like Init(os.Stderr, os.Stderr) and
+// reports whether l is usable, which is false only for a nil Log
+func (l *Log) ready() bool {
+	if l == nil {
+		return false
+	}
+	if l.core == nil {
+		l.initZero()
+	}
+	return true
+}
+
+func (l *Log) initZero() {
+	initMu.Lock()
+	defer initMu.Unlock()
+	if l.core == nil {
+		l.Init(os.Stderr, os.Stderr)
+	}
 }
 
+// SetFlags sets the log package flags of all level loggers. Lshortfile
+// and Llongfile have no effect, see SetReportCaller.
 func (l *Log) SetFlags(flags int) {
+	if !l.ready() {
+		return
+	}
 	l.stdVar.SetFlags(flags)
 	l.infoVar.SetFlags(flags)
 	l.warningVar.SetFlags(flags)
 	l.debugVar.SetFlags(flags)
+	l.traceVar.SetFlags(flags)
 	l.errorVar.SetFlags(flags)
 	l.panicVar.SetFlags(flags)
+	l.fatalVar.SetFlags(flags)
 }
 
+// SetColorPrefix colors the level prefixes in color mode, unless the
+// color scope is ColorMessageOnly
 func (l *Log) SetColorPrefix() {
-	if l.modeHas(LgColor) {
-		l.infoVar.SetPrefix(green("INFO:  "))
-		l.warningVar.SetPrefix(yellow("WARN:  "))
-		l.debugVar.SetPrefix(red("DEBUG: "))
-		l.errorVar.SetPrefix(red("ERROR: "))
-		l.panicVar.SetPrefix(red("PANIC: "))
+	if !l.ready() {
+		return
+	}
+	l.mu.RLock()
+	scope := l.colorScope
+	l.mu.RUnlock()
+
+	if l.modeHas(LgColor) && scope != ColorMessageOnly {
+		l.infoVar.SetPrefix(l.painter(LevelInfo)(l.levelPrefix(LevelInfo)))
+		l.warningVar.SetPrefix(l.painter(LevelWarn)(l.levelPrefix(LevelWarn)))
+		l.debugVar.SetPrefix(l.painter(LevelDebug)(l.levelPrefix(LevelDebug)))
+		l.traceVar.SetPrefix(l.painter(LevelTrace)(l.levelPrefix(LevelTrace)))
+		l.errorVar.SetPrefix(l.painter(LevelError)(l.levelPrefix(LevelError)))
+		l.panicVar.SetPrefix(l.painter(LevelPanic)(l.levelPrefix(LevelPanic)))
+		l.fatalVar.SetPrefix(l.painter(LevelFatal)(l.levelPrefix(LevelFatal)))
+		l.setColoredPrefix(true)
 	}
 }
 
+// setPlainPrefix restores the uncolored default prefixes
+func (l *Log) setPlainPrefix() {
+	l.stdVar.SetPrefix(l.stdPrefix())
+	l.infoVar.SetPrefix(l.levelPrefix(LevelInfo))
+	l.warningVar.SetPrefix(l.levelPrefix(LevelWarn))
+	l.debugVar.SetPrefix(l.levelPrefix(LevelDebug))
+	l.traceVar.SetPrefix(l.levelPrefix(LevelTrace))
+	l.errorVar.SetPrefix(l.levelPrefix(LevelError))
+	l.panicVar.SetPrefix(l.levelPrefix(LevelPanic))
+	l.fatalVar.SetPrefix(l.levelPrefix(LevelFatal))
+	l.setColoredPrefix(false)
+}
+
+func (l *Log) setColoredPrefix(b bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.coloredPrefix = b
+}
+
 func (l *Log) SetNoPrefix() {
+	if !l.ready() {
+		return
+	}
 	l.stdVar.SetPrefix("")
 	l.infoVar.SetPrefix("")
 	l.warningVar.SetPrefix("")
 	l.debugVar.SetPrefix("")
+	l.traceVar.SetPrefix("")
 	l.errorVar.SetPrefix("")
 	l.panicVar.SetPrefix("")
+	l.fatalVar.SetPrefix("")
+	l.setColoredPrefix(false)
 }
 
 func (l *Log) SetOutput(stdOut, stdErr io.Writer) {
+	if !l.ready() {
+		return
+	}
+	l.setOutput(stdOut, stdErr, stdErr)
+}
+
+func (l *Log) setOutput(stdOut, stdErr, panicOut io.Writer) {
+	l.mu.Lock()
+	l.stdOut, l.stdErr, l.panicOut = stdOut, stdErr, panicOut
+	l.tty = isTerminal(stdOut) && isTerminal(stdErr)
+	auto := l.colorAuto
+	l.mu.Unlock()
+
+	l.applyOutput()
+
+	if auto {
+		l.setColor(l.colorSupported())
+	}
+}
+
+// applyOutput points the level loggers at the configured writers,
+// adding the log file to each of them if one is set
+func (l *Log) applyOutput() {
+	l.mu.Lock()
+	withFile := func(w io.Writer) io.Writer {
+		w = plainUnlessTerminal(w)
+		if l.file != nil {
+			w = io.MultiWriter(w, ansiStripper{l.file})
+		}
+		return l.locked(w)
+	}
+
+	stdOut, stdErr, panicOut := withFile(l.stdOut), withFile(l.stdErr), withFile(l.panicOut)
+
+	l.levelWriters = make(map[Level]io.Writer, len(l.levelOut))
+	for lv, w := range l.levelOut {
+		l.levelWriters[lv] = withFile(w)
+	}
+	l.mu.Unlock()
+
 	l.stdVar.SetOutput(stdOut)
 	l.infoVar.SetOutput(stdOut)
 	l.warningVar.SetOutput(stdOut)
 	l.debugVar.SetOutput(stdErr)
+	l.traceVar.SetOutput(stdErr)
 	l.errorVar.SetOutput(stdErr)
-	l.panicVar.SetOutput(stdErr)
+	l.panicVar.SetOutput(panicOut)
+	l.fatalVar.SetOutput(panicOut)
+}
+
+// SetLevelOutput routes all entries of level lv to w instead of the
+// stdout/stderr writers. A nil writer restores the default routing.
+func (l *Log) SetLevelOutput(lv Level, w io.Writer) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	if w == nil {
+		delete(l.levelOut, lv)
+	} else {
+		if l.levelOut == nil {
+			l.levelOut = make(map[Level]io.Writer)
+		}
+		l.levelOut[lv] = w
+	}
+	l.mu.Unlock()
+
+	l.applyOutput()
+}
+
+// writerFor returns the writer set for lv with SetLevelOutput, if any
+func (l *Log) writerFor(lv Level) (io.Writer, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	w, ok := l.levelWriters[lv]
+	return w, ok
+}
+
+// SetFile additionally writes all levels to the file at path, which is
+// rotated according to cfg. The stdout/stderr split is kept as is.
+func (l *Log) SetFile(path string, cfg RotationConfig) error {
+	if !l.ready() {
+		return nil
+	}
+	f, err := openRotatingFile(path, cfg)
+	if err != nil {
+		return err
+	}
+	return l.setFile(f)
+}
+
+// setFile replaces the log file by f and closes the old one
+func (l *Log) setFile(f *rotatingFile) error {
+	l.mu.Lock()
+	old, shared := l.file, l.sharedFile
+	l.file, l.sharedFile = f, false
+	l.mu.Unlock()
+
+	l.applyOutput()
+
+	if old != nil && !shared {
+		return old.Close()
+	}
+	return nil
+}
+
+// lockedWriter serializes writes of all level loggers sharing a core.
+// A live line is cleared before and redrawn after each write.
+type lockedWriter struct {
+	mu   *sync.Mutex
+	w    io.Writer
+	live *liveLine
+}
+
+func (lw lockedWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	if lw.live.shown() {
+		lw.live.clear()
+		defer lw.live.draw()
+	}
+	return lw.w.Write(p)
+}
+
+func (l *Log) locked(w io.Writer) io.Writer {
+	if lw, ok := w.(lockedWriter); ok && lw.mu == &l.wmu {
+		return w
+	}
+	return lockedWriter{mu: &l.wmu, w: w, live: &l.live}
 }
 
 // internal mode handling functions
 func (l *Log) modeSet(flag BitSet) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.modeRegister = l.modeRegister | flag
 }
 
 func (l *Log) modeClear(flag BitSet) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.modeRegister = l.modeRegister &^ flag
 }
 
 func (l *Log) modeToggle(flag BitSet) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.modeRegister = l.modeRegister ^ flag
 }
 
 func (l *Log) modeHas(flag BitSet) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 	return l.modeRegister&flag != 0
 }
 
 // Exposed mode handling functions
 func (l *Log) SetInteractive() {
+	if !l.ready() {
+		return
+	}
 	l.SetFlags(log.Lmsgprefix)
 	l.SetColorPrefix()
 }
 
 func (l *Log) EnableBuffer() {
+	if !l.ready() {
+		return
+	}
 	l.modeSet(LgBuffer)
 }
 
 func (l *Log) DisableBuffer() {
+	if !l.ready() {
+		return
+	}
 	l.modeClear(LgBuffer)
 }
 
 func (l *Log) SetMode(f BitSet) {
+	if !l.ready() {
+		return
+	}
 	l.modeSet(f)
 }
 
 func (l *Log) ClearMode(f BitSet) {
+	if !l.ready() {
+		return
+	}
 	l.modeClear(f)
 }
 
 func (l *Log) ToggleMode(f BitSet) {
+	if !l.ready() {
+		return
+	}
 	l.modeToggle(f)
 }
 
 func (l *Log) GetMode() BitSet {
+	if !l.ready() {
+		return 0
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
 	return l.modeRegister
 }
 
 func (l *Log) HasMode(f BitSet) bool {
+	if !l.ready() {
+		return false
+	}
 	return l.modeHas(f)
 }
 
 func (l *Log) SetModeBool(f BitSet, b bool) {
+	if !l.ready() {
+		return
+	}
 	if b {
 		l.modeSet(f)
 	} else {
@@ -150,96 +476,290 @@ func (l *Log) SetModeBool(f BitSet, b bool) {
 	}
 }
 
-// Buffer Handling
-func (l *Log) AddBuffer(format string, v ...interface{}) {
-	if l.modeHas(LgBuffer) {
-		l.bufferData = append(l.bufferData, fmt.Sprintf(format, v...))
+// Intrinsic functions
+// levelVar returns the level logger for entries of lv with the given prefix
+func (l *Log) levelVar(lv Level, prefix string) *log.Logger {
+	switch lv {
+	case LevelTrace:
+		return l.traceVar
+	case LevelDebug:
+		return l.debugVar
+	case LevelVerbose, LevelInfo:
+		if prefix == "" {
+			return l.stdVar
+		}
+		return l.infoVar
+	case LevelWarn:
+		return l.warningVar
+	case LevelError:
+		return l.errorVar
+	case LevelPanic:
+		return l.panicVar
+	}
+	return l.fatalVar
+}
+
+// emit writes a single entry with the flags and prefix of lg, as text
+// or with the Formatter of the format, to the additional outputs and to syslog, and calls
+// the hooks. Entries routed with SetLevelOutput bypass lg's writer.
+func (l *Log) emit(lg *log.Logger, paint paintFunc, e *Entry) {
+	w, routed := l.writerFor(e.Level)
+	if !routed {
+		w = lg.Writer()
+	}
+
+	l.mu.RLock()
+	f, ml := l.lineFormatter(), l.multiline
+	wrap := 0
+	if l.wrap {
+		wrap = l.terminalWidth(lg, e.Level)
+	}
+	te := e
+	if f == nil {
+		te = l.linkCaller(lg, e)
+	}
+	mainPaint := l.messagePaint(paint, true)
+	l.mu.RUnlock()
+
+	if f != nil {
+		writeFormatted(w, f, l.structured(e))
+	} else {
+		b := getBuf()
+		*b, _ = lineFormat{lg.Flags(), lg.Prefix(), l.timeFormat(e), mainPaint, ml, wrap}.appendEntry(*b, te)
+		w.Write(*b)
+		putBuf(b)
 	}
+	l.toOutputs(e, paint)
+	l.toSyslog(e)
+	l.fireHooks(e)
 }
 
-func (l *Log) GetBuffer() string {
-	return strings.Join(l.bufferData, "\n")
+func (l *Log) output(lg *log.Logger, lv Level, prefix string, paint paintFunc, format string, v ...interface{}) {
+	l.outputEntry(lg, paint, l.newEntry(lv, prefix, fmt.Sprintf(format, v...)))
+}
+
+// outputEntry passes e through sampling, rate limiting and
+// deduplication and delivers it
+func (l *Log) outputEntry(lg *log.Logger, paint paintFunc, e *Entry) {
+	l.count(e)
+	l.collect(e)
+	if l.sampled(e) && l.limited(lg, paint, e) && l.deduped(lg, paint, e) {
+		l.deliver(lg, paint, e)
+	}
+}
+
+// deliver writes e, or queues it if async mode is enabled
+func (l *Log) deliver(lg *log.Logger, paint paintFunc, e *Entry) {
+	if q := l.asyncQueue(); q != nil {
+		if e.Level < LevelPanic && q.push(asyncItem{l: l, lg: lg, paint: paint, e: e}) {
+			return
+		}
+		q.sync()
+	}
+	l.writeOut(lg, paint, e)
+}
+
+// writeOut emits e and adds it to the buffer
+func (l *Log) writeOut(lg *log.Logger, paint paintFunc, e *Entry) {
+	defer l.timeWrite(time.Now())
+
+	l.sincePrev(e)
+	l.emit(lg, paint, e)
+
+	if l.HasMode(LgBuffer) {
+		se := l.structured(e)
+		l.buffer(e.Level, e.Prefix, se.text()+se.stackSuffix())
+	}
 }
 
-// Intrinsic functions
 func (l *Log) log(format string, v ...interface{}) {
-	l.stdVar.Printf(format, v...)
-	l.AddBuffer(format, v...)
+	l.output(l.stdVar, LevelInfo, "", plain, format, v...)
 }
 
 func (l *Log) stdbold(format string, v ...interface{}) {
-	l.infoVar.Printf(bold(format), v...)
-	l.AddBuffer(format, v...)
+	l.output(l.infoVar, LevelInfo, "INFO", bold, format, v...)
 }
 
 func (l *Log) info(format string, v ...interface{}) {
-	l.infoVar.Printf(green(format), v...)
-	l.AddBuffer(format, v...)
+	l.output(l.infoVar, LevelInfo, "INFO", l.painter(LevelInfo), format, v...)
 }
 
 func (l *Log) infobold(format string, v ...interface{}) {
-	l.infoVar.Printf(bold(green(format)), v...)
-	l.AddBuffer(format, v...)
+	l.output(l.infoVar, LevelInfo, "INFO", l.boldPainter(LevelInfo), format, v...)
+}
+
+func (l *Log) verbose(format string, v ...interface{}) {
+	l.output(l.stdVar, LevelVerbose, "", plain, format, v...)
+}
+
+func (l *Log) verboseinfo(format string, v ...interface{}) {
+	l.output(l.infoVar, LevelVerbose, "INFO", l.painter(LevelVerbose), format, v...)
 }
 
 func (l *Log) warn(format string, v ...interface{}) {
-	l.warningVar.Printf(yellow(format), v...)
-	l.AddBuffer(format, v...)
+	if l.warningsAsErrors() {
+		l.Error(format, v...)
+		return
+	}
+	l.output(l.warningVar, LevelWarn, "WARN", l.painter(LevelWarn), format, v...)
+}
+
+func (l *Log) trace(format string, v ...interface{}) {
+	l.output(l.traceVar, LevelTrace, "TRACE", l.painter(LevelTrace), format, v...)
 }
 
 func (l *Log) debug(format string, v ...interface{}) {
-	l.debugVar.Printf(red(format), v...)
-	l.AddBuffer(format, v...)
+	l.output(l.debugVar, LevelDebug, "DEBUG", l.painter(LevelDebug), format, v...)
+}
+
+func (l *Log) panic(withStack bool, msg string) {
+	e := l.newEntry(LevelPanic, "PANIC", msg)
+	if withStack {
+		e.Stack = l.stack()
+	}
+	l.count(e)
+	l.collect(e)
+	l.syncAsync()
+	l.sincePrev(e)
+	l.emit(l.panicVar, l.painter(LevelPanic), e)
+	// the panic may end the program before a batch is written
+	l.flushSinks()
 }
 
 func (l *Log) error(format string, v ...interface{}) {
-	l.errorVar.Printf(red(format), v...)
-	l.AddBuffer(format, v...)
+	l.dumpRecorder()
+	l.output(l.errorVar, LevelError, "ERROR", l.painter(LevelError), format, v...)
+}
+
+// logLevel logs msg with the default prefix of lv, without the
+// panic and exit semantics of Panicf and Fatal
+func (l *Log) logLevel(lv Level, msg string) {
+	switch lv {
+	case LevelTrace:
+		l.Trace("%s", msg)
+	case LevelDebug:
+		l.Debug("%s", msg)
+	case LevelVerbose:
+		l.VerboseInfo("%s", msg)
+	case LevelInfo:
+		l.StandardInfo("%s", msg)
+	case LevelWarn:
+		l.Warn("%s", msg)
+	case LevelError:
+		l.Error("%s", msg)
+	case LevelPanic:
+		l.Panic("%s", msg)
+	default:
+		l.dumpRecorder()
+		l.output(l.fatalVar, LevelFatal, "FATAL", l.painter(LevelFatal), "%s", msg)
+	}
 }
 
 // User functions
 func (l *Log) Panic(format string, v ...interface{}) {
-	l.panicVar.Printf(red(format), v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelPanic) {
+		l.dumpRecorder()
+		l.panic(true, fmt.Sprintf(format, v...))
+	}
 }
 
 func (l *Log) Standard(format string, v ...interface{}) {
-	l.log(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelInfo) {
+		l.log(format, v...)
+	}
 }
 
 func (l *Log) Bold(format string, v ...interface{}) {
-	l.stdbold(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelInfo) {
+		l.stdbold(format, v...)
+	}
 }
 
 func (l *Log) StandardInfo(format string, v ...interface{}) {
-	l.info(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelInfo) {
+		l.info(format, v...)
+	}
 }
 
 func (l *Log) BoldInfo(format string, v ...interface{}) {
-	l.infobold(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelInfo) {
+		l.infobold(format, v...)
+	}
 }
 
 func (l *Log) Verbose(format string, v ...interface{}) {
-	if l.modeHas(LgVerbose) {
-		l.log(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelVerbose) {
+		l.verbose(format, v...)
+	} else {
+		l.record(LevelVerbose, "", format, v...)
 	}
 }
 
 func (l *Log) VerboseInfo(format string, v ...interface{}) {
-	if l.modeHas(LgVerbose) {
-		l.info(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelVerbose) {
+		l.verboseinfo(format, v...)
+	} else {
+		l.record(LevelVerbose, "INFO", format, v...)
+	}
+}
+
+func (l *Log) Trace(format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelTrace) {
+		l.trace(format, v...)
+	} else {
+		l.record(LevelTrace, "TRACE", format, v...)
 	}
 }
 
 func (l *Log) Debug(format string, v ...interface{}) {
-	if l.modeHas(LgDebug) {
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelDebug) {
 		l.debug(format, v...)
+	} else {
+		l.record(LevelDebug, "DEBUG", format, v...)
 	}
 }
 
 func (l *Log) Warn(format string, v ...interface{}) {
-	l.warn(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelWarn) {
+		l.warn(format, v...)
+	}
 }
 
 func (l *Log) Error(format string, v ...interface{}) {
-	l.error(format, v...)
+	if !l.ready() {
+		return
+	}
+	if l.Enabled(LevelError) {
+		l.error(format, v...)
+	}
 }
@@ -0,0 +1,210 @@
+package MyLog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+// syncBuffer is a bytes.Buffer safe for concurrent use
+type syncBuffer struct {
+	mu sync.Mutex
+	b  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.b.String()
+}
+
+// lines returns the lines written so far, without the final newline
+func (b *syncBuffer) lines() []string {
+	s := strings.TrimSuffix(b.String(), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// newTestLog returns a Log writing to the returned buffers, panics
+// included, without timestamps
+func newTestLog() (l *Log, stdout, stderr *syncBuffer) {
+	stdout, stderr = new(syncBuffer), new(syncBuffer)
+	l = new(Log)
+	l.Init(stdout, stderr)
+	l.SetOutput(stdout, stderr)
+	l.SetFlags(0)
+	return l, stdout, stderr
+}
+
+// forceColor makes the color package emit escapes although the tests
+// do not run on a terminal
+func forceColor(t *testing.T) {
+	old := color.NoColor
+	color.NoColor = false
+	t.Cleanup(func() { color.NoColor = old })
+}
+
+func TestLevelRouting(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetLevel(LevelTrace)
+
+	l.Standard("standard")
+	l.StandardInfo("info")
+	l.Warn("warn")
+	l.Debug("debug")
+	l.Trace("trace")
+	l.Error("error")
+
+	wantOut := "       standard\nINFO:  info\nWARN:  warn\n"
+	wantErr := "DEBUG: debug\nTRACE: trace\nERROR: error\n"
+	if got := stdout.String(); got != wantOut {
+		t.Errorf("stdout = %q, want %q", got, wantOut)
+	}
+	if got := stderr.String(); got != wantErr {
+		t.Errorf("stderr = %q, want %q", got, wantErr)
+	}
+}
+
+func TestModeRegister(t *testing.T) {
+	l, _, _ := newTestLog()
+
+	l.SetMode(LgVerbose | LgBuffer)
+	if got := l.GetMode(); got != LgVerbose|LgBuffer {
+		t.Fatalf("GetMode() = %b, want %b", got, LgVerbose|LgBuffer)
+	}
+	l.ClearMode(LgVerbose)
+	l.ToggleMode(LgDebug)
+	if !l.HasMode(LgDebug) || l.HasMode(LgVerbose) || !l.HasMode(LgBuffer) {
+		t.Errorf("mode = %b, want debug and buffer", l.GetMode())
+	}
+	l.SetModeBool(LgBuffer, false)
+	if l.HasMode(LgBuffer) {
+		t.Error("SetModeBool(LgBuffer, false) left the buffer enabled")
+	}
+}
+
+func TestDebugMode(t *testing.T) {
+	l, _, stderr := newTestLog()
+
+	l.Debug("hidden")
+	l.SetMode(LgDebug)
+	l.Debug("shown")
+
+	if got, want := stderr.String(), "DEBUG: shown\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+// TestConcurrentUse is meant for go test -race: logging, changing the
+// mode and using the buffer from many goroutines must neither race nor
+// interleave lines
+func TestConcurrentUse(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.EnableBuffer()
+
+	const workers, n = 8, 200
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				l.StandardInfo("worker %d line %d", w, i)
+				l.Warn("worker %d line %d", w, i)
+				l.Debug("worker %d line %d", w, i)
+				l.AddBuffer("worker %d buffered %d", w, i)
+				_ = l.GetBuffer()
+				_ = l.GetBufferEntries()
+			}
+		}(w)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; i++ {
+			l.SetMode(LgDebug)
+			l.ToggleMode(LgVerbose)
+			_ = l.HasMode(LgDebug)
+			l.ClearMode(LgDebug)
+			l.SetLevel(LevelInfo)
+			_ = l.GetLevel()
+		}
+	}()
+	wg.Wait()
+
+	out := stdout.lines()
+	if len(out) != 2*workers*n {
+		t.Errorf("got %d stdout lines, want %d", len(out), 2*workers*n)
+	}
+	for _, ln := range append(out, stderr.lines()...) {
+		var w, i int
+		var prefix string
+		if _, err := fmt.Sscanf(ln, "%s worker %d line %d", &prefix, &w, &i); err != nil {
+			t.Fatalf("garbled line %q: %v", ln, err)
+		}
+	}
+
+	if got := len(l.GetBufferEntries()); got < workers*n {
+		t.Errorf("buffer holds %d entries, want at least %d", got, workers*n)
+	}
+}
+
+func TestConcurrentOutputChange(t *testing.T) {
+	l, _, _ := newTestLog()
+	a, b := new(syncBuffer), new(syncBuffer)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 500; i++ {
+			l.StandardInfo("line %d", i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			if i%2 == 0 {
+				l.SetOutput(a, a)
+			} else {
+				l.SetOutput(b, b)
+			}
+		}
+	}()
+	wg.Wait()
+
+	if n := len(a.lines()) + len(b.lines()); n > 500 {
+		t.Errorf("got %d lines, want at most 500", n)
+	}
+}
+
+func TestSetLevelOutput(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	errs := new(syncBuffer)
+	l.SetLevelOutput(LevelError, errs)
+
+	l.Error("routed")
+	l.Warn("default")
+	if errs.String() != "ERROR: routed\n" || stderr.String() != "" || stdout.String() != "WARN:  default\n" {
+		t.Errorf("errors = %q, stderr = %q, stdout = %q", errs.String(), stderr.String(), stdout.String())
+	}
+
+	l.SetLevelOutput(LevelError, nil)
+	l.Error("restored")
+	if stderr.String() != "ERROR: restored\n" {
+		t.Errorf("stderr = %q after removing the level output", stderr.String())
+	}
+}
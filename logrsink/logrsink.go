@@ -0,0 +1,95 @@
+// Package logrsink exposes a MyLog.Log as logr.LogSink, so it can be
+// used with libraries accepting a logr.Logger.
+package logrsink
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// sink implements logr.LogSink on top of a MyLog.Log
+type sink struct {
+	l *MyLog.Log
+}
+
+// New returns a logr.LogSink writing through l. V-levels are mapped
+// onto MyLog levels: V(0) is Info, V(1) Verbose, V(2) Debug and
+// everything above Trace.
+func New(l *MyLog.Log) logr.LogSink {
+	return &sink{l: l}
+}
+
+// NewLogger returns a logr.Logger writing through l, see New
+func NewLogger(l *MyLog.Log) logr.Logger {
+	return logr.New(New(l))
+}
+
+// level maps a logr V-level onto a MyLog level
+func level(v int) MyLog.Level {
+	switch {
+	case v <= 0:
+		return MyLog.LevelInfo
+	case v == 1:
+		return MyLog.LevelVerbose
+	case v == 2:
+		return MyLog.LevelDebug
+	}
+	return MyLog.LevelTrace
+}
+
+func (s *sink) Init(logr.RuntimeInfo) {}
+
+func (s *sink) Enabled(v int) bool {
+	return s.l.Enabled(level(v))
+}
+
+func (s *sink) Info(v int, msg string, keysAndValues ...interface{}) {
+	l := s.l.WithFields(fields(keysAndValues))
+
+	switch level(v) {
+	case MyLog.LevelInfo:
+		l.StandardInfo("%s", msg)
+	case MyLog.LevelVerbose:
+		l.VerboseInfo("%s", msg)
+	case MyLog.LevelDebug:
+		l.Debug("%s", msg)
+	default:
+		l.Trace("%s", msg)
+	}
+}
+
+func (s *sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	l := s.l.WithFields(fields(keysAndValues))
+	if err != nil {
+		l = l.WithField("error", err)
+	}
+	l.Error("%s", msg)
+}
+
+func (s *sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &sink{l: s.l.WithFields(fields(keysAndValues))}
+}
+
+func (s *sink) WithName(name string) logr.LogSink {
+	return &sink{l: s.l.Named(name)}
+}
+
+// fields converts logr key/value pairs. A missing value is logged as nil.
+func fields(keysAndValues []interface{}) MyLog.Fields {
+	f := make(MyLog.Fields, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+
+		var value interface{}
+		if i+1 < len(keysAndValues) {
+			value = keysAndValues[i+1]
+		}
+		f[key] = value
+	}
+	return f
+}
@@ -0,0 +1,55 @@
+package logrsink_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+	"github.com/hleinders/MyLog/logrsink"
+)
+
+func newLog() (*MyLog.Log, *bytes.Buffer) {
+	var b bytes.Buffer
+	return MyLog.New(MyLog.WithOutput(&b, &b), MyLog.WithFlags(0)), &b
+}
+
+func TestLogger(t *testing.T) {
+	l, b := newLog()
+	lg := logrsink.NewLogger(l).WithName("db").WithValues("conn", 3)
+
+	lg.Info("opened", "user", "alice")
+	lg.V(2).Info("hidden")
+	lg.Error(errors.New("timeout"), "query failed", "odd")
+
+	want := "INFO:  [db] opened conn=3 user=alice\n" +
+		"ERROR: [db] query failed conn=3 error=timeout odd=<nil>\n"
+	if b.String() != want {
+		t.Errorf("output = %q, want %q", b.String(), want)
+	}
+}
+
+func TestLevels(t *testing.T) {
+	l, b := newLog()
+	l.SetLevel(MyLog.LevelTrace)
+	lg := logrsink.NewLogger(l)
+
+	for v := 0; v <= 3; v++ {
+		lg.V(v).Info("v")
+	}
+	want := "INFO:  v\nINFO:  v\nDEBUG: v\nTRACE: v\n"
+	if got := b.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestCaller is outside of package logrsink, whose frames are skipped
+func TestCaller(t *testing.T) {
+	l, b := newLog()
+	l.SetReportCaller(true)
+	logrsink.NewLogger(l).Info("here")
+	if !strings.Contains(b.String(), "logrsink_test.go:") {
+		t.Errorf("output = %q, want the test as caller", b.String())
+	}
+}
@@ -0,0 +1,171 @@
+// Package loki pushes MyLog entries to Grafana Loki using its HTTP
+// push API. Entries are batched into one stream per level.
+package loki
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Options configures a Pusher
+type Options struct {
+	URL        string                  // default http://localhost:3100/loki/api/v1/push
+	App        string                  // "app" label, default the executable name
+	Env        string                  // "env" label, omitted if empty
+	Labels     map[string]string       // additional static labels
+	Headers    map[string]string       // e.g. X-Scope-OrgID or authentication
+	Client     *http.Client            // default http.DefaultClient
+	Batch      MyLog.BatchOptions      // used by Attach, MaxSize defaults to 1000
+	Resilience MyLog.ResilienceOptions // retries and circuit breaker used by Attach
+}
+
+// Pusher is a MyLog.Sink pushing each batch of entries to Loki in one
+// request. Rejected requests are reported as MyLog.Permanent, so only
+// network errors, 429 and 5xx responses are retried.
+type Pusher struct {
+	opts   Options
+	labels map[string]string
+}
+
+// New returns a Pusher. It sends synchronously, see Attach for batching
+// and retries.
+func New(opts Options) *Pusher {
+	if opts.URL == "" {
+		opts.URL = "http://localhost:3100/loki/api/v1/push"
+	}
+	if opts.App == "" {
+		opts.App = filepath.Base(os.Args[0])
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Batch.MaxSize <= 0 {
+		opts.Batch.MaxSize = 1000
+	}
+
+	labels := map[string]string{"app": opts.App}
+	if opts.Env != "" {
+		labels["env"] = opts.Env
+	}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+	return &Pusher{opts: opts, labels: labels}
+}
+
+// Attach adds a Pusher for all entries of l as a sink, batched and
+// with retries. l.Flush and l.Close push the queued entries.
+func Attach(l *MyLog.Log, opts Options) *MyLog.BatchSink {
+	p := New(opts)
+	b := MyLog.Batch(MyLog.NewResilientSink(p, p.opts.Resilience), p.opts.Batch)
+	l.AddSink(b)
+	return b
+}
+
+// WriteEntries pushes entries in one request
+func (p *Pusher) WriteEntries(entries []MyLog.Entry) error {
+	body, err := json.Marshal(p.request(entries))
+	if err != nil {
+		return MyLog.Permanent(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return MyLog.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range p.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := p.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("loki: %s", resp.Status)
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			err = MyLog.Permanent(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Close does nothing, there is no connection to close
+func (p *Pusher) Close() error {
+	return nil
+}
+
+// push API request body
+type (
+	pushRequest struct {
+		Streams []stream `json:"streams"`
+	}
+	stream struct {
+		Stream map[string]string `json:"stream"`
+		Values [][2]string       `json:"values"`
+	}
+	line struct {
+		Message string       `json:"msg"`
+		Logger  string       `json:"logger,omitempty"`
+		Caller  string       `json:"caller,omitempty"`
+		Stack   string       `json:"stack,omitempty"`
+		Fields  MyLog.Fields `json:"fields,omitempty"`
+	}
+)
+
+func (p *Pusher) request(entries []MyLog.Entry) pushRequest {
+	streams := map[MyLog.Level]*stream{}
+	var order []MyLog.Level
+	for _, e := range entries {
+		s := streams[e.Level]
+		if s == nil {
+			labels := make(map[string]string, len(p.labels)+1)
+			for k, v := range p.labels {
+				labels[k] = v
+			}
+			labels["level"] = e.Level.String()
+			s = &stream{Stream: labels}
+			streams[e.Level] = s
+			order = append(order, e.Level)
+		}
+		s.Values = append(s.Values, [2]string{strconv.FormatInt(e.Time.UnixNano(), 10), encode(e)})
+	}
+
+	req := pushRequest{Streams: make([]stream, len(order))}
+	for i, lv := range order {
+		req.Streams[i] = *streams[lv]
+	}
+	return req
+}
+
+// encode renders e as a JSON log line
+func encode(e MyLog.Entry) string {
+	ln := line{Message: e.Message, Logger: e.Name, Caller: e.Caller, Stack: e.Stack}
+	if len(e.Fields) > 0 {
+		ln.Fields = make(MyLog.Fields, len(e.Fields))
+		for k, v := range e.Fields {
+			if err, ok := v.(error); ok {
+				v = err.Error()
+			}
+			ln.Fields[k] = v
+		}
+	}
+
+	b, err := json.Marshal(ln)
+	if err != nil {
+		ln.Fields = nil
+		b, _ = json.Marshal(ln)
+	}
+	return string(b)
+}
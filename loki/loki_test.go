@@ -0,0 +1,92 @@
+package loki
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// server records the push requests sent to it
+type server struct {
+	mu       sync.Mutex
+	requests []pushRequest
+	tenant   string
+	status   int
+}
+
+func newServer(t *testing.T, status int) (*server, string) {
+	s := &server{status: status}
+	hs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req pushRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		s.mu.Lock()
+		s.requests = append(s.requests, req)
+		s.tenant = r.Header.Get("X-Scope-OrgID")
+		s.mu.Unlock()
+		w.WriteHeader(s.status)
+	}))
+	t.Cleanup(hs.Close)
+	return s, hs.URL
+}
+
+func TestWriteEntries(t *testing.T) {
+	s, url := newServer(t, http.StatusNoContent)
+	p := New(Options{URL: url, App: "api", Env: "prod", Labels: map[string]string{"region": "eu"}, Headers: map[string]string{"X-Scope-OrgID": "team"}})
+
+	err := p.WriteEntries([]MyLog.Entry{
+		{Time: time.Unix(0, 10), Level: MyLog.LevelInfo, Message: "one", Fields: MyLog.Fields{"err": errors.New("timeout")}},
+		{Time: time.Unix(0, 20), Level: MyLog.LevelError, Message: "two", Name: "db"},
+		{Time: time.Unix(0, 30), Level: MyLog.LevelInfo, Message: "three"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(s.requests) != 1 || s.tenant != "team" {
+		t.Fatalf("%d requests, tenant %q", len(s.requests), s.tenant)
+	}
+	streams := s.requests[0].Streams
+	if len(streams) != 2 {
+		t.Fatalf("%d streams, want one per level", len(streams))
+	}
+	info := streams[0]
+	if l := info.Stream; l["app"] != "api" || l["env"] != "prod" || l["region"] != "eu" || l["level"] != "info" {
+		t.Errorf("labels = %v", l)
+	}
+	want := [][2]string{{"10", `{"msg":"one","fields":{"err":"timeout"}}`}, {"30", `{"msg":"three"}`}}
+	if len(info.Values) != 2 || info.Values[0] != want[0] || info.Values[1] != want[1] {
+		t.Errorf("values = %q, want %q", info.Values, want)
+	}
+	if streams[1].Values[0][1] != `{"msg":"two","logger":"db"}` {
+		t.Errorf("error line = %s", streams[1].Values[0][1])
+	}
+}
+
+func TestAttach(t *testing.T) {
+	s, url := newServer(t, http.StatusNoContent)
+	l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+	Attach(l, Options{URL: url})
+
+	l.StandardInfo("a")
+	l.Warn("b")
+	l.Close()
+	if len(s.requests) != 1 || len(s.requests[0].Streams) != 2 {
+		t.Errorf("requests = %+v", s.requests)
+	}
+}
+
+func TestRejected(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusTooManyRequests, http.StatusInternalServerError} {
+		_, url := newServer(t, status)
+		if err := New(Options{URL: url}).WriteEntries([]MyLog.Entry{{}}); err == nil {
+			t.Errorf("status %d accepted", status)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package MyLog
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+// Masker rewrites personal data in a string, see OutputOptions.Maskers
+type Masker func(s string) string
+
+// MaskMode selects how much of a match a Masker hides
+type MaskMode uint8
+
+const (
+	MaskFull    MaskMode = iota // replace the match by a placeholder like [EMAIL]
+	MaskPartial                 // keep a part useful for debugging, like the last digits
+)
+
+var (
+	emailRe = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// international numbers, area codes in parentheses and national
+	// numbers with a leading 0 and a separator after the area code, so
+	// that dates, times and plain IDs are left alone
+	phoneRe = regexp.MustCompile(`\+\d{1,3}[ .\-]?(?:\(\d{1,4}\)[ .\-]?)?\d{1,4}(?:[ .\-]?\d{2,4}){1,4}\b|\(\d{2,5}\)[ .\-]?\d{3,4}[ .\-]?\d{3,4}\b|\b0\d{2,4}[ /\-]\d{4,8}\b`)
+	cardRe  = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+	ipv4Re  = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	ipv6Re  = regexp.MustCompile(`(?i)[0-9a-f]{0,4}(?::[0-9a-f]{0,4}){2,7}`)
+)
+
+// MaskEmails masks email addresses; partially as j***@example.com
+func MaskEmails(mode MaskMode) Masker {
+	return func(s string) string {
+		return emailRe.ReplaceAllStringFunc(s, func(m string) string {
+			if mode == MaskFull {
+				return "[EMAIL]"
+			}
+			at := strings.LastIndexByte(m, '@')
+			return m[:1] + "***" + m[at:]
+		})
+	}
+}
+
+// MaskPhones masks phone numbers of 8 to 15 digits that start with a
+// country code like +49, an area code in parentheses or a 0 followed by
+// the area code and a separator; partially keeping the last two digits.
+// Apply MaskCardNumbers first, since card numbers look like phone
+// numbers.
+func MaskPhones(mode MaskMode) Masker {
+	return func(s string) string {
+		return phoneRe.ReplaceAllStringFunc(s, func(m string) string {
+			if n := countDigits(m); n < 8 || n > 15 || net.ParseIP(m) != nil {
+				return m
+			}
+			if mode == MaskFull {
+				return "[PHONE]"
+			}
+			return maskDigits(m, 2)
+		})
+	}
+}
+
+// MaskCardNumbers masks payment card numbers passing the Luhn check;
+// partially keeping the last four digits
+func MaskCardNumbers(mode MaskMode) Masker {
+	return func(s string) string {
+		return cardRe.ReplaceAllStringFunc(s, func(m string) string {
+			if !luhn(m) {
+				return m
+			}
+			if mode == MaskFull {
+				return "[CARD]"
+			}
+			return maskDigits(m, 4)
+		})
+	}
+}
+
+// MaskIPs masks IPv4 and IPv6 addresses; partially keeping the network,
+// the first two octets or 48 bits, like 192.168.*.*
+func MaskIPs(mode MaskMode) Masker {
+	mask := func(m string) string {
+		ip := net.ParseIP(m)
+		switch {
+		case ip == nil:
+			return m
+		case mode == MaskFull:
+			return "[IP]"
+		case ip.To4() != nil:
+			ip4 := ip.To4()
+			return fmt.Sprintf("%d.%d.*.*", ip4[0], ip4[1])
+		}
+		return ip.Mask(net.CIDRMask(48, 128)).String() + "*"
+	}
+	return func(s string) string {
+		s = ipv4Re.ReplaceAllStringFunc(s, mask)
+		return ipv6Re.ReplaceAllStringFunc(s, mask)
+	}
+}
+
+// Mask returns e with maskers applied to the message and to string and
+// error field values, e.g. for use in a Hook. The fields of e are not
+// modified.
+func Mask(e Entry, maskers ...Masker) Entry {
+	if len(maskers) == 0 {
+		return e
+	}
+
+	e.rewrite(func(s string) string { return applyMaskers(maskers, s) })
+	return e
+}
+
+func applyMaskers(maskers []Masker, s string) string {
+	for _, m := range maskers {
+		s = m(s)
+	}
+	return s
+}
+
+func countDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] >= '0' && s[i] <= '9' {
+			n++
+		}
+	}
+	return n
+}
+
+// maskDigits replaces all but the last keep digits of s by '*',
+// keeping separators
+func maskDigits(s string, keep int) string {
+	b := []byte(s)
+	n := countDigits(s)
+	for i := range b {
+		if b[i] >= '0' && b[i] <= '9' {
+			if n > keep {
+				b[i] = '*'
+			}
+			n--
+		}
+	}
+	return string(b)
+}
+
+// luhn reports whether the digits of s pass the Luhn checksum
+func luhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
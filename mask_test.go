@@ -0,0 +1,95 @@
+package MyLog
+
+import "testing"
+
+func TestMaskPhones(t *testing.T) {
+	tests := []struct {
+		in, full, partial string
+	}{
+		{"call +49 151 12345678", "call [PHONE]", "call +** *** ******78"},
+		{"call +1-555-123-4567 now", "call [PHONE] now", "call +*-***-***-**67 now"},
+		{"call (030) 1234 5678", "call [PHONE]", "call (***) **** **78"},
+		{"call 0151/1234567", "call [PHONE]", "call ****/*****67"},
+		{"on 2024-01-15", "on 2024-01-15", "on 2024-01-15"},
+		{"at 2024-01-15T10:30:00", "at 2024-01-15T10:30:00", "at 2024-01-15T10:30:00"},
+		{"order 12345678", "order 12345678", "order 12345678"},
+		{"id 123456789012", "id 123456789012", "id 123456789012"},
+		{"took 1.234.567 ns", "took 1.234.567 ns", "took 1.234.567 ns"},
+		{"version 1.2.3.4", "version 1.2.3.4", "version 1.2.3.4"},
+	}
+	for _, tt := range tests {
+		if got := MaskPhones(MaskFull)(tt.in); got != tt.full {
+			t.Errorf("MaskPhones(MaskFull)(%q) = %q, want %q", tt.in, got, tt.full)
+		}
+		if got := MaskPhones(MaskPartial)(tt.in); got != tt.partial {
+			t.Errorf("MaskPhones(MaskPartial)(%q) = %q, want %q", tt.in, got, tt.partial)
+		}
+	}
+}
+
+func TestMaskEmails(t *testing.T) {
+	in := "mail jane.doe@example.com now"
+	if got := MaskEmails(MaskFull)(in); got != "mail [EMAIL] now" {
+		t.Errorf("full = %q", got)
+	}
+	if got := MaskEmails(MaskPartial)(in); got != "mail j***@example.com now" {
+		t.Errorf("partial = %q", got)
+	}
+}
+
+func TestMaskCardNumbers(t *testing.T) {
+	tests := []struct{ in, full, partial string }{
+		{"card 4111 1111 1111 1111", "card [CARD]", "card **** **** **** 1111"},
+		{"card 4111-1111-1111-1112", "card 4111-1111-1111-1112", "card 4111-1111-1111-1112"},
+	}
+	for _, tt := range tests {
+		if got := MaskCardNumbers(MaskFull)(tt.in); got != tt.full {
+			t.Errorf("MaskCardNumbers(MaskFull)(%q) = %q, want %q", tt.in, got, tt.full)
+		}
+		if got := MaskCardNumbers(MaskPartial)(tt.in); got != tt.partial {
+			t.Errorf("MaskCardNumbers(MaskPartial)(%q) = %q, want %q", tt.in, got, tt.partial)
+		}
+	}
+}
+
+func TestMaskIPs(t *testing.T) {
+	tests := []struct{ in, full, partial string }{
+		{"from 192.168.10.20", "from [IP]", "from 192.168.*.*"},
+		{"from 2001:db8:abcd:12::1", "from [IP]", "from 2001:db8:abcd::*"},
+		{"version 999.1.2.3", "version 999.1.2.3", "version 999.1.2.3"},
+		{"at 10:30:00", "at 10:30:00", "at 10:30:00"},
+	}
+	for _, tt := range tests {
+		if got := MaskIPs(MaskFull)(tt.in); got != tt.full {
+			t.Errorf("MaskIPs(MaskFull)(%q) = %q, want %q", tt.in, got, tt.full)
+		}
+		if got := MaskIPs(MaskPartial)(tt.in); got != tt.partial {
+			t.Errorf("MaskIPs(MaskPartial)(%q) = %q, want %q", tt.in, got, tt.partial)
+		}
+	}
+}
+
+func TestMask(t *testing.T) {
+	fields := Fields{"user": "jane@example.com", "n": 3}
+	e := Mask(Entry{Message: "from 10.0.0.1", Fields: fields}, MaskIPs(MaskFull), MaskEmails(MaskFull))
+	if e.Message != "from [IP]" || e.Fields["user"] != "[EMAIL]" || e.Fields["n"] != 3 {
+		t.Errorf("Mask() = %+v", e)
+	}
+	if fields["user"] != "jane@example.com" {
+		t.Error("Mask modified the fields of the entry")
+	}
+}
+
+func TestOutputMaskers(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	prod := new(syncBuffer)
+	l.AddOutput(prod, OutputOptions{Maskers: []Masker{MaskEmails(MaskPartial)}})
+
+	l.WithField("to", "jane@example.com").StandardInfo("sent")
+	if got := prod.String(); got != "INFO:  sent to=j***@example.com\n" {
+		t.Errorf("masked output = %q", got)
+	}
+	if got := stdout.String(); got != "INFO:  sent to=jane@example.com\n" {
+		t.Errorf("console = %q, want it unmasked", got)
+	}
+}
@@ -0,0 +1,23 @@
+package MyLog
+
+// Multiline selects how text lines render messages containing
+// newlines. JSON and logfmt always escape them.
+type Multiline uint8
+
+const (
+	MultilineRaw    Multiline = iota // continuation lines start at column 0 (default)
+	MultilineIndent                  // continuation lines are aligned with the message
+	MultilinePrefix                  // continuation lines repeat timestamp and prefix
+	MultilineEscape                  // newlines are written as \n
+)
+
+// SetMultiline sets how the text lines of l render multi-line
+// messages. Outputs have their own setting, see OutputOptions.
+func (l *Log) SetMultiline(m Multiline) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.multiline = m
+}
@@ -0,0 +1,55 @@
+package MyLog
+
+import (
+	"log"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestMultiline(t *testing.T) {
+	tests := []struct {
+		m    Multiline
+		want string
+	}{
+		{MultilineRaw, "WARN:  first\nsecond k=v\n"},
+		{MultilineIndent, "WARN:  first\n       second k=v\n"},
+		{MultilinePrefix, "WARN:  first\nWARN:  second k=v\n"},
+		{MultilineEscape, `WARN:  first\nsecond k=v` + "\n"},
+	}
+	for _, tt := range tests {
+		l, stdout, _ := newTestLog()
+		l.SetMultiline(tt.m)
+		l.WithField("k", "v").Warn("first\nsecond\n")
+		if got := stdout.String(); got != tt.want {
+			t.Errorf("mode %d: %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestMultilineTimestamp(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetFlags(log.Ltime)
+	l.SetMultiline(MultilineIndent)
+
+	l.StandardInfo("a\nb")
+	want := regexp.MustCompile(`^INFO:  \d\d:\d\d:\d\d a\n {16}b\n$`)
+	if !want.MatchString(stdout.String()) {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
+
+func TestMultilineOutput(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	esc, lf := new(syncBuffer), new(syncBuffer)
+	l.AddOutput(esc, OutputOptions{Multiline: MultilineEscape})
+	l.AddOutput(lf, OutputOptions{Format: FormatLogfmt})
+
+	l.StandardInfo("a\r\nb")
+	if stdout.String() != "INFO:  a\r\nb\n" || esc.String() != `INFO:  a\r\nb`+"\n" {
+		t.Errorf("stdout = %q, escaped output = %q", stdout.String(), esc.String())
+	}
+	if !strings.Contains(lf.String(), `msg="a\r\nb"`) || strings.Count(lf.String(), "\n") != 1 {
+		t.Errorf("logfmt output = %q", lf.String())
+	}
+}
@@ -0,0 +1,21 @@
+module github.com/hleinders/MyLog/myloggrpc
+
+go 1.21
+
+require (
+	github.com/hleinders/MyLog v0.0.0
+	google.golang.org/grpc v1.66.3
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/mattn/go-colorable v0.1.9 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	golang.org/x/net v0.26.0 // indirect
+	golang.org/x/sys v0.21.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+)
+
+replace github.com/hleinders/MyLog => ../
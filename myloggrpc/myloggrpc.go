@@ -0,0 +1,226 @@
+// Package myloggrpc provides gRPC client and server interceptors
+// logging method, peer, status code and latency of each call through a
+// MyLog.Log, optionally with the payloads.
+package myloggrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Options configures the interceptors
+type Options struct {
+	// Payloads logs requests and responses as debug entries
+	Payloads bool
+
+	// MaxPayload truncates rendered payloads, default 1024 bytes
+	MaxPayload int
+
+	// CodeLevel returns the level of a finished call, default
+	// DefaultCodeLevel
+	CodeLevel func(codes.Code) MyLog.Level
+}
+
+// DefaultCodeLevel logs successful calls as info and all others as
+// errors
+func DefaultCodeLevel(c codes.Code) MyLog.Level {
+	if c == codes.OK {
+		return MyLog.LevelInfo
+	}
+	return MyLog.LevelError
+}
+
+func (o Options) defaults() Options {
+	if o.MaxPayload <= 0 {
+		o.MaxPayload = 1024
+	}
+	if o.CodeLevel == nil {
+		o.CodeLevel = DefaultCodeLevel
+	}
+	return o
+}
+
+// UnaryServerInterceptor logs unary calls handled by the server. The
+// handler finds a Log with the method field in the context, see
+// MyLog.FromContext.
+func UnaryServerInterceptor(l *MyLog.Log, opts Options) grpc.UnaryServerInterceptor {
+	opts = opts.defaults()
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		cl := callLog(ctx, l, info.FullMethod, "server")
+		ctx = MyLog.IntoContext(ctx, cl)
+		if opts.Payloads {
+			cl.Debug("request %s", opts.render(req))
+		}
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		if opts.Payloads && err == nil {
+			cl.Debug("response %s", opts.render(resp))
+		}
+		opts.finish(cl, info.FullMethod, err, start)
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor logs streaming calls handled by the server
+func StreamServerInterceptor(l *MyLog.Log, opts Options) grpc.StreamServerInterceptor {
+	opts = opts.defaults()
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		cl := callLog(ss.Context(), l, info.FullMethod, "server")
+		ws := &serverStream{ServerStream: ss, ctx: MyLog.IntoContext(ss.Context(), cl), l: cl, opts: opts}
+
+		start := time.Now()
+		err := handler(srv, ws)
+		opts.finish(cl, info.FullMethod, err, start)
+		return err
+	}
+}
+
+// UnaryClientInterceptor logs unary calls made by the client
+func UnaryClientInterceptor(l *MyLog.Log, opts Options) grpc.UnaryClientInterceptor {
+	opts = opts.defaults()
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		cl := l.WithFields(MyLog.Fields{"grpc.method": method, "grpc.target": cc.Target(), "grpc.side": "client"})
+		if opts.Payloads {
+			cl.Debug("request %s", opts.render(req))
+		}
+
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, callOpts...)
+		if opts.Payloads && err == nil {
+			cl.Debug("response %s", opts.render(reply))
+		}
+		opts.finish(cl, method, err, start)
+		return err
+	}
+}
+
+// StreamClientInterceptor logs streaming calls made by the client,
+// once the stream ends
+func StreamClientInterceptor(l *MyLog.Log, opts Options) grpc.StreamClientInterceptor {
+	opts = opts.defaults()
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+		cl := l.WithFields(MyLog.Fields{"grpc.method": method, "grpc.target": cc.Target(), "grpc.side": "client"})
+
+		start := time.Now()
+		cs, err := streamer(ctx, desc, cc, method, callOpts...)
+		if err != nil {
+			opts.finish(cl, method, err, start)
+			return nil, err
+		}
+		return &clientStream{ClientStream: cs, l: cl, opts: opts, method: method, start: start}, nil
+	}
+}
+
+// callLog returns l with the fields of a call handled by the server
+func callLog(ctx context.Context, l *MyLog.Log, method, side string) *MyLog.Log {
+	f := MyLog.Fields{"grpc.method": method, "grpc.side": side}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		f["grpc.peer"] = p.Addr.String()
+	}
+	return l.WithFields(f)
+}
+
+// finish logs the end of a call with its status code and latency
+func (o Options) finish(l *MyLog.Log, method string, err error, start time.Time) {
+	code := status.Code(err)
+	l = l.WithFields(MyLog.Fields{"grpc.code": code.String(), "duration": time.Since(start)})
+	if err != nil {
+		l = l.WithField("error", status.Convert(err).Message())
+	}
+
+	msg := fmt.Sprintf("%s %s", path.Base(method), code)
+	switch lv := o.CodeLevel(code); {
+	case lv >= MyLog.LevelError:
+		l.Error("%s", msg)
+	case lv == MyLog.LevelWarn:
+		l.Warn("%s", msg)
+	case lv <= MyLog.LevelDebug:
+		l.Debug("%s", msg)
+	default:
+		l.StandardInfo("%s", msg)
+	}
+}
+
+// render formats a payload for logging
+func (o Options) render(m interface{}) string {
+	s := fmt.Sprintf("%v", m)
+	if len(s) > o.MaxPayload {
+		s = s[:o.MaxPayload] + "..."
+	}
+	return s
+}
+
+// serverStream carries the context with the Log and logs payloads
+type serverStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	l    *MyLog.Log
+	opts Options
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	err := s.ServerStream.SendMsg(m)
+	if s.opts.Payloads && err == nil {
+		s.l.Debug("sent %s", s.opts.render(m))
+	}
+	return err
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if s.opts.Payloads && err == nil {
+		s.l.Debug("received %s", s.opts.render(m))
+	}
+	return err
+}
+
+// clientStream logs payloads and the end of the stream
+type clientStream struct {
+	grpc.ClientStream
+	l      *MyLog.Log
+	opts   Options
+	method string
+	start  time.Time
+	done   bool
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	err := s.ClientStream.SendMsg(m)
+	if s.opts.Payloads && err == nil {
+		s.l.Debug("sent %s", s.opts.render(m))
+	}
+	return err
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == nil:
+		if s.opts.Payloads {
+			s.l.Debug("received %s", s.opts.render(m))
+		}
+	case !s.done:
+		s.done = true
+		if errors.Is(err, io.EOF) {
+			s.opts.finish(s.l, s.method, nil, s.start)
+		} else {
+			s.opts.finish(s.l, s.method, err, s.start)
+		}
+	}
+	return err
+}
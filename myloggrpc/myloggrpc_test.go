@@ -0,0 +1,182 @@
+package myloggrpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newLog() (*MyLog.Log, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	l := MyLog.New()
+	l.SetOutput(stdout, stderr)
+	l.SetFlags(0)
+	return l, stdout, stderr
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	l, stdout, stderr := newLog()
+	l.SetMode(MyLog.LgDebug)
+	icpt := UnaryServerInterceptor(l, Options{Payloads: true, MaxPayload: 5})
+	info := &grpc.UnaryServerInfo{FullMethod: "/shop.Cart/Add"}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(192, 0, 2, 1), Port: 80}})
+
+	resp, err := icpt(ctx, "request body", info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		MyLog.FromContext(ctx).Warn("inside")
+		return "ok", nil
+	})
+	if resp != "ok" || err != nil {
+		t.Fatalf("interceptor = %v, %v", resp, err)
+	}
+	out := stdout.String()
+	for _, s := range []string{"WARN:  inside grpc.method=/shop.Cart/Add grpc.peer=192.0.2.1:80 grpc.side=server", "INFO:  Add OK ", "grpc.code=OK"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("stdout misses %q: %q", s, out)
+		}
+	}
+	if got := stderr.String(); !strings.Contains(got, "DEBUG: request reque... ") || !strings.Contains(got, "DEBUG: response ok ") {
+		t.Errorf("payloads = %q", got)
+	}
+
+	_, err = icpt(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "no cart")
+	})
+	if status.Code(err) != codes.NotFound || !strings.Contains(stderr.String(), "ERROR: Add NotFound ") ||
+		!strings.Contains(stderr.String(), `error="no cart"`) {
+		t.Errorf("failed call = %v, logged %q", err, stderr.String())
+	}
+}
+
+func TestCodeLevel(t *testing.T) {
+	l, stdout, stderr := newLog()
+	opts := Options{CodeLevel: func(c codes.Code) MyLog.Level {
+		if c == codes.NotFound {
+			return MyLog.LevelWarn
+		}
+		return MyLog.LevelDebug
+	}}
+	icpt := UnaryServerInterceptor(l, opts)
+	info := &grpc.UnaryServerInfo{FullMethod: "/shop.Cart/Get"}
+
+	icpt(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "")
+	})
+	icpt(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+		return nil, nil
+	})
+	if !strings.HasPrefix(stdout.String(), "WARN:  Get NotFound ") || stderr.String() != "" {
+		t.Errorf("stdout = %q, stderr = %q, want a warning and a hidden debug entry", stdout.String(), stderr.String())
+	}
+}
+
+// fakeServerStream is a ServerStream receiving a single message
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	recv int
+}
+
+func (s *fakeServerStream) Context() context.Context  { return s.ctx }
+func (s *fakeServerStream) SendMsg(interface{}) error { return nil }
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	if s.recv++; s.recv > 1 {
+		return io.EOF
+	}
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	l, stdout, stderr := newLog()
+	l.SetMode(MyLog.LgDebug)
+	icpt := StreamServerInterceptor(l, Options{Payloads: true})
+	info := &grpc.StreamServerInfo{FullMethod: "/shop.Cart/Watch"}
+
+	err := icpt(nil, &fakeServerStream{ctx: context.Background()}, info, func(srv interface{}, ss grpc.ServerStream) error {
+		MyLog.FromContext(ss.Context()).StandardInfo("inside")
+		var m string
+		if err := ss.RecvMsg(&m); err != nil {
+			return err
+		}
+		return ss.SendMsg("update")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "INFO:  inside grpc.method=/shop.Cart/Watch") || !strings.Contains(out, "INFO:  Watch OK ") {
+		t.Errorf("stdout = %q", out)
+	}
+	if !strings.Contains(stderr.String(), "DEBUG: received ") || !strings.Contains(stderr.String(), "DEBUG: sent update ") {
+		t.Errorf("payloads = %q", stderr.String())
+	}
+}
+
+func newConn(t *testing.T) *grpc.ClientConn {
+	cc, err := grpc.NewClient("passthrough:///shop:443", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return cc
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	l, _, stderr := newLog()
+	icpt := UnaryClientInterceptor(l, Options{})
+
+	err := icpt(context.Background(), "/shop.Cart/Add", nil, nil, newConn(t),
+		func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+			return status.Error(codes.Unavailable, "down")
+		})
+	if status.Code(err) != codes.Unavailable {
+		t.Errorf("interceptor = %v", err)
+	}
+	out := stderr.String()
+	for _, s := range []string{"ERROR: Add Unavailable ", "grpc.side=client", "grpc.target=passthrough:///shop:443", "error=down"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("stderr misses %q: %q", s, out)
+		}
+	}
+}
+
+// fakeClientStream is a ClientStream receiving one message
+type fakeClientStream struct {
+	grpc.ClientStream
+	recv int
+}
+
+func (s *fakeClientStream) RecvMsg(m interface{}) error {
+	if s.recv++; s.recv > 1 {
+		return io.EOF
+	}
+	return nil
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	l, stdout, _ := newLog()
+	icpt := StreamClientInterceptor(l, Options{})
+
+	cs, err := icpt(context.Background(), &grpc.StreamDesc{}, newConn(t), "/shop.Cart/Watch",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &fakeClientStream{}, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		cs.RecvMsg(nil)
+	}
+	if got := strings.Count(stdout.String(), "INFO:  Watch OK "); got != 1 {
+		t.Errorf("stream end logged %d times: %q", got, stdout.String())
+	}
+}
@@ -0,0 +1,59 @@
+// Package mylogprom exposes the counters of a MyLog.Log as Prometheus
+// metrics.
+package mylogprom
+
+import (
+	MyLog "github.com/hleinders/MyLog"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	entriesDesc = prometheus.NewDesc("mylog_entries_total",
+		"Entries logged, by level.", []string{"level"}, nil)
+	discardedDesc = prometheus.NewDesc("mylog_discarded_total",
+		"Entries not written, by reason.", []string{"reason"}, nil)
+	queueDesc = prometheus.NewDesc("mylog_async_queue_length",
+		"Entries waiting in the async queue.", nil, nil)
+	writeDesc = prometheus.NewDesc("mylog_write_seconds",
+		"Time spent writing entries.", nil, nil)
+)
+
+// collector reads the Stats of a Log on each scrape
+type collector struct {
+	l *MyLog.Log
+}
+
+// Collector returns a prometheus.Collector for the Stats of l. Register
+// it once per Log:
+//
+//	prometheus.MustRegister(mylogprom.Collector(l))
+func Collector(l *MyLog.Log) prometheus.Collector {
+	return collector{l: l}
+}
+
+func (c collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- entriesDesc
+	ch <- discardedDesc
+	ch <- queueDesc
+	ch <- writeDesc
+}
+
+func (c collector) Collect(ch chan<- prometheus.Metric) {
+	s := c.l.Stats()
+
+	for level, n := range s.Levels {
+		ch <- prometheus.MustNewConstMetric(entriesDesc, prometheus.CounterValue, float64(n), level)
+	}
+
+	for reason, n := range map[string]uint64{
+		"async_dropped": s.Dropped,
+		"sampled":       s.Sampled,
+		"rate_limited":  s.RateLimited,
+		"deduplicated":  s.Deduplicated,
+	} {
+		ch <- prometheus.MustNewConstMetric(discardedDesc, prometheus.CounterValue, float64(n), reason)
+	}
+
+	ch <- prometheus.MustNewConstMetric(queueDesc, prometheus.GaugeValue, float64(s.Queued))
+	ch <- prometheus.MustNewConstSummary(writeDesc, s.Writes, s.WriteTime.Seconds(), nil)
+}
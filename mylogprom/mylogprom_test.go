@@ -0,0 +1,43 @@
+package mylogprom
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestCollector(t *testing.T) {
+	l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+	l.SetSampling(1, 0)
+	l.Warn("same")
+	l.Warn("same")
+	l.Error("failed")
+
+	want := `# HELP mylog_discarded_total Entries not written, by reason.
+# TYPE mylog_discarded_total counter
+mylog_discarded_total{reason="async_dropped"} 0
+mylog_discarded_total{reason="deduplicated"} 0
+mylog_discarded_total{reason="rate_limited"} 0
+mylog_discarded_total{reason="sampled"} 1
+# HELP mylog_entries_total Entries logged, by level.
+# TYPE mylog_entries_total counter
+mylog_entries_total{level="debug"} 0
+mylog_entries_total{level="error"} 1
+mylog_entries_total{level="fatal"} 0
+mylog_entries_total{level="info"} 0
+mylog_entries_total{level="panic"} 0
+mylog_entries_total{level="trace"} 0
+mylog_entries_total{level="verbose"} 0
+mylog_entries_total{level="warn"} 2
+`
+	err := testutil.CollectAndCompare(Collector(l), strings.NewReader(want), "mylog_entries_total", "mylog_discarded_total")
+	if err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(Collector(l)); n != 14 {
+		t.Errorf("collected %d metrics, want 14", n)
+	}
+}
@@ -0,0 +1,91 @@
+// Package mylogtest provides helpers for tests of code logging with
+// MyLog, recording entries so that tests can assert on them.
+package mylogtest
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Capture records the entries of a Log
+type Capture struct {
+	mu      sync.Mutex
+	entries []MyLog.Entry
+}
+
+// NewCaptureLog returns a Log recording all entries of all levels in
+// the returned Capture and writing nothing
+func NewCaptureLog() (*MyLog.Log, *Capture) {
+	c := new(Capture)
+
+	l := new(MyLog.Log)
+	l.Init(io.Discard, io.Discard)
+	l.SetOutput(io.Discard, io.Discard)
+	l.SetLevel(MyLog.LevelTrace)
+	l.AddHook(c.hook)
+	return l, c
+}
+
+func (c *Capture) hook(e MyLog.Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, e)
+	return nil
+}
+
+// Entries returns a copy of the recorded entries, oldest first
+func (c *Capture) Entries() []MyLog.Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]MyLog.Entry(nil), c.entries...)
+}
+
+// LastError returns the last entry of level Error or above, or nil
+func (c *Capture) LastError() *MyLog.Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := len(c.entries) - 1; i >= 0; i-- {
+		if c.entries[i].Level >= MyLog.LevelError {
+			e := c.entries[i]
+			return &e
+		}
+	}
+	return nil
+}
+
+// Reset drops all recorded entries
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+// Contains reports whether an entry of level lv contains substr in its
+// message or fields
+func (c *Capture) Contains(lv MyLog.Level, substr string) bool {
+	for _, e := range c.Entries() {
+		if e.Level == lv && strings.Contains(e.Message+e.Fields.String(), substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// AssertContains fails t unless an entry of level lv contains substr,
+// listing the recorded entries
+func (c *Capture) AssertContains(t testing.TB, lv MyLog.Level, substr string) {
+	t.Helper()
+	if c.Contains(lv, substr) {
+		return
+	}
+
+	var sb strings.Builder
+	for _, e := range c.Entries() {
+		sb.WriteString("\n\t" + e.Level.String() + ": " + e.Message + e.Fields.String())
+	}
+	t.Errorf("no %s entry containing %q, logged:%s", lv, substr, sb.String())
+}
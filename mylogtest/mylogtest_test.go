@@ -0,0 +1,76 @@
+package mylogtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// fakeTB records what the helpers report to a test
+type fakeTB struct {
+	testing.TB
+	mu       sync.Mutex
+	errors   []string
+	logs     []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, v ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.errors = append(f.errors, fmt.Sprintf(format, v...))
+}
+
+func (f *fakeTB) Log(v ...interface{}) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = append(f.logs, fmt.Sprint(v...))
+}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func TestCapture(t *testing.T) {
+	l, c := NewCaptureLog()
+	l.Trace("connecting")
+	l.WithField("user", "alice").Warn("retry")
+	l.Error("first failure")
+	l.Error("last failure")
+	l.StandardInfo("done")
+
+	if got := len(c.Entries()); got != 5 {
+		t.Errorf("%d entries recorded, want all 5", got)
+	}
+	if e := c.LastError(); e == nil || e.Message != "last failure" {
+		t.Errorf("LastError() = %v", e)
+	}
+	if !c.Contains(MyLog.LevelWarn, "user=alice") || c.Contains(MyLog.LevelError, "retry") {
+		t.Error("Contains() does not match level and text")
+	}
+
+	c.Reset()
+	if len(c.Entries()) != 0 || c.LastError() != nil {
+		t.Error("Reset() kept entries")
+	}
+}
+
+func TestAssertContains(t *testing.T) {
+	l, c := NewCaptureLog()
+	l.Warn("disk full")
+	ft := new(fakeTB)
+
+	c.AssertContains(ft, MyLog.LevelWarn, "disk")
+	if len(ft.errors) != 0 {
+		t.Errorf("matching assertion failed: %v", ft.errors)
+	}
+	c.AssertContains(ft, MyLog.LevelError, "disk")
+	if len(ft.errors) != 1 || !strings.Contains(ft.errors[0], `no error entry containing "disk", logged:`+"\n\twarn: disk full") {
+		t.Errorf("failed assertion reported %q", ft.errors)
+	}
+}
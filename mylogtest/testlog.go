@@ -0,0 +1,53 @@
+package mylogtest
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// NewTestLog returns a Log writing all output through t.Log, so that it
+// is shown with the test and only if it fails or with go test -v. With
+// -v debug and trace entries are written as well. The caller is
+// reported on each entry, as t.Log only sees the writer. Output after
+// the end of the test is dropped.
+func NewTestLog(t testing.TB) *MyLog.Log {
+	w := &testWriter{t: t}
+	t.Cleanup(w.stop)
+
+	l := new(MyLog.Log)
+	l.Init(w, w)
+	l.SetOutput(w, w)
+	l.SetFlags(log.Ltime | log.Lmicroseconds | log.Lmsgprefix)
+	l.SetReportCaller(true)
+	if testing.Verbose() {
+		l.SetLevel(MyLog.LevelTrace)
+	}
+	return l
+}
+
+// testWriter passes each write to t.Log
+type testWriter struct {
+	mu   sync.Mutex
+	t    testing.TB
+	done bool
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.done {
+		w.t.Helper()
+		w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+	return len(p), nil
+}
+
+func (w *testWriter) stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.done = true
+}
@@ -0,0 +1,40 @@
+package mylogtest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewTestLog(t *testing.T) {
+	ft := new(fakeTB)
+	l := NewTestLog(ft)
+
+	l.Warn("through t.Log")
+	l.Trace("verbose only")
+	if len(ft.logs) == 0 || !strings.Contains(ft.logs[0], "WARN:  through t.Log") {
+		t.Fatalf("t.Log got %q", ft.logs)
+	}
+	if !strings.Contains(ft.logs[0], "testlog_test.go:") {
+		t.Errorf("entry without the caller: %q", ft.logs[0])
+	}
+	if strings.HasSuffix(ft.logs[0], "\n") {
+		t.Errorf("entry passed on with its newline: %q", ft.logs[0])
+	}
+	// trace entries are written with -v only
+	want := 1
+	if testing.Verbose() {
+		want = 2
+	}
+	if len(ft.logs) != want {
+		t.Errorf("%d entries, want %d", len(ft.logs), want)
+	}
+
+	for _, fn := range ft.cleanups {
+		fn()
+	}
+	n := len(ft.logs)
+	l.Error("after the test")
+	if len(ft.logs) != n {
+		t.Errorf("entry logged after the end of the test: %q", ft.logs[n:])
+	}
+}
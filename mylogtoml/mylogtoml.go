@@ -0,0 +1,14 @@
+// Package mylogtoml lets MyLog.LoadConfig and MyLog.Log.WatchConfig
+// read TOML files. Import it for its side effect:
+//
+//	import _ "github.com/hleinders/MyLog/mylogtoml"
+package mylogtoml
+
+import (
+	"github.com/BurntSushi/toml"
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func init() {
+	MyLog.RegisterConfigFormat(".toml", toml.Unmarshal)
+}
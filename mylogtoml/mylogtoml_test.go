@@ -0,0 +1,25 @@
+package mylogtoml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.toml")
+	data := "level = \"warn\"\n\n[file]\npath = \"app.log\"\nmax_size_mb = 10\n\n[[outputs]]\npath = \"stderr\"\nmin_level = \"error\"\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := MyLog.LoadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Level != "warn" || cfg.File.MaxSizeMB != 10 || len(cfg.Outputs) != 1 || cfg.Outputs[0].MinLevel != "error" {
+		t.Errorf("cfg = %+v", cfg)
+	}
+}
@@ -0,0 +1,17 @@
+module github.com/hleinders/MyLog/mylogyaml
+
+go 1.21
+
+require (
+	github.com/hleinders/MyLog v0.0.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/mattn/go-colorable v0.1.9 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
+)
+
+replace github.com/hleinders/MyLog => ../
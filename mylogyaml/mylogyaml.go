@@ -0,0 +1,15 @@
+// Package mylogyaml lets MyLog.LoadConfig and MyLog.Log.WatchConfig
+// read YAML files. Import it for its side effect:
+//
+//	import _ "github.com/hleinders/MyLog/mylogyaml"
+package mylogyaml
+
+import (
+	MyLog "github.com/hleinders/MyLog"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	MyLog.RegisterConfigFormat(".yaml", yaml.Unmarshal)
+	MyLog.RegisterConfigFormat(".yml", yaml.Unmarshal)
+}
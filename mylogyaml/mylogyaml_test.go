@@ -0,0 +1,27 @@
+package mylogyaml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestLoadConfig(t *testing.T) {
+	for _, name := range []string{"log.yaml", "log.yml"} {
+		path := filepath.Join(t.TempDir(), name)
+		data := "level: warn\nfile:\n  path: app.log\n  max_size_mb: 10\noutputs:\n  - path: stderr\n    min_level: error\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		cfg, err := MyLog.LoadConfig(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Level != "warn" || cfg.File.MaxSizeMB != 10 || len(cfg.Outputs) != 1 || cfg.Outputs[0].MinLevel != "error" {
+			t.Errorf("%s: cfg = %+v", name, cfg)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package MyLog
+
+// Named returns a child Log for a subsystem, whose entries are marked
+// with "[name]". Names of nested children are joined with dots, e.g.
+// l.Named("db").Named("pool") logs as "[db.pool]". The child shares
+// outputs, modes and buffer with l.
+func (l *Log) Named(name string) *Log {
+	if !l.ready() {
+		return nil
+	}
+	c := *l
+	if c.name != "" && name != "" {
+		c.name += "." + name
+	} else if name != "" {
+		c.name = name
+	}
+	return &c
+}
+
+// WithPrefix returns a child Log that prepends prefix to every message,
+// after any prefix of l. The child shares outputs, modes and buffer with l.
+func (l *Log) WithPrefix(prefix string) *Log {
+	if !l.ready() {
+		return nil
+	}
+	c := *l
+	c.prefix += prefix
+	return &c
+}
+
+// Name returns the name set with Named
+func (l *Log) Name() string {
+	if !l.ready() {
+		return ""
+	}
+	return l.name
+}
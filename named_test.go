@@ -0,0 +1,43 @@
+package MyLog
+
+import "testing"
+
+func TestNamed(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	db := l.Named("db")
+	pool := db.Named("pool")
+
+	db.StandardInfo("connected")
+	pool.Warn("exhausted")
+	if pool.Name() != "db.pool" || l.Name() != "" {
+		t.Errorf("names = %q, %q", pool.Name(), l.Name())
+	}
+	want := "INFO:  [db] connected\nWARN:  [db.pool] exhausted\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestWithPrefix(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	w := l.WithPrefix("worker-3: ").WithPrefix("job 9: ")
+
+	w.StandardInfo("done")
+	l.StandardInfo("parent")
+	if want := "INFO:  worker-3: job 9: done\nINFO:  parent\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestChildSharesConfig(t *testing.T) {
+	l, _, stderr := newTestLog()
+	c := l.Named("db")
+
+	l.SetMode(LgDebug)
+	c.Debug("shown")
+	l.SetOutput(new(syncBuffer), stderr)
+	c.Error("moved")
+	if want := "DEBUG: [db] shown\nERROR: [db] moved\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+}
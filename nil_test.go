@@ -0,0 +1,76 @@
+package MyLog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestNilLog calls every method of a nil *Log with zero arguments; none
+// may panic, except Panicf, which panics by design. The Fatal methods
+// still exit.
+func TestNilLog(t *testing.T) {
+	stubExit(t)
+	var l *Log
+	v := reflect.ValueOf(l)
+	for i := 0; i < v.NumMethod(); i++ {
+		m, name := v.Method(i), v.Type().Method(i).Name
+		if name == "Panicf" {
+			continue
+		}
+
+		args := make([]reflect.Value, m.Type().NumIn())
+		for j := range args {
+			args[j] = zeroArg(m.Type().In(j))
+		}
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					t.Errorf("%s on a nil Log panics: %v", name, r)
+				}
+			}()
+			if m.Type().IsVariadic() {
+				m.CallSlice(args)
+			} else {
+				m.Call(args)
+			}
+		}()
+	}
+}
+
+// zeroArg returns the zero value of typ, or a func returning zero
+// values for func types, which callers must not pass as nil
+func zeroArg(typ reflect.Type) reflect.Value {
+	if typ.Kind() != reflect.Func {
+		return reflect.Zero(typ)
+	}
+	return reflect.MakeFunc(typ, func([]reflect.Value) []reflect.Value {
+		out := make([]reflect.Value, typ.NumOut())
+		for i := range out {
+			out[i] = reflect.Zero(typ.Out(i))
+		}
+		return out
+	})
+}
+
+func TestZeroLog(t *testing.T) {
+	var s struct{ Log Log }
+	out := captureStderr(t, func() {
+		s.Log.SetFlags(0)
+		s.Log.StandardInfo("info")
+		s.Log.Error("failed")
+	})
+	if out != "INFO:  info\nERROR: failed\n" {
+		t.Errorf("zero Log wrote %q, want stderr", out)
+	}
+
+	// derived loggers of a zero Log work as well
+	var z Log
+	w := new(syncBuffer)
+	z.WithField("k", 1).SetOutput(w, w)
+	z.SetFlags(0)
+	z.Warn("shared")
+	if !strings.HasPrefix(w.String(), "WARN:  shared") {
+		t.Errorf("output set through a derived Log = %q", w.String())
+	}
+}
@@ -0,0 +1,49 @@
+package MyLog
+
+// Logger is the set of logging methods of a Log, for libraries that
+// accept a logger optionally. Configuration and the methods that panic
+// or exit are left out.
+type Logger interface {
+	Standard(format string, v ...interface{})
+	Bold(format string, v ...interface{})
+	StandardInfo(format string, v ...interface{})
+	BoldInfo(format string, v ...interface{})
+	Verbose(format string, v ...interface{})
+	VerboseInfo(format string, v ...interface{})
+	Trace(format string, v ...interface{})
+	Debug(format string, v ...interface{})
+	Warn(format string, v ...interface{})
+	Error(format string, v ...interface{})
+	Panic(format string, v ...interface{})
+	ErrorWithStack(err error, format string, v ...interface{})
+	Enabled(lv Level) bool
+}
+
+var _ Logger = (*Log)(nil)
+
+// NopLogger returns a Logger discarding everything
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Standard(string, ...interface{})              {}
+func (nopLogger) Bold(string, ...interface{})                  {}
+func (nopLogger) StandardInfo(string, ...interface{})          {}
+func (nopLogger) BoldInfo(string, ...interface{})              {}
+func (nopLogger) Verbose(string, ...interface{})               {}
+func (nopLogger) VerboseInfo(string, ...interface{})           {}
+func (nopLogger) Trace(string, ...interface{})                 {}
+func (nopLogger) Debug(string, ...interface{})                 {}
+func (nopLogger) Warn(string, ...interface{})                  {}
+func (nopLogger) Error(string, ...interface{})                 {}
+func (nopLogger) Panic(string, ...interface{})                 {}
+func (nopLogger) ErrorWithStack(error, string, ...interface{}) {}
+func (nopLogger) Enabled(Level) bool                           { return false }
+
+// nopWriteCloser discards all writes
+type nopWriteCloser struct{}
+
+func (nopWriteCloser) Write(p []byte) (int, error) { return len(p), nil }
+func (nopWriteCloser) Close() error                { return nil }
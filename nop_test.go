@@ -0,0 +1,43 @@
+package MyLog
+
+import (
+	"errors"
+	"testing"
+)
+
+// useLogger logs through the Logger interface like a library would
+func useLogger(l Logger) {
+	l.Standard("standard")
+	l.Bold("bold")
+	l.StandardInfo("info %d", 1)
+	l.BoldInfo("bold info")
+	l.Verbose("verbose")
+	l.VerboseInfo("verbose info")
+	l.Trace("trace")
+	l.Debug("debug")
+	l.Warn("warn")
+	l.Error("error")
+	l.Panic("panic")
+	l.ErrorWithStack(errors.New("boom"), "failed")
+}
+
+func TestNopLogger(t *testing.T) {
+	l := NopLogger()
+	useLogger(l)
+	for lv := LevelTrace; lv <= LevelFatal; lv++ {
+		if l.Enabled(lv) {
+			t.Errorf("Enabled(%v) = true", lv)
+		}
+	}
+	if n := testing.AllocsPerRun(100, func() { l.Warn("disk %s", "full") }); n != 0 && !raceEnabled {
+		t.Errorf("Warn allocates %v times", n)
+	}
+}
+
+func TestLogIsLogger(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	useLogger(l)
+	if stdout.String() == "" || stderr.String() == "" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+}
@@ -0,0 +1,5 @@
+//go:build !race
+
+package MyLog
+
+const raceEnabled = false
@@ -0,0 +1,61 @@
+package MyLog
+
+import (
+	"io"
+	"os"
+)
+
+// New returns a Log writing to os.Stdout and os.Stderr like Init, with
+// opts applied
+func New(opts ...Option) *Log {
+	l := new(Log)
+	l.Init(os.Stdout, os.Stderr)
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Option changes a setting of a Log, see New and With
+type Option func(l *Log)
+
+// WithLevel sets the level, see SetLevel
+func WithLevel(lv Level) Option {
+	return func(l *Log) { l.SetLevel(lv) }
+}
+
+// WithOutput sets the stdout and stderr writers, see SetOutput
+func WithOutput(stdOut, stdErr io.Writer) Option {
+	return func(l *Log) { l.SetOutput(stdOut, stdErr) }
+}
+
+// WithColor enables or disables color mode, see SetColor
+func WithColor(b bool) Option {
+	return func(l *Log) { l.SetColor(b) }
+}
+
+// WithColorAuto enables color mode on terminals, see SetColorAuto
+func WithColorAuto() Option {
+	return func(l *Log) { l.SetColorAuto() }
+}
+
+// WithColorScope sets the colored parts of entries, see SetColorScope
+func WithColorScope(s ColorScope) Option {
+	return func(l *Log) { l.SetColorScope(s) }
+}
+
+// WithBuffer enables the buffer keeping the last n entries, all of them
+// if n is 0, see EnableBufferN
+func WithBuffer(n int) Option {
+	return func(l *Log) { l.EnableBufferN(n) }
+}
+
+// WithTimeFormat sets the timestamp layout, see SetTimeFormat
+func WithTimeFormat(layout string) Option {
+	return func(l *Log) { l.SetTimeFormat(layout) }
+}
+
+// WithFlags sets the log package flags, see SetFlags
+func WithFlags(flags int) Option {
+	return func(l *Log) { l.SetFlags(flags) }
+}
@@ -0,0 +1,42 @@
+package MyLog
+
+import (
+	"log"
+	"regexp"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	stdout, stderr := new(syncBuffer), new(syncBuffer)
+	l := New(
+		WithOutput(stdout, stderr),
+		WithLevel(LevelDebug),
+		WithFlags(log.Ltime),
+		WithTimeFormat("[15:04]"),
+		WithBuffer(2),
+		WithColor(true),
+		WithColorScope(ColorPrefixOnly),
+	)
+
+	l.Debug("shown")
+	for i := 0; i < 3; i++ {
+		l.AddBuffer("entry %d", i)
+	}
+
+	if ok, _ := regexp.MatchString(`^DEBUG: \[\d\d:\d\d\] shown\n$`, stderr.String()); !ok {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	if l.BufferLen() != 2 || l.GetBuffer() != "entry 1\nentry 2" {
+		t.Errorf("buffer = %q, want the last 2 entries", l.GetBuffer())
+	}
+	if !l.HasMode(LgColor) || l.colorScope != ColorPrefixOnly {
+		t.Errorf("color mode %v, scope %v", l.HasMode(LgColor), l.colorScope)
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	l := New()
+	if l.GetLevel() != LevelInfo || l.HasMode(LgBuffer) {
+		t.Errorf("level %v, mode %b", l.GetLevel(), l.GetMode())
+	}
+}
@@ -0,0 +1,253 @@
+// Package otlp exports MyLog entries to an OpenTelemetry collector,
+// using OTLP over HTTP with JSON encoding.
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	MyLog "github.com/hleinders/MyLog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Field names the trace context is stored in, see ContextExtractor
+const (
+	TraceIDField = "trace_id"
+	SpanIDField  = "span_id"
+)
+
+// Options configures an Exporter
+type Options struct {
+	Endpoint    string                  // default http://localhost:4318/v1/logs
+	Headers     map[string]string       // e.g. for authentication
+	ServiceName string                  // default the executable name
+	Client      *http.Client            // default http.DefaultClient
+	Batch       MyLog.BatchOptions      // used by Attach, MaxSize defaults to 512
+	Resilience  MyLog.ResilienceOptions // retries and circuit breaker used by Attach
+}
+
+// Exporter is a MyLog.Sink sending each batch of entries to the
+// collector in one request. Rejected requests are reported as
+// MyLog.Permanent, so only network errors, 429 and 5xx responses are
+// retried.
+type Exporter struct {
+	opts Options
+}
+
+// ContextExtractor returns a MyLog.ContextExtractor adding the trace
+// and span ID of the OpenTelemetry span in the context, see
+// MyLog.Log.SetContextExtractor
+func ContextExtractor() MyLog.ContextExtractor {
+	return func(ctx context.Context) MyLog.Fields {
+		sc := trace.SpanContextFromContext(ctx)
+		if !sc.IsValid() {
+			return nil
+		}
+		return MyLog.Fields{TraceIDField: sc.TraceID().String(), SpanIDField: sc.SpanID().String()}
+	}
+}
+
+// New returns an Exporter. It sends synchronously, see Attach for
+// batching and retries.
+func New(opts Options) *Exporter {
+	if opts.Endpoint == "" {
+		opts.Endpoint = "http://localhost:4318/v1/logs"
+	}
+	if opts.ServiceName == "" {
+		opts.ServiceName = filepath.Base(os.Args[0])
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Batch.MaxSize <= 0 {
+		opts.Batch.MaxSize = 512
+	}
+	return &Exporter{opts: opts}
+}
+
+// Attach adds an Exporter for all entries of l as a sink, batched and
+// with retries, and sets ContextExtractor on l. l.Flush and l.Close
+// send the queued entries.
+func Attach(l *MyLog.Log, opts Options) *MyLog.BatchSink {
+	x := New(opts)
+	l.SetContextExtractor(ContextExtractor())
+	b := MyLog.Batch(MyLog.NewResilientSink(x, x.opts.Resilience), x.opts.Batch)
+	l.AddSink(b)
+	return b
+}
+
+// WriteEntries sends entries in one request
+func (x *Exporter) WriteEntries(entries []MyLog.Entry) error {
+	body, err := json.Marshal(x.request(entries))
+	if err != nil {
+		return MyLog.Permanent(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, x.opts.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return MyLog.Permanent(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range x.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := x.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("otlp: %s", resp.Status)
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			err = MyLog.Permanent(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Close does nothing, there is no connection to close
+func (x *Exporter) Close() error {
+	return nil
+}
+
+// OTLP/JSON messages, see opentelemetry-proto logs/v1
+type (
+	exportRequest struct {
+		ResourceLogs []resourceLogs `json:"resourceLogs"`
+	}
+	resourceLogs struct {
+		Resource  resource    `json:"resource"`
+		ScopeLogs []scopeLogs `json:"scopeLogs"`
+	}
+	resource struct {
+		Attributes []keyValue `json:"attributes"`
+	}
+	scopeLogs struct {
+		Scope      scope       `json:"scope"`
+		LogRecords []logRecord `json:"logRecords"`
+	}
+	scope struct {
+		Name string `json:"name"`
+	}
+	logRecord struct {
+		TimeUnixNano   string     `json:"timeUnixNano"`
+		SeverityNumber int        `json:"severityNumber"`
+		SeverityText   string     `json:"severityText"`
+		Body           anyValue   `json:"body"`
+		Attributes     []keyValue `json:"attributes,omitempty"`
+		TraceID        string     `json:"traceId,omitempty"`
+		SpanID         string     `json:"spanId,omitempty"`
+	}
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+	anyValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+func (x *Exporter) request(entries []MyLog.Entry) exportRequest {
+	records := make([]logRecord, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, record(e))
+	}
+
+	return exportRequest{ResourceLogs: []resourceLogs{{
+		Resource: resource{Attributes: []keyValue{{Key: "service.name", Value: stringValue(x.opts.ServiceName)}}},
+		ScopeLogs: []scopeLogs{{
+			Scope:      scope{Name: "github.com/hleinders/MyLog"},
+			LogRecords: records,
+		}},
+	}}}
+}
+
+// record converts e; trace and span IDs are taken from its fields
+func record(e MyLog.Entry) logRecord {
+	r := logRecord{
+		TimeUnixNano:   strconv.FormatInt(e.Time.UnixNano(), 10),
+		SeverityNumber: severity(e.Level),
+		SeverityText:   strings.ToUpper(e.Level.String()),
+		Body:           stringValue(e.Message),
+	}
+
+	for k, v := range e.Fields {
+		switch k {
+		case TraceIDField:
+			r.TraceID = fmt.Sprint(v)
+		case SpanIDField:
+			r.SpanID = fmt.Sprint(v)
+		default:
+			r.Attributes = append(r.Attributes, keyValue{Key: k, Value: value(v)})
+		}
+	}
+	if e.Name != "" {
+		r.Attributes = append(r.Attributes, keyValue{Key: "logger.name", Value: stringValue(e.Name)})
+	}
+	if e.Caller != "" {
+		r.Attributes = append(r.Attributes, keyValue{Key: "code.caller", Value: stringValue(e.Caller)})
+	}
+	if e.Stack != "" {
+		r.Attributes = append(r.Attributes, keyValue{Key: "exception.stacktrace", Value: stringValue(e.Stack)})
+	}
+	return r
+}
+
+// severity maps a MyLog level onto an OTLP severity number
+func severity(lv MyLog.Level) int {
+	switch lv {
+	case MyLog.LevelTrace:
+		return 1
+	case MyLog.LevelDebug:
+		return 5
+	case MyLog.LevelVerbose:
+		return 8
+	case MyLog.LevelInfo:
+		return 9
+	case MyLog.LevelWarn:
+		return 13
+	case MyLog.LevelError:
+		return 17
+	case MyLog.LevelPanic:
+		return 20
+	}
+	return 21
+}
+
+func stringValue(s string) anyValue {
+	return anyValue{StringValue: &s}
+}
+
+// value converts a field value into an attribute value
+func value(v interface{}) anyValue {
+	switch v := v.(type) {
+	case string:
+		return stringValue(v)
+	case bool:
+		return anyValue{BoolValue: &v}
+	case int:
+		s := strconv.Itoa(v)
+		return anyValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return anyValue{IntValue: &s}
+	case float64:
+		return anyValue{DoubleValue: &v}
+	case error:
+		return stringValue(v.Error())
+	}
+	return stringValue(fmt.Sprint(v))
+}
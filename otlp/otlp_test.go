@@ -0,0 +1,103 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// collector records the requests sent to it
+type collector struct {
+	mu       sync.Mutex
+	requests []exportRequest
+	headers  []http.Header
+}
+
+func newCollector(t *testing.T, status int) (*collector, string) {
+	c := new(collector)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req exportRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		c.mu.Lock()
+		c.requests = append(c.requests, req)
+		c.headers = append(c.headers, r.Header)
+		c.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return c, s.URL
+}
+
+func TestWriteEntries(t *testing.T) {
+	c, url := newCollector(t, http.StatusOK)
+	x := New(Options{Endpoint: url, ServiceName: "svc", Headers: map[string]string{"Authorization": "Bearer k"}})
+
+	err := x.WriteEntries([]MyLog.Entry{
+		{Time: time.Unix(1, 5), Level: MyLog.LevelWarn, Message: "careful", Name: "db",
+			Fields: MyLog.Fields{"n": 3, "ok": true, TraceIDField: "abc", SpanIDField: "def"}},
+		{Time: time.Unix(2, 0), Level: MyLog.LevelFatal, Message: "gone"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.requests) != 1 || c.headers[0].Get("Authorization") != "Bearer k" {
+		t.Fatalf("%d requests, headers %v", len(c.requests), c.headers)
+	}
+	rl := c.requests[0].ResourceLogs[0]
+	if *rl.Resource.Attributes[0].Value.StringValue != "svc" {
+		t.Errorf("resource = %+v", rl.Resource)
+	}
+	recs := rl.ScopeLogs[0].LogRecords
+	if len(recs) != 2 {
+		t.Fatalf("%d records, want 2", len(recs))
+	}
+	r := recs[0]
+	if r.TimeUnixNano != "1000000005" || r.SeverityNumber != 13 || r.SeverityText != "WARN" || *r.Body.StringValue != "careful" {
+		t.Errorf("record = %+v", r)
+	}
+	if r.TraceID != "abc" || r.SpanID != "def" || len(r.Attributes) != 3 {
+		t.Errorf("trace %q, span %q, attributes %+v", r.TraceID, r.SpanID, r.Attributes)
+	}
+	if recs[1].SeverityNumber != 21 {
+		t.Errorf("fatal severity = %d", recs[1].SeverityNumber)
+	}
+}
+
+func TestAttach(t *testing.T) {
+	c, url := newCollector(t, http.StatusOK)
+	l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+	Attach(l, Options{Endpoint: url})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{1, 2, 3},
+		SpanID:  trace.SpanID{4, 5},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+	l.InfoCtx(ctx, "traced")
+	l.Error("plain")
+	l.Close()
+
+	if len(c.requests) != 1 {
+		t.Fatalf("%d requests, want 1 batch", len(c.requests))
+	}
+	recs := c.requests[0].ResourceLogs[0].ScopeLogs[0].LogRecords
+	if len(recs) != 2 || recs[0].TraceID != sc.TraceID().String() || recs[0].SpanID != sc.SpanID().String() || recs[1].TraceID != "" {
+		t.Errorf("records = %+v", recs)
+	}
+}
+
+func TestRejected(t *testing.T) {
+	_, url := newCollector(t, http.StatusBadRequest)
+	if err := New(Options{Endpoint: url}).WriteEntries([]MyLog.Entry{{}}); err == nil {
+		t.Error("rejected request succeeded")
+	}
+}
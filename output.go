@@ -0,0 +1,106 @@
+package MyLog
+
+import (
+	"io"
+	"strings"
+)
+
+// OutputOptions control how entries are rendered for an output added with AddOutput
+type OutputOptions struct {
+	Format    Format    // FormatText, FormatJSON or FormatLogfmt
+	Formatter Formatter // replaces Format if set
+	Maskers   []Masker  // applied to messages and fields, e.g. MaskEmails(MaskFull)
+	Color     bool      // color prefixes and messages in text format
+	Flags     int       // timestamp flags of the log package, e.g. log.LstdFlags
+	MinLevel  Level     // entries below this level are not written
+	Multiline Multiline // handling of multi-line messages in text format
+}
+
+// output is an additional destination receiving all entries
+type output struct {
+	w          io.Writer
+	opts       OutputOptions
+	fromConfig bool // added by ApplyConfig
+}
+
+// AddOutput adds w as an additional destination for all entries, next
+// to the stdout/stderr writers. Each output has its own format options,
+// e.g. a colored console and a plain file copy. Without Color, escape
+// sequences are stripped unless w is a terminal.
+func (l *Log) AddOutput(w io.Writer, opts OutputOptions) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !opts.Color {
+		w = plainUnlessTerminal(w)
+	}
+	l.outputs = append(l.outputs, output{w: l.locked(w), opts: opts})
+}
+
+// RemoveOutputs removes all outputs added with AddOutput
+func (l *Log) RemoveOutputs() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.outputs = nil
+}
+
+// textPrefix returns the text prefix of e, aligned like the level loggers
+func (l *Log) textPrefix(e *Entry) string {
+	if e.Prefix == "" {
+		return l.stdPrefix()
+	}
+	return l.levelPrefix(labelLevel(e.Level))
+}
+
+// paintPrefix colors a text prefix like SetColorPrefix does
+func (l *Log) paintPrefix(lv Level, p string) string {
+	if strings.TrimSpace(p) == "" {
+		return p
+	}
+	return l.painter(labelLevel(lv))(p)
+}
+
+// toOutputs writes an entry to all outputs added with AddOutput
+func (l *Log) toOutputs(e *Entry, paint paintFunc) {
+	l.mu.RLock()
+	outputs := l.outputs
+	scope, paint := l.colorScope, l.messagePaint(paint, false)
+	l.mu.RUnlock()
+
+	for _, o := range outputs {
+		if e.Level < o.opts.MinLevel {
+			continue
+		}
+		e := e
+		if len(o.opts.Maskers) > 0 {
+			me := Mask(*e, o.opts.Maskers...)
+			e = &me
+		}
+
+		f := o.opts.Formatter
+		if f == nil {
+			f = o.opts.Format.formatter()
+		}
+		if f != nil {
+			writeFormatted(o.w, f, l.structured(e))
+			continue
+		}
+
+		lf := lineFormat{o.opts.Flags, l.textPrefix(e), l.timeFormat(e), plain, o.opts.Multiline, 0}
+		if o.opts.Color {
+			if scope != ColorMessageOnly {
+				lf.prefix = l.paintPrefix(e.Level, lf.prefix)
+			}
+			lf.paint = paint
+		}
+		b := getBuf()
+		*b, _ = lf.appendEntry(*b, e)
+		o.w.Write(*b)
+		putBuf(b)
+	}
+}
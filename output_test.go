@@ -0,0 +1,59 @@
+package MyLog
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAddOutput(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	text, js, errsOnly := new(syncBuffer), new(syncBuffer), new(syncBuffer)
+	l.AddOutput(text, OutputOptions{})
+	l.AddOutput(js, OutputOptions{Format: FormatJSON})
+	l.AddOutput(errsOnly, OutputOptions{MinLevel: LevelError})
+
+	l.WithField("k", "v").StandardInfo("hello")
+	l.Error("failed")
+
+	if got, want := text.String(), "INFO:  hello k=v\nERROR: failed\n"; got != want {
+		t.Errorf("text output = %q, want %q", got, want)
+	}
+	if got, want := errsOnly.String(), "ERROR: failed\n"; got != want {
+		t.Errorf("error output = %q, want %q", got, want)
+	}
+	lines := js.lines()
+	if len(lines) != 2 {
+		t.Fatalf("JSON output = %q", js.String())
+	}
+	var e struct{ Level, Message string }
+	if err := json.Unmarshal([]byte(lines[1]), &e); err != nil || e.Level != "error" || e.Message != "failed" {
+		t.Errorf("JSON entry = %+v, %v", e, err)
+	}
+	if stdout.String() != "INFO:  hello k=v\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	l.RemoveOutputs()
+	l.StandardInfo("main only")
+	if strings.Contains(text.String(), "main only") {
+		t.Error("RemoveOutputs kept an output")
+	}
+}
+
+func TestAddOutputColor(t *testing.T) {
+	forceColor(t)
+	l, _, _ := newTestLog()
+	l.SetColor(true)
+	plainOut, colored := new(syncBuffer), new(syncBuffer)
+	l.AddOutput(plainOut, OutputOptions{})
+	l.AddOutput(colored, OutputOptions{Color: true})
+
+	l.Warn("careful")
+	if strings.Contains(plainOut.String(), "\x1b[") {
+		t.Errorf("plain output has escapes: %q", plainOut.String())
+	}
+	if !strings.Contains(colored.String(), "\x1b[") || stripANSI(colored.String()) != "WARN:  careful\n" {
+		t.Errorf("colored output = %q", colored.String())
+	}
+}
@@ -0,0 +1,36 @@
+package MyLog
+
+import "fmt"
+
+// Panicf logs the message like Panic and then panics with it
+func (l *Log) Panicf(format string, v ...interface{}) {
+	msg := fmt.Sprintf(format, v...)
+	l.Panic("%s", msg)
+	panic(msg)
+}
+
+// RecoverAndLog recovers from a panic and logs it together with the
+// stack trace. If dumpBuffer is set, the log buffer is written as well.
+// The panic goes on unrecovered if l is nil or the panic level is not
+// enabled. It must be deferred directly:
+//
+//	defer l.RecoverAndLog(true)
+func (l *Log) RecoverAndLog(dumpBuffer bool) {
+	// checked before recovering, so that the panic is not swallowed
+	if !l.ready() || !l.Enabled(LevelPanic) {
+		return
+	}
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	l.dumpRecorder()
+	l.panic(true, fmt.Sprintf("recovered: %v", r))
+
+	if dumpBuffer {
+		if buf := l.GetBuffer(); buf != "" {
+			l.panic(false, "buffer contents:\n"+buf)
+		}
+	}
+}
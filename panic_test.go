@@ -0,0 +1,73 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPanicf(t *testing.T) {
+	l, _, stderr := newTestLog()
+	defer func() {
+		if r := recover(); r != "bad state 3" {
+			t.Errorf("recovered %v, want the message", r)
+		}
+		out := stderr.String()
+		// frames of this package are skipped, the test function included
+		if !strings.HasPrefix(out, "PANIC: bad state 3\n") || !strings.Contains(out, "testing.tRunner()") {
+			t.Errorf("stderr = %q, want the message with a stack trace", out)
+		}
+	}()
+	l.Panicf("bad state %d", 3)
+}
+
+func TestPanicDoesNotPanic(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.Panic("logged only")
+	if !strings.HasPrefix(stderr.String(), "PANIC: logged only\n") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestRecoverAndLog(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.EnableBuffer()
+	l.StandardInfo("before")
+
+	func() {
+		defer l.RecoverAndLog(true)
+		panic("boom")
+	}()
+
+	out := stderr.String()
+	if !strings.Contains(out, "PANIC: recovered: boom\n") {
+		t.Errorf("stderr = %q, want the recovered value", out)
+	}
+	if !strings.Contains(out, "PANIC: buffer contents:\nbefore") {
+		t.Errorf("stderr = %q, want the buffer", out)
+	}
+}
+
+// panicsThrough reports whether a panic got past a deferred RecoverAndLog
+func panicsThrough(l *Log) (panicked bool) {
+	defer func() { panicked = recover() != nil }()
+	func() {
+		defer l.RecoverAndLog(false)
+		panic("boom")
+	}()
+	return false
+}
+
+func TestRecoverAndLogKeepsPanic(t *testing.T) {
+	if !panicsThrough(nil) {
+		t.Error("a nil Log swallowed the panic")
+	}
+
+	l, _, stderr := newTestLog()
+	l.Suppress(LevelPanic)
+	if !panicsThrough(l) {
+		t.Error("a Log with the panic level suppressed swallowed the panic")
+	}
+	if stderr.String() != "" {
+		t.Errorf("stderr = %q, want nothing", stderr.String())
+	}
+}
@@ -0,0 +1,29 @@
+// Package pflagset registers the logging flags of MyLog.Log.FlagSet on
+// a pflag.FlagSet, with -v as shorthand for --verbose.
+package pflagset
+
+import (
+	"flag"
+
+	MyLog "github.com/hleinders/MyLog"
+	"github.com/spf13/pflag"
+)
+
+// Register adds the flags of l.FlagSet to fs, or to pflag.CommandLine
+// if fs is nil. -vv and -vvv raise the verbosity step by step.
+func Register(l *MyLog.Log, fs *pflag.FlagSet) {
+	if fs == nil {
+		fs = pflag.CommandLine
+	}
+
+	gfs := flag.NewFlagSet("", flag.ContinueOnError)
+	l.FlagSet(gfs)
+	gfs.VisitAll(func(gf *flag.Flag) {
+		pf := pflag.PFlagFromGoFlag(gf)
+		if pf.Name == "verbose" {
+			pf.Shorthand = "v"
+			pf.NoOptDefVal = "+1"
+		}
+		fs.AddFlag(pf)
+	})
+}
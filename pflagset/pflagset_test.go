@@ -0,0 +1,41 @@
+package pflagset
+
+import (
+	"io"
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+	"github.com/spf13/pflag"
+)
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"-v"}, 1},
+		{[]string{"-vvv"}, 3},
+		{[]string{"-v", "--verbose"}, 2},
+		{[]string{"--verbose=4"}, 4},
+	}
+	for _, tt := range tests {
+		l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		Register(l, fs)
+		if err := fs.Parse(tt.args); err != nil || l.GetVerbosity() != tt.want {
+			t.Errorf("%q: verbosity %d, %v, want %d", tt.args, l.GetVerbosity(), err, tt.want)
+		}
+	}
+}
+
+func TestRegisterFlags(t *testing.T) {
+	l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+	fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	Register(l, fs)
+	if err := fs.Parse([]string{"--debug", "--log-format=logfmt"}); err != nil {
+		t.Fatal(err)
+	}
+	if !l.HasMode(MyLog.LgDebug) || l.GetFormat() != MyLog.FormatLogfmt {
+		t.Errorf("mode %b, format %v", l.GetMode(), l.GetFormat())
+	}
+}
@@ -0,0 +1,26 @@
+package MyLog
+
+import "sync"
+
+// maxPooledBuf keeps unusually large lines from pinning memory in the pool
+const maxPooledBuf = 64 << 10
+
+// bufPool holds the byte slices lines are rendered into
+var bufPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 256)
+		return &b
+	},
+}
+
+func getBuf() *[]byte {
+	b := bufPool.Get().(*[]byte)
+	*b = (*b)[:0]
+	return b
+}
+
+func putBuf(b *[]byte) {
+	if cap(*b) <= maxPooledBuf {
+		bufPool.Put(b)
+	}
+}
@@ -0,0 +1,95 @@
+package MyLog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// PrefixData holds the values available to prefix templates
+type PrefixData struct {
+	Level string // level label like "WARN"
+	App   string // base name of the executable
+	Pid   int
+}
+
+// SetLevelPrefix replaces the prefix of lv, "WARN:  " for example. The
+// prefix may be a text/template using the fields of PrefixData, like
+// "{{.Level}} {{.App}}: ". Info and Verbose share their prefix. An
+// empty prefix restores the default.
+func (l *Log) SetLevelPrefix(lv Level, prefix string) error {
+	if !l.ready() {
+		return nil
+	}
+	lv = labelLevel(lv)
+
+	if strings.Contains(prefix, "{{") {
+		t, err := template.New("prefix").Parse(prefix)
+		if err != nil {
+			return err
+		}
+
+		data := PrefixData{
+			Level: strings.ToUpper(lv.String()),
+			App:   filepath.Base(os.Args[0]),
+			Pid:   os.Getpid(),
+		}
+		var sb strings.Builder
+		if err := t.Execute(&sb, data); err != nil {
+			return err
+		}
+		prefix = sb.String()
+	}
+
+	l.mu.Lock()
+	if prefix == "" {
+		delete(l.levelPrefixes, lv)
+	} else {
+		if l.levelPrefixes == nil {
+			l.levelPrefixes = make(map[Level]string)
+		}
+		l.levelPrefixes[lv] = prefix
+	}
+	colored := l.coloredPrefix
+	l.mu.Unlock()
+
+	p := l.levelPrefix(lv)
+	if colored {
+		p = l.painter(lv)(p)
+	}
+	l.levelVar(lv, "INFO").SetPrefix(p)
+	return nil
+}
+
+// levelPrefix returns the prefix of lv, as set with SetLevelPrefix or
+// the default, with its icon
+func (l *Log) levelPrefix(lv Level) string {
+	l.mu.RLock()
+	p, ok := l.levelPrefixes[lv]
+	l.mu.RUnlock()
+
+	if !ok {
+		p = defaultPrefix(lv)
+	}
+	return l.withIcon(lv, p, false)
+}
+
+// defaultPrefix returns the prefix of lv without SetLevelPrefix
+func defaultPrefix(lv Level) string {
+	return fmt.Sprintf("%-6s ", strings.ToUpper(lv.String())+":")
+}
+
+// stdPrefix returns the blank prefix of standard messages
+func (l *Log) stdPrefix() string {
+	return l.withIcon(LevelInfo, "       ", true)
+}
+
+// labelLevel returns the level whose prefix entries of lv carry
+func labelLevel(lv Level) Level {
+	if lv == LevelVerbose {
+		return LevelInfo
+	}
+	return lv
+}
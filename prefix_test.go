@@ -0,0 +1,45 @@
+package MyLog
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetLevelPrefix(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	if err := l.SetLevelPrefix(LevelWarn, "W "); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.SetLevelPrefix(LevelError, "{{.Level}} {{.App}}[{{.Pid}}]: "); err != nil {
+		t.Fatal(err)
+	}
+	l.SetLevelPrefix(LevelVerbose, "I ")
+
+	l.Warn("careful")
+	l.StandardInfo("info")
+	l.Error("failed")
+	if want := "W careful\nI info\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	want := fmt.Sprintf("ERROR %s[%d]: failed\n", filepath.Base(os.Args[0]), os.Getpid())
+	if stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+
+	l.SetLevelPrefix(LevelWarn, "")
+	l.Warn("default")
+	if want := "W careful\nI info\nWARN:  default\n"; stdout.String() != want {
+		t.Errorf("stdout = %q after restoring the default", stdout.String())
+	}
+}
+
+func TestSetLevelPrefixInvalid(t *testing.T) {
+	l, _, _ := newTestLog()
+	for _, p := range []string{"{{.Level", "{{.Missing}}"} {
+		if err := l.SetLevelPrefix(LevelWarn, p); err == nil {
+			t.Errorf("SetLevelPrefix(%q) succeeded", p)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package MyLog
+
+import (
+	"os"
+	"testing"
+)
+
+// openPTY skips the test, pseudo terminals are only opened on Linux
+func openPTY(t *testing.T) (master, tty *os.File) {
+	t.Skip("pseudo terminals are only opened on Linux")
+	return nil, nil
+}
@@ -0,0 +1,51 @@
+package MyLog
+
+// SetQuiet limits l to warnings and above, regardless of the level, the
+// module levels and LgDebug or LgVerbose, e.g. for a --quiet flag
+func (l *Log) SetQuiet(b bool) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.quiet = b
+}
+
+// IsQuiet reports whether SetQuiet is in effect
+func (l *Log) IsQuiet() bool {
+	if !l.ready() {
+		return false
+	}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.quiet
+}
+
+// Suppress silences the given levels entirely, whatever the other
+// settings. Suppressed entries are not buffered or passed to hooks.
+func (l *Log) Suppress(levels ...Level) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, lv := range levels {
+		l.suppressed |= 1 << lv
+	}
+}
+
+// Unsuppress enables the given levels again, or all levels if none
+// are given
+func (l *Log) Unsuppress(levels ...Level) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(levels) == 0 {
+		l.suppressed = 0
+	}
+	for _, lv := range levels {
+		l.suppressed &^= 1 << lv
+	}
+}
@@ -0,0 +1,61 @@
+package MyLog
+
+import "testing"
+
+func TestSetQuiet(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetMode(LgDebug | LgVerbose)
+	l.SetLevel(LevelTrace)
+	if err := l.SetModuleLevels("db=trace"); err != nil {
+		t.Fatal(err)
+	}
+	l.SetQuiet(true)
+
+	db := l.Named("db")
+	l.StandardInfo("info")
+	l.Verbose("verbose")
+	db.Debug("debug")
+	l.Warn("warn")
+	db.Error("error")
+	if !l.IsQuiet() || stdout.String() != "WARN:  warn\n" || stderr.String() != "ERROR: [db] error\n" {
+		t.Errorf("quiet: stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	l.SetQuiet(false)
+	l.StandardInfo("info")
+	if l.IsQuiet() || stdout.String() != "WARN:  warn\nINFO:  info\n" {
+		t.Errorf("after SetQuiet(false): stdout = %q", stdout.String())
+	}
+}
+
+func TestSuppress(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	hooked := 0
+	l.AddHook(func(Entry) error { hooked++; return nil })
+	l.EnableBuffer()
+	l.SetMode(LgDebug | LgBuffer)
+
+	l.Suppress(LevelWarn, LevelDebug)
+	l.Warn("warn")
+	l.Debug("debug")
+	l.Error("error")
+	if stdout.String() != "" || stderr.String() != "ERROR: error\n" {
+		t.Errorf("suppressed: stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+	if hooked != 1 || l.BufferLen() != 1 {
+		t.Errorf("hook ran %d times, %d buffered, want the error only", hooked, l.BufferLen())
+	}
+
+	l.Unsuppress(LevelWarn)
+	l.Warn("warn")
+	l.Debug("debug")
+	if stdout.String() != "WARN:  warn\n" || stderr.String() != "ERROR: error\n" {
+		t.Errorf("after Unsuppress(LevelWarn): stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	l.Unsuppress()
+	l.Debug("debug")
+	if stderr.String() != "ERROR: error\nDEBUG: debug\n" {
+		t.Errorf("after Unsuppress(): stderr = %q", stderr.String())
+	}
+}
@@ -0,0 +1,7 @@
+//go:build race
+
+package MyLog
+
+// raceEnabled reports whether the tests run with the race detector,
+// which adds allocations
+const raceEnabled = true
@@ -0,0 +1,95 @@
+package MyLog
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// rateLimiter allows n entries per period of one level
+type rateLimiter struct {
+	n   int
+	per time.Duration
+
+	mu         sync.Mutex
+	start      time.Time
+	count      int
+	suppressed int
+	lg         *log.Logger
+	paint      paintFunc
+	prefix     string
+	timer      *time.Timer
+}
+
+// SetRateLimit caps entries of level lv at n per period; the number of
+// suppressed entries is reported once the period is over. An n of 0
+// removes the limit.
+func (l *Log) SetRateLimit(lv Level, n int, per time.Duration) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	old := l.rateLimits[lv]
+	if n > 0 && per > 0 {
+		if l.rateLimits == nil {
+			l.rateLimits = make(map[Level]*rateLimiter)
+		}
+		l.rateLimits[lv] = &rateLimiter{n: n, per: per}
+	} else {
+		delete(l.rateLimits, lv)
+	}
+	l.mu.Unlock()
+
+	if old != nil {
+		l.reportSuppressed(lv, old)
+	}
+}
+
+// limited reports whether e is within the rate limit of its level
+func (l *Log) limited(lg *log.Logger, paint paintFunc, e *Entry) bool {
+	l.mu.RLock()
+	r := l.rateLimits[e.Level]
+	l.mu.RUnlock()
+
+	if r == nil || e.Level >= LevelPanic {
+		return true
+	}
+
+	r.mu.Lock()
+	if e.Time.Sub(r.start) >= r.per {
+		r.start, r.count = e.Time, 0
+	}
+	r.count++
+	if r.count <= r.n {
+		r.mu.Unlock()
+		return true
+	}
+
+	r.suppressed++
+	l.stats.limited.Add(1)
+	r.lg, r.paint, r.prefix = lg, paint, e.Prefix
+	if r.timer == nil {
+		r.timer = time.AfterFunc(r.start.Add(r.per).Sub(e.Time), func() { l.reportSuppressed(e.Level, r) })
+	}
+	r.mu.Unlock()
+	return false
+}
+
+// reportSuppressed writes the number of entries r has suppressed, if any
+func (l *Log) reportSuppressed(lv Level, r *rateLimiter) {
+	r.mu.Lock()
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+	n := r.suppressed
+	r.suppressed = 0
+	lg, paint, prefix := r.lg, r.paint, r.prefix
+	r.mu.Unlock()
+
+	if n > 0 {
+		msg := fmt.Sprintf("%d entries suppressed by rate limit", n)
+		l.deliver(lg, paint, &Entry{Time: time.Now(), Level: lv, Prefix: prefix, Message: msg})
+	}
+}
@@ -0,0 +1,59 @@
+package MyLog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetRateLimit(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetRateLimit(LevelWarn, 2, time.Hour)
+
+	for i := 0; i < 5; i++ {
+		l.Warn("warn %d", i)
+		l.Error("error %d", i)
+	}
+	if want := "WARN:  warn 0\nWARN:  warn 1\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if n := len(stderr.lines()); n != 5 {
+		t.Errorf("got %d error lines, want 5 without limit", n)
+	}
+
+	l.Flush()
+	if want := "WARN:  warn 0\nWARN:  warn 1\nWARN:  3 entries suppressed by rate limit\n"; stdout.String() != want {
+		t.Errorf("stdout after Flush = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestRateLimitPeriod(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetRateLimit(LevelInfo, 1, 20*time.Millisecond)
+
+	l.StandardInfo("first")
+	l.StandardInfo("suppressed")
+	deadline := time.Now().Add(5 * time.Second)
+	for len(stdout.lines()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	l.StandardInfo("next period")
+
+	want := "INFO:  first\nINFO:  1 entries suppressed by rate limit\nINFO:  next period\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestRemoveRateLimit(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetRateLimit(LevelInfo, 1, time.Hour)
+	l.StandardInfo("first")
+	l.StandardInfo("suppressed")
+	l.SetRateLimit(LevelInfo, 0, 0)
+	l.StandardInfo("unlimited")
+
+	want := "INFO:  first\nINFO:  1 entries suppressed by rate limit\nINFO:  unlimited\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+}
@@ -0,0 +1,86 @@
+package MyLog
+
+import "fmt"
+
+// defaultRecorderSize is used by EnableFlightRecorder for sizes below 1
+const defaultRecorderSize = 100
+
+// EnableFlightRecorder keeps the last n Trace, Debug and Verbose entries
+// even if their level is disabled; with LgBuffer set they are added to
+// the log buffer as well. When an Error, Panic or Fatal entry is written,
+// the recorded entries are emitted first, so that the context leading to
+// the failure is visible without running in debug mode all the time.
+// Entries silenced by SetQuiet or Suppress are not recorded. An n below
+// 1 keeps the last 100 entries.
+func (l *Log) EnableFlightRecorder(n int) {
+	if !l.ready() {
+		return
+	}
+	if n < 1 {
+		n = defaultRecorderSize
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.recorder == nil {
+		l.recorder = &ring{}
+	}
+	l.recorder.resize(n)
+}
+
+// DisableFlightRecorder stops recording and drops all recorded entries
+func (l *Log) DisableFlightRecorder() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.recorder = nil
+}
+
+// record stores an entry below the level threshold in the flight
+// recorder, unless it is silenced by SetQuiet or Suppress
+func (l *Log) record(lv Level, prefix, format string, v ...interface{}) {
+	l.mu.RLock()
+	enabled := l.recordable(lv)
+	l.mu.RUnlock()
+	if !enabled {
+		return
+	}
+
+	ne := l.newEntry(lv, prefix, fmt.Sprintf(format, v...))
+	e := BufferEntry{Time: ne.Time, Level: lv, Prefix: prefix, Msg: stripANSI(ne.text())}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.recorder != nil {
+		l.recorder.add(e)
+	}
+	if l.modeRegister&LgBuffer != 0 {
+		l.bufferData.add(e)
+	}
+}
+
+// recordable reports whether the recorder keeps entries of lv, which
+// are not silenced by SetQuiet or Suppress; l.mu must be held
+func (l *Log) recordable(lv Level) bool {
+	return l.recorder != nil && l.suppressed&(1<<lv) == 0 && !(l.quiet && lv < LevelWarn)
+}
+
+// dumpRecorder writes and clears all recorded entries
+func (l *Log) dumpRecorder() {
+	l.mu.Lock()
+	var entries []BufferEntry
+	if l.recorder != nil {
+		entries = l.recorder.entries()
+		l.recorder.reset()
+	}
+	l.mu.Unlock()
+
+	if len(entries) > 0 {
+		l.syncAsync()
+	}
+	for _, e := range entries {
+		l.writeEntry(e)
+	}
+}
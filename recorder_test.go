@@ -0,0 +1,70 @@
+package MyLog
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFlightRecorder(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetLevel(LevelInfo)
+	l.EnableFlightRecorder(2)
+
+	l.Debug("step 1")
+	l.Trace("step 2")
+	l.Debug("step 3")
+	if stderr.String() != "" {
+		t.Fatalf("recorded entries written early: %q", stderr.String())
+	}
+
+	l.Error("failed")
+	if got, want := stderr.String(), "TRACE: step 2\nDEBUG: step 3\nERROR: failed\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+
+	// the recorder is cleared by the dump
+	l.Error("again")
+	if got, want := stderr.String(), "TRACE: step 2\nDEBUG: step 3\nERROR: failed\nERROR: again\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestFlightRecorderSilenced(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.EnableFlightRecorder(10)
+	l.Suppress(LevelDebug)
+	l.Debug("suppressed")
+	l.Unsuppress(LevelDebug)
+	l.SetQuiet(true)
+	l.Trace("quiet")
+	l.SetQuiet(false)
+
+	l.Error("failed")
+	if got, want := stderr.String(), "ERROR: failed\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestFlightRecorderDefaultSize(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.EnableFlightRecorder(0)
+	for i := 0; i < defaultRecorderSize+10; i++ {
+		l.Debug("step %d", i)
+	}
+	l.Error("failed")
+
+	lines := stderr.lines()
+	if len(lines) != defaultRecorderSize+1 {
+		t.Fatalf("got %d lines, want %d", len(lines), defaultRecorderSize+1)
+	}
+	if want := fmt.Sprintf("DEBUG: step %d", 10); lines[0] != want {
+		t.Errorf("first line = %q, want %q", lines[0], want)
+	}
+
+	l.DisableFlightRecorder()
+	l.Debug("dropped")
+	l.Error("end")
+	if got := stderr.lines(); got[len(got)-1] != "ERROR: end" || len(got) != defaultRecorderSize+2 {
+		t.Errorf("disabled recorder dumped entries: %q", got[len(got)-2:])
+	}
+}
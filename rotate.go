@@ -0,0 +1,287 @@
+package MyLog
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotationConfig controls the rotation of a log file set with SetFile
+type RotationConfig struct {
+	MaxSizeMB  int               // rotate when the file would exceed this size, 0 disables
+	MaxBackups int               // number of rotated files to keep, 0 keeps all
+	MaxAgeDays int               // remove rotated files older than this, 0 keeps all
+	Compress   bool              // gzip rotated files
+	Interval   time.Duration     // rotate after this period, 0 disables
+	Encryption *EncryptionConfig // encrypt the file, nil for plain text
+}
+
+// backupTimeFormat is part of the rotated file names; no colons for windows
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// rotatingFile is an io.WriteCloser that rotates the file it writes to
+type rotatingFile struct {
+	mu     sync.Mutex
+	cmu    sync.Mutex // serializes background cleanups
+	path   string
+	cfg    RotationConfig
+	file   *os.File
+	size   int64
+	opened time.Time
+
+	cipher     *fileCipher // nil for plain text
+	keyWritten bool        // the key record of cipher is in the current file
+}
+
+func openRotatingFile(path string, cfg RotationConfig) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, cfg: cfg}
+	if cfg.Encryption != nil {
+		c, err := newFileCipher(cfg.Encryption)
+		if err != nil {
+			return nil, err
+		}
+		r.cipher = c
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+
+	go r.cleanup()
+
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	r.file = f
+	r.size = fi.Size()
+	r.opened = time.Now()
+	r.keyWritten = false
+
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+
+	if r.needsRotation(len(p)) {
+		// after a failed rename p still goes to the reopened file
+		if err := r.rotate(); err != nil && r.file == nil {
+			return 0, err
+		}
+	}
+
+	if r.cipher != nil {
+		return r.writeSealed(p)
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+
+	return n, err
+}
+
+// writeSealed writes p as an encrypted record
+func (r *rotatingFile) writeSealed(p []byte) (int, error) {
+	rec, err := r.cipher.seal(nil, p, !r.keyWritten)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := r.file.Write(rec)
+	r.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	r.keyWritten = true
+
+	return len(p), nil
+}
+
+func (r *rotatingFile) needsRotation(n int) bool {
+	// an empty file is never rotated
+	if r.size == 0 {
+		return false
+	}
+	maxSize := int64(r.cfg.MaxSizeMB) << 20
+	if maxSize > 0 && r.size+int64(n) > maxSize {
+		return true
+	}
+	return r.cfg.Interval > 0 && time.Since(r.opened) >= r.cfg.Interval
+}
+
+// rotate moves the current file to a timestamped backup and reopens path
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	if err := os.Rename(r.path, r.backupName(time.Now())); err != nil {
+		// keep writing to the current file rather than losing the log
+		if oerr := r.open(); oerr != nil {
+			return errors.Join(err, oerr)
+		}
+		return err
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+
+	go r.cleanup()
+
+	return nil
+}
+
+// backupName returns e.g. app-2006-01-02T15-04-05.000.log for app.log
+func (r *rotatingFile) backupName(t time.Time) string {
+	dir, base := filepath.Split(r.path)
+	ext := filepath.Ext(base)
+	name := strings.TrimSuffix(base, ext)
+
+	return filepath.Join(dir, name+"-"+t.Format(backupTimeFormat)+ext)
+}
+
+type backupFile struct {
+	path string
+	time time.Time
+}
+
+// backups lists the rotated files of r, newest first
+func (r *rotatingFile) backups() ([]backupFile, error) {
+	dir, base := filepath.Split(r.path)
+	if dir == "" {
+		dir = "."
+	}
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []backupFile
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+
+		stamp := strings.TrimPrefix(e.Name(), prefix)
+		stamp = strings.TrimSuffix(strings.TrimSuffix(stamp, ".gz"), ext)
+		t, err := time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+
+		files = append(files, backupFile{path: filepath.Join(dir, e.Name()), time: t})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].time.After(files[j].time) })
+
+	return files, nil
+}
+
+// cleanup removes surplus and expired backups and compresses the rest
+func (r *rotatingFile) cleanup() {
+	r.cmu.Lock()
+	defer r.cmu.Unlock()
+
+	files, err := r.backups()
+	if err != nil {
+		return
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -r.cfg.MaxAgeDays)
+	for i, f := range files {
+		expired := r.cfg.MaxAgeDays > 0 && f.time.Before(cutoff)
+		if (r.cfg.MaxBackups > 0 && i >= r.cfg.MaxBackups) || expired {
+			os.Remove(f.path)
+			continue
+		}
+
+		if r.cfg.Compress && !strings.HasSuffix(f.path, ".gz") {
+			compressFile(f.path)
+		}
+	}
+}
+
+// compressFile replaces path by a gzipped copy path.gz
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if cerr := gz.Close(); err == nil {
+		err = cerr
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	src.Close()
+	return os.Remove(path)
+}
+
+// Sync commits the current file to stable storage
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Close closes the current file; further writes fail
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+
+	return err
+}
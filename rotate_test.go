@@ -0,0 +1,135 @@
+package MyLog
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetFile(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	path := filepath.Join(t.TempDir(), "logs", "app.log")
+	if err := l.SetFile(path, RotationConfig{}); err != nil {
+		t.Fatal(err)
+	}
+
+	l.StandardInfo("to both")
+	if err := l.Close(); err != nil {
+		t.Fatal(err)
+	}
+	l.StandardInfo("stdout only")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "INFO:  to both\n"; got != want {
+		t.Errorf("file = %q, want %q", got, want)
+	}
+	if got, want := stdout.String(), "INFO:  to both\nINFO:  stdout only\n"; got != want {
+		t.Errorf("stdout = %q, want %q", got, want)
+	}
+}
+
+// writeChunks writes n chunks of size bytes to r, waiting between them
+// so that each backup gets its own name
+func writeChunks(t *testing.T, r *rotatingFile, n, size int) {
+	t.Helper()
+	chunk := bytes.Repeat([]byte("x"), size-1)
+	chunk = append(chunk, '\n')
+	for i := 0; i < n; i++ {
+		if _, err := r.Write(chunk); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestRotateBySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := openRotatingFile(path, RotationConfig{MaxSizeMB: 1, MaxBackups: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	writeChunks(t, r, 5, 600<<10)
+	r.cleanup()
+
+	files, err := r.backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 2 {
+		t.Errorf("%d backups, want 2", len(files))
+	}
+	fi, err := os.Stat(path)
+	if err != nil || fi.Size() != 600<<10 {
+		t.Errorf("current file: %v, %v; want a single chunk", fi, err)
+	}
+}
+
+func TestRotateCompress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := openRotatingFile(path, RotationConfig{Interval: time.Nanosecond, Compress: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	writeChunks(t, r, 3, 10)
+	r.cleanup()
+
+	files, _ := r.backups()
+	if len(files) != 2 {
+		t.Fatalf("%d backups, want 2", len(files))
+	}
+	for _, f := range files {
+		if !strings.HasSuffix(f.path, ".log.gz") {
+			t.Errorf("backup %s is not compressed", f.path)
+		}
+	}
+}
+
+func TestRotateRenameFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("open files cannot be removed")
+	}
+	path := filepath.Join(t.TempDir(), "app.log")
+	r, err := openRotatingFile(path, RotationConfig{Interval: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	if _, err := r.Write([]byte("lost\n")); err != nil {
+		t.Fatal(err)
+	}
+	// renaming a file that is gone fails
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Write([]byte("kept\n")); err != nil {
+		t.Fatalf("write after a failed rename: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "kept\n" {
+		t.Errorf("reopened file = %q, %v", data, err)
+	}
+}
+
+func TestRotatingFileClosed(t *testing.T) {
+	r, err := openRotatingFile(filepath.Join(t.TempDir(), "app.log"), RotationConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Close()
+	if _, err := r.Write([]byte("late\n")); err != os.ErrClosed {
+		t.Errorf("write after Close = %v, want os.ErrClosed", err)
+	}
+}
@@ -0,0 +1,74 @@
+package MyLog
+
+import (
+	"sync"
+	"time"
+)
+
+// sampleTick is the period the sampling counters are reset after
+const sampleTick = time.Second
+
+// sampler counts identical messages per tick
+type sampler struct {
+	initial    int
+	thereafter int
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[sampleKey]int
+}
+
+type sampleKey struct {
+	lv  Level
+	msg string
+}
+
+// SetSampling limits messages with the same level and text: of each
+// such message, the first initial per second are written, then every
+// thereafter-th. A thereafter of 0 drops all others, an initial of 0
+// disables sampling. Panic and fatal entries are never sampled.
+func (l *Log) SetSampling(initial, thereafter int) {
+	if !l.ready() {
+		return
+	}
+	var s *sampler
+	if initial > 0 {
+		s = &sampler{initial: initial, thereafter: thereafter, counts: make(map[sampleKey]int)}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sampler = s
+}
+
+// sampled reports whether e passes the sampler, if any
+func (l *Log) sampled(e *Entry) bool {
+	l.mu.RLock()
+	s := l.sampler
+	l.mu.RUnlock()
+
+	if s == nil || e.Level >= LevelPanic || s.allow(e) {
+		return true
+	}
+	l.stats.sampled.Add(1)
+	return false
+}
+
+func (s *sampler) allow(e *Entry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e.Time.Sub(s.start) >= sampleTick {
+		s.start = e.Time
+		clear(s.counts)
+	}
+
+	k := sampleKey{e.Level, e.Message}
+	n := s.counts[k] + 1
+	s.counts[k] = n
+
+	if n <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (n-s.initial)%s.thereafter == 0
+}
@@ -0,0 +1,51 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetSampling(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetSampling(2, 3)
+
+	for i := 0; i < 10; i++ {
+		l.StandardInfo("same")
+		l.Warn("same")
+	}
+	// entries 1, 2, 5 and 8 of each level
+	if n := len(stdout.lines()); n != 8 {
+		t.Errorf("got %d lines, want 8:\n%s", n, stdout.String())
+	}
+
+	l.SetSampling(0, 0)
+	l.StandardInfo("same")
+	if n := len(stdout.lines()); n != 9 {
+		t.Error("SetSampling(0, 0) did not disable sampling")
+	}
+}
+
+func TestSamplerTick(t *testing.T) {
+	s := &sampler{initial: 1, counts: make(map[sampleKey]int)}
+	now := time.Now()
+	e := &Entry{Level: LevelInfo, Message: "m", Time: now}
+
+	if !s.allow(e) || s.allow(e) {
+		t.Fatal("want only the first entry within a tick")
+	}
+	e.Time = now.Add(sampleTick)
+	if !s.allow(e) {
+		t.Error("counts not reset after a tick")
+	}
+}
+
+func TestSamplingSkipsPanics(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetSampling(1, 0)
+	l.Panic("p")
+	l.Panic("p")
+	if n := strings.Count(stderr.String(), "PANIC: p\n"); n != 2 {
+		t.Errorf("got %d panic entries, want 2", n)
+	}
+}
@@ -0,0 +1,113 @@
+package MyLog
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultWidth is the line width if no terminal width is known
+const defaultWidth = 80
+
+// SetRuleChars sets the characters repeated to draw the rules of
+// Section and Banner. Empty strings restore the defaults, "─" and "═"
+// or "-" and "=" without Unicode support.
+func (l *Log) SetRuleChars(section, banner string) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sectionRule, l.bannerRule = section, banner
+}
+
+// SetRuleWidth sets the width of rules including the prefix and
+// timestamp. 0 selects the terminal width, or 80 columns.
+func (l *Log) SetRuleWidth(n int) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ruleWidth = n
+}
+
+// Section writes an empty line and title within a rule, like
+// "── Phase 2: migration ─────", as standard messages
+func (l *Log) Section(title string) {
+	if !l.ready() || !l.Enabled(LevelInfo) {
+		return
+	}
+
+	rule, _ := l.rules()
+	head := rule + rule + " " + title + " "
+	l.log("%s", "")
+	l.output(l.stdVar, LevelInfo, "", l.headerPainter(), "%s", head+repeatTo(rule, l.textWidth()-utf8.RuneCountInString(head)))
+}
+
+// Banner writes title centered between two rules as standard messages
+func (l *Log) Banner(title string) {
+	if !l.ready() || !l.Enabled(LevelInfo) {
+		return
+	}
+
+	_, rule := l.rules()
+	width := l.textWidth()
+	line := repeatTo(rule, width)
+	pad := max((width-utf8.RuneCountInString(title))/2, 0)
+
+	paint := l.headerPainter()
+	l.output(l.stdVar, LevelInfo, "", paint, "%s", line)
+	l.output(l.stdVar, LevelInfo, "", paint, "%s", strings.Repeat(" ", pad)+title)
+	l.output(l.stdVar, LevelInfo, "", paint, "%s", line)
+}
+
+// rules returns the rule characters of Section and Banner
+func (l *Log) rules() (section, banner string) {
+	l.mu.RLock()
+	section, banner = l.sectionRule, l.bannerRule
+	l.mu.RUnlock()
+
+	if section == "" {
+		section = sectionGlyph.String()
+	}
+	if banner == "" {
+		banner = bannerGlyph.String()
+	}
+	return section, banner
+}
+
+// headerPainter colors headers in color mode
+func (l *Log) headerPainter() paintFunc {
+	if l.modeHas(LgColor) {
+		return l.boldPainter(LevelInfo)
+	}
+	return plain
+}
+
+// textWidth returns the number of columns left for the text of a
+// standard message, after its prefix and timestamp
+func (l *Log) textWidth() int {
+	l.mu.RLock()
+	width, w := l.ruleWidth, l.stdOut
+	l.mu.RUnlock()
+
+	if width <= 0 {
+		width = defaultWidth
+		if cols, _, ok := terminalSize(w); ok {
+			width = cols
+		}
+	}
+
+	head := appendLine(nil, l.stdVar.Flags(), l.stdVar.Prefix(), l.timeFormat(nil), time.Now())
+	return max(width-utf8.RuneCountInString(stripANSI(string(head)))+1, 10)
+}
+
+// repeatTo repeats s to a width of n characters
+func repeatTo(s string, n int) string {
+	if n <= 0 || s == "" {
+		return ""
+	}
+	r := []rune(strings.Repeat(s, n/utf8.RuneCountInString(s)+1))
+	return string(r[:n])
+}
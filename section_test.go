@@ -0,0 +1,66 @@
+package MyLog
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestSection(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetRuleWidth(30)
+	l.SetRuleChars("-", "=")
+
+	l.Section("Phase 2")
+	l.Banner("Done")
+
+	want := []string{
+		"       ",
+		"       -- Phase 2 ------------",
+		"       =======================",
+		"                Done",
+		"       =======================",
+	}
+	if got := stdout.lines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("stdout =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSectionDefaults(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unicode support is decided by WT_SESSION")
+	}
+	setLocale(t, "en_US.UTF-8")
+	l, stdout, _ := newTestLog()
+
+	l.Section("x")
+	got := stdout.lines()[1]
+	if !strings.HasPrefix(got, "       ── x ──") || len([]rune(got)) != defaultWidth {
+		t.Errorf("section = %q (%d columns)", got, len([]rune(got)))
+	}
+
+	l.SetLevel(LevelWarn)
+	l.Banner("hidden")
+	if len(stdout.lines()) != 2 {
+		t.Errorf("banner shown at the warn level: %q", stdout.String())
+	}
+}
+
+func TestRepeatTo(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"-", 3, "---"},
+		{"-=", 5, "-=-=-"},
+		{"─", 2, "──"},
+		{"-", 0, ""},
+		{"", 5, ""},
+	}
+	for _, tt := range tests {
+		if got := repeatTo(tt.s, tt.n); got != tt.want {
+			t.Errorf("repeatTo(%q, %d) = %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
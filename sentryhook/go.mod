@@ -0,0 +1,18 @@
+module github.com/hleinders/MyLog/sentryhook
+
+go 1.21
+
+require (
+	github.com/getsentry/sentry-go v0.31.1
+	github.com/hleinders/MyLog v0.0.0
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/mattn/go-colorable v0.1.9 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+)
+
+replace github.com/hleinders/MyLog => ../
@@ -0,0 +1,133 @@
+// Package sentryhook reports MyLog error and panic entries to Sentry.
+package sentryhook
+
+import (
+	"strings"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// modulePath is used to drop MyLog's own frames from stack traces
+const modulePath = "github.com/hleinders/MyLog"
+
+// Options configures the Sentry client
+type Options struct {
+	DSN          string
+	Environment  string
+	Release      string
+	Breadcrumbs  int           // buffer entries sent as breadcrumbs, 0 for all
+	FlushTimeout time.Duration // wait for delivery of panic entries, default 2s
+}
+
+// New returns a hook reporting entries to the Sentry project given by
+// opts.DSN. If l has the buffer enabled, its entries are attached as
+// breadcrumbs. The hook does not filter levels, see Attach.
+func New(l *MyLog.Log, opts Options) (MyLog.Hook, error) {
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         opts.DSN,
+		Environment: opts.Environment,
+		Release:     opts.Release,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.FlushTimeout <= 0 {
+		opts.FlushTimeout = 2 * time.Second
+	}
+
+	hub := sentry.NewHub(client, sentry.NewScope())
+	return func(e MyLog.Entry) error {
+		hub.CaptureEvent(event(l, e, opts.Breadcrumbs))
+		if e.Level >= MyLog.LevelPanic {
+			hub.Flush(opts.FlushTimeout)
+		}
+		return nil
+	}, nil
+}
+
+// Attach creates a hook with New and adds it to l for Error and Panic
+// entries
+func Attach(l *MyLog.Log, opts Options) error {
+	h, err := New(l, opts)
+	if err != nil {
+		return err
+	}
+	l.AddHook(h, MyLog.LevelError, MyLog.LevelPanic)
+	return nil
+}
+
+// event converts e into a Sentry event
+func event(l *MyLog.Log, e MyLog.Entry, breadcrumbs int) *sentry.Event {
+	ev := sentry.NewEvent()
+	ev.Level = level(e.Level)
+	ev.Message = e.Message
+	ev.Timestamp = e.Time
+	ev.Logger = e.Name
+
+	for k, v := range e.Fields {
+		ev.Extra[k] = v
+	}
+	if e.Caller != "" {
+		ev.Tags["caller"] = e.Caller
+	}
+	if e.Stack != "" {
+		ev.Extra["stack"] = e.Stack
+	}
+
+	ev.Threads = []sentry.Thread{{
+		Stacktrace: stacktrace(),
+		Current:    true,
+		Crashed:    e.Level >= MyLog.LevelPanic,
+	}}
+
+	entries := l.GetBufferEntries()
+	if breadcrumbs > 0 && len(entries) > breadcrumbs {
+		entries = entries[len(entries)-breadcrumbs:]
+	}
+	for _, b := range entries {
+		ev.Breadcrumbs = append(ev.Breadcrumbs, &sentry.Breadcrumb{
+			Type:      "default",
+			Category:  "log",
+			Level:     level(b.Level),
+			Message:   b.Msg,
+			Timestamp: b.Time,
+		})
+	}
+	return ev
+}
+
+// stacktrace returns the current stack without MyLog's frames
+func stacktrace() *sentry.Stacktrace {
+	st := sentry.NewStacktrace()
+	if st == nil {
+		return nil
+	}
+
+	frames := st.Frames[:0]
+	for _, f := range st.Frames {
+		if f.Module == modulePath || strings.HasPrefix(f.Module, modulePath+"/") {
+			continue
+		}
+		frames = append(frames, f)
+	}
+	st.Frames = frames
+	return st
+}
+
+// level maps a MyLog level onto a Sentry level
+func level(lv MyLog.Level) sentry.Level {
+	switch {
+	case lv >= MyLog.LevelFatal:
+		return sentry.LevelFatal
+	case lv >= MyLog.LevelError:
+		return sentry.LevelError
+	case lv == MyLog.LevelWarn:
+		return sentry.LevelWarning
+	case lv == MyLog.LevelInfo:
+		return sentry.LevelInfo
+	}
+	return sentry.LevelDebug
+}
@@ -0,0 +1,68 @@
+package sentryhook
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestEvent(t *testing.T) {
+	l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard), MyLog.WithBuffer(0))
+	l.StandardInfo("one")
+	l.Warn("two")
+	l.Debug("not buffered")
+
+	e := MyLog.Entry{
+		Time:    time.Now(),
+		Level:   MyLog.LevelError,
+		Name:    "db",
+		Message: "query failed",
+		Fields:  MyLog.Fields{"table": "users"},
+		Caller:  "db.go:12 db.Query",
+	}
+	ev := event(l, e, 1)
+
+	if ev.Level != sentry.LevelError || ev.Message != "query failed" || ev.Logger != "db" {
+		t.Errorf("event = %+v", ev)
+	}
+	if ev.Extra["table"] != "users" || ev.Tags["caller"] != "db.go:12 db.Query" {
+		t.Errorf("extra = %v, tags = %v", ev.Extra, ev.Tags)
+	}
+	if len(ev.Breadcrumbs) != 1 || ev.Breadcrumbs[0].Message != "two" || ev.Breadcrumbs[0].Level != sentry.LevelWarning {
+		t.Errorf("breadcrumbs = %+v", ev.Breadcrumbs)
+	}
+	if len(ev.Threads) != 1 || ev.Threads[0].Crashed {
+		t.Errorf("threads = %+v", ev.Threads)
+	}
+	for _, f := range ev.Threads[0].Stacktrace.Frames {
+		if strings.HasPrefix(f.Module, modulePath) {
+			t.Errorf("stack trace has MyLog frame %s.%s", f.Module, f.Function)
+		}
+	}
+}
+
+func TestLevel(t *testing.T) {
+	tests := map[MyLog.Level]sentry.Level{
+		MyLog.LevelTrace: sentry.LevelDebug,
+		MyLog.LevelInfo:  sentry.LevelInfo,
+		MyLog.LevelWarn:  sentry.LevelWarning,
+		MyLog.LevelError: sentry.LevelError,
+		MyLog.LevelPanic: sentry.LevelError,
+		MyLog.LevelFatal: sentry.LevelFatal,
+	}
+	for lv, want := range tests {
+		if got := level(lv); got != want {
+			t.Errorf("level(%v) = %v, want %v", lv, got, want)
+		}
+	}
+}
+
+func TestNewInvalidDSN(t *testing.T) {
+	if _, err := New(MyLog.New(), Options{DSN: "not a dsn"}); err == nil {
+		t.Error("New accepted an invalid DSN")
+	}
+}
@@ -0,0 +1,117 @@
+package MyLog
+
+import (
+	"bytes"
+	"os"
+	"os/signal"
+)
+
+// signalHandler toggles debug mode and dumps the buffer on signals
+type signalHandler struct {
+	ch   chan os.Signal
+	done chan struct{}
+}
+
+// HandleSignals flips LgDebug whenever sigDebugToggle is received and
+// dumps the buffer on sigBufferDump, e.g. syscall.SIGUSR1 and SIGUSR2.
+// The buffer goes to os.Stderr unless a file is set with
+// SetSignalDumpFile. Either signal may be nil. StopSignals or Close
+// end the handling.
+func (l *Log) HandleSignals(sigDebugToggle, sigBufferDump os.Signal) {
+	if !l.ready() {
+		return
+	}
+	l.StopSignals()
+
+	var sigs []os.Signal
+	for _, s := range []os.Signal{sigDebugToggle, sigBufferDump} {
+		if s != nil {
+			sigs = append(sigs, s)
+		}
+	}
+	if len(sigs) == 0 {
+		return
+	}
+
+	h := &signalHandler{ch: make(chan os.Signal, 1), done: make(chan struct{})}
+	signal.Notify(h.ch, sigs...)
+	go func() {
+		defer close(h.done)
+		for s := range h.ch {
+			switch s {
+			case sigDebugToggle:
+				l.ToggleMode(LgDebug)
+				l.Warn("debug mode %s by signal %v", onOff(l.HasMode(LgDebug)), s)
+			case sigBufferDump:
+				l.dumpOnSignal()
+			}
+		}
+	}()
+
+	l.mu.Lock()
+	l.signals = h
+	l.mu.Unlock()
+}
+
+// StopSignals ends the handling started by HandleSignals
+func (l *Log) StopSignals() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	h := l.signals
+	l.signals = nil
+	l.mu.Unlock()
+
+	if h != nil {
+		signal.Stop(h.ch)
+		close(h.ch)
+		<-h.done
+	}
+}
+
+// SetSignalDumpFile makes the buffer dump of HandleSignals append to
+// the file at path instead of os.Stderr. An empty path restores os.Stderr.
+func (l *Log) SetSignalDumpFile(path string) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.signalDumpPath = path
+}
+
+func (l *Log) dumpOnSignal() {
+	l.mu.RLock()
+	path := l.signalDumpPath
+	l.mu.RUnlock()
+
+	// render first, so that a blocked writer only holds up the dump
+	var b bytes.Buffer
+	if path == "" {
+		l.DumpBufferTo(&b)
+		if _, err := os.Stderr.Write(b.Bytes()); err != nil {
+			l.Error("dumping buffer: %v", err)
+		}
+		return
+	}
+
+	l.DumpBufferTo(ansiStripper{&b})
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err == nil {
+		_, err = f.Write(b.Bytes())
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}
+	if err != nil {
+		l.Error("dumping buffer: %v", err)
+	}
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,36 @@
+//go:build !windows && !plan9
+
+package MyLog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestHandleSignals(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.EnableBuffer()
+	dump := filepath.Join(t.TempDir(), "dump.log")
+	l.SetSignalDumpFile(dump)
+	l.HandleSignals(syscall.SIGUSR1, syscall.SIGUSR2)
+	defer l.StopSignals()
+
+	syscall.Kill(os.Getpid(), syscall.SIGUSR1)
+	if !eventually(func() bool { return l.HasMode(LgDebug) }) {
+		t.Fatal("debug mode not toggled")
+	}
+	if !eventually(func() bool { return strings.Contains(stdout.String(), "WARN:  debug mode on by signal") }) {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	syscall.Kill(os.Getpid(), syscall.SIGUSR2)
+	if !eventually(func() bool {
+		b, _ := os.ReadFile(dump)
+		return strings.Contains(string(b), "debug mode on")
+	}) {
+		t.Error("buffer not dumped")
+	}
+}
@@ -0,0 +1,330 @@
+package MyLog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Sink is a destination receiving entries, usually a remote service.
+// WriteEntries is not called concurrently by the sinks of this package.
+type Sink interface {
+	WriteEntries(entries []Entry) error
+	Close() error
+}
+
+// AddSink writes every entry of the given levels, or of all levels if
+// none are given, to s. Write failures are handled like hook errors,
+// see SetHookErrorPolicy. s is closed by Close.
+func (l *Log) AddSink(s Sink, levels ...Level) {
+	if !l.ready() {
+		return
+	}
+	a := &addedSink{s: s}
+	l.AddHook(a.write, levels...)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sinks = append(l.sinks, a)
+}
+
+// addedSink is a sink added with AddSink. Once closed, its hook drops
+// the entries.
+type addedSink struct {
+	mu     sync.Mutex
+	s      Sink
+	closed bool
+}
+
+func (a *addedSink) write(e Entry) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return nil
+	}
+	return a.s.WriteEntries([]Entry{e})
+}
+
+func (a *addedSink) close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.closed = true
+	return a.s.Close()
+}
+
+// closeSinks closes and removes all sinks added with AddSink
+func (l *Log) closeSinks() error {
+	l.mu.Lock()
+	sinks := l.sinks
+	l.sinks = nil
+	l.mu.Unlock()
+
+	var errs []error
+	for _, a := range sinks {
+		errs = append(errs, a.close())
+	}
+	return errors.Join(errs...)
+}
+
+// flushSinks flushes all sinks added with AddSink that have a Flush
+// method, like BatchSink
+func (l *Log) flushSinks() {
+	l.mu.RLock()
+	sinks := l.sinks
+	l.mu.RUnlock()
+
+	for _, a := range sinks {
+		if f, ok := a.s.(interface{ Flush() }); ok {
+			f.Flush()
+		}
+	}
+}
+
+// HookSink returns a Sink calling h for each entry, e.g. to wrap the
+// Hook method of a network writer
+func HookSink(h Hook) Sink {
+	return hookSink(h)
+}
+
+type hookSink Hook
+
+func (h hookSink) WriteEntries(entries []Entry) error {
+	var errs []error
+	for _, e := range entries {
+		if err := h(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h hookSink) Close() error {
+	return nil
+}
+
+// WriterSink returns a Sink writing entries as JSON lines to w, e.g. a
+// local fallback file. Close closes w if it is an io.Closer.
+func WriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *writerSink) WriteEntries(entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range entries {
+		if err := writeFormatted(s.w, JSONFormatter{}, &entries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *writerSink) Close() error {
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+var errCircuitOpen = errors.New("circuit open")
+
+// Permanent marks err as a failure retrying cannot fix, like a rejected
+// request. ResilientSink passes the entries on to the fallback at once,
+// and the failure does not count towards opening the circuit.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return permanentError{err}
+}
+
+type permanentError struct{ err error }
+
+func (p permanentError) Error() string { return p.err.Error() }
+func (p permanentError) Unwrap() error { return p.err }
+
+func isPermanent(err error) bool {
+	var p permanentError
+	var s *SkippedError
+	return errors.As(err, &p) || errors.As(err, &s)
+}
+
+// Skipped reports that the entries at the given indexes of a batch were
+// skipped, like messages too large to send, while all others were
+// written. ResilientSink passes only the skipped entries on to the
+// fallback and treats the failure as permanent. err describes why they
+// were skipped; Skipped returns nil if no indexes are given.
+func Skipped(err error, indexes []int) error {
+	if len(indexes) == 0 {
+		return nil
+	}
+	return &SkippedError{Indexes: indexes, Err: err}
+}
+
+// SkippedError is the error returned by Skipped
+type SkippedError struct {
+	Indexes []int // of the skipped entries in the batch
+	Err     error
+}
+
+func (s *SkippedError) Error() string {
+	msg := fmt.Sprintf("%d entries skipped", len(s.Indexes))
+	if s.Err != nil {
+		msg += ": " + s.Err.Error()
+	}
+	return msg
+}
+
+func (s *SkippedError) Unwrap() error { return s.Err }
+
+// unwritten returns the entries err reports as not written: the skipped
+// ones for a SkippedError, otherwise all
+func unwritten(entries []Entry, err error) []Entry {
+	var s *SkippedError
+	if !errors.As(err, &s) {
+		return entries
+	}
+	var skipped []Entry
+	for _, i := range s.Indexes {
+		if i >= 0 && i < len(entries) {
+			skipped = append(skipped, entries[i])
+		}
+	}
+	return skipped
+}
+
+// ResilienceOptions configures a ResilientSink
+type ResilienceOptions struct {
+	Retries    int           // retries of a failed write, default 3, < 0 for none
+	MinBackoff time.Duration // first retry delay, default 100ms
+	MaxBackoff time.Duration // maximum retry delay, default 2s
+	Threshold  int           // consecutive failed writes opening the circuit, default 5
+	Cooldown   time.Duration // time the circuit stays open, default 30s
+	Fallback   Sink          // receives the entries that could not be written
+}
+
+// ResilientSink wraps a Sink with retries, exponential backoff and a
+// circuit breaker. After Threshold failed writes the circuit opens and
+// entries go straight to the fallback, without waiting for the sink,
+// until Cooldown has passed; a single trial write then decides whether
+// it closes again. Entries are only lost if there is no fallback or the
+// fallback fails, which is reported by WriteEntries and Lost.
+//
+// Retries make WriteEntries wait while the sink is failing. Callers
+// that must not wait should use it with EnableAsync or Batch.
+type ResilientSink struct {
+	s    Sink
+	opts ResilienceOptions
+
+	mu        sync.Mutex
+	failures  int       // consecutive failed writes
+	openUntil time.Time // circuit is open before this time
+	lost      uint64
+}
+
+// NewResilientSink returns s wrapped with retries and a circuit breaker
+func NewResilientSink(s Sink, opts ResilienceOptions) *ResilientSink {
+	if opts.Retries < 0 {
+		opts.Retries = 0
+	} else if opts.Retries == 0 {
+		opts.Retries = 3
+	}
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = 100 * time.Millisecond
+	}
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(2*time.Second, opts.MinBackoff)
+	}
+	if opts.Threshold <= 0 {
+		opts.Threshold = 5
+	}
+	if opts.Cooldown <= 0 {
+		opts.Cooldown = 30 * time.Second
+	}
+	return &ResilientSink{s: s, opts: opts}
+}
+
+// WriteEntries writes entries to the sink, or to the fallback if the
+// circuit is open or all retries failed. Of a batch the sink reports as
+// partly written with Skipped, only the skipped entries go to the
+// fallback.
+func (r *ResilientSink) WriteEntries(entries []Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	if time.Now().Before(r.openUntil) {
+		err = errCircuitOpen
+	} else {
+		// a half-open circuit gets a single trial
+		retries := r.opts.Retries
+		if r.failures >= r.opts.Threshold {
+			retries = 0
+		}
+		if err = r.write(entries, retries); err == nil {
+			r.failures = 0
+			return nil
+		}
+		// a permanent failure means the sink answered, the circuit
+		// stays as it is
+		if !isPermanent(err) {
+			if r.failures++; r.failures >= r.opts.Threshold {
+				r.openUntil = time.Now().Add(r.opts.Cooldown)
+			}
+		}
+	}
+
+	entries = unwritten(entries, err)
+	if r.opts.Fallback != nil {
+		ferr := r.opts.Fallback.WriteEntries(entries)
+		if ferr == nil {
+			return nil
+		}
+		err = errors.Join(err, ferr)
+	}
+	r.lost += uint64(len(entries))
+	return fmt.Errorf("sink: %d entries lost: %w", len(entries), err)
+}
+
+func (r *ResilientSink) write(entries []Entry, retries int) error {
+	backoff := r.opts.MinBackoff
+	for try := 0; ; try++ {
+		err := r.s.WriteEntries(entries)
+		if err == nil || try >= retries || isPermanent(err) {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff = min(2*backoff, r.opts.MaxBackoff)
+	}
+}
+
+// CircuitOpen reports whether entries currently go to the fallback
+func (r *ResilientSink) CircuitOpen() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return time.Now().Before(r.openUntil)
+}
+
+// Lost returns the number of entries neither the sink nor the fallback
+// could write
+func (r *ResilientSink) Lost() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.lost
+}
+
+// Close closes the sink and the fallback
+func (r *ResilientSink) Close() error {
+	err := r.s.Close()
+	if r.opts.Fallback != nil {
+		err = errors.Join(err, r.opts.Fallback.Close())
+	}
+	return err
+}
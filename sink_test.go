@@ -0,0 +1,192 @@
+package MyLog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failSink fails its first fails writes with err
+type failSink struct {
+	recordSink
+	fails int
+	err   error
+	calls int
+}
+
+func (s *failSink) WriteEntries(entries []Entry) error {
+	s.calls++
+	if s.calls <= s.fails {
+		return s.err
+	}
+	return s.recordSink.WriteEntries(entries)
+}
+
+var errDown = errors.New("down")
+
+func fastRetries(fallback Sink) ResilienceOptions {
+	return ResilienceOptions{
+		MinBackoff: time.Microsecond,
+		MaxBackoff: time.Microsecond,
+		Threshold:  2,
+		Cooldown:   time.Hour,
+		Fallback:   fallback,
+	}
+}
+
+func TestAddSink(t *testing.T) {
+	l, _, _ := newTestLog()
+	s := new(recordSink)
+	l.AddSink(s, LevelError)
+
+	l.StandardInfo("skipped")
+	l.Error("sent")
+	if len(s.entries) != 1 || s.entries[0].Message != "sent" {
+		t.Errorf("sink got %+v, want the error only", s.entries)
+	}
+}
+
+func TestResilientSinkRetries(t *testing.T) {
+	s := &failSink{fails: 2, err: errDown}
+	r := NewResilientSink(s, fastRetries(nil))
+
+	if err := r.WriteEntries([]Entry{{Message: "x"}}); err != nil {
+		t.Fatalf("WriteEntries() = %v, want success on the third try", err)
+	}
+	if s.calls != 3 || len(s.entries) != 1 || r.CircuitOpen() {
+		t.Errorf("%d calls, %d entries, circuit open %v", s.calls, len(s.entries), r.CircuitOpen())
+	}
+}
+
+func TestResilientSinkPermanent(t *testing.T) {
+	s := &failSink{fails: 10, err: Permanent(errDown)}
+	fb := new(recordSink)
+	r := NewResilientSink(s, fastRetries(fb))
+
+	for i := 0; i < 3; i++ {
+		if err := r.WriteEntries([]Entry{{Message: "x"}}); err != nil {
+			t.Fatalf("WriteEntries() = %v, want the fallback to take it", err)
+		}
+	}
+	if s.calls != 3 || len(fb.entries) != 3 {
+		t.Errorf("%d calls, %d fallback entries, want no retries", s.calls, len(fb.entries))
+	}
+	if r.CircuitOpen() {
+		t.Error("permanent failures opened the circuit")
+	}
+	if Permanent(nil) != nil {
+		t.Error("Permanent(nil) != nil")
+	}
+}
+
+// skipSink writes all entries but reports the second as skipped
+type skipSink struct {
+	recordSink
+	calls int
+}
+
+func (s *skipSink) WriteEntries(entries []Entry) error {
+	s.calls++
+	s.recordSink.WriteEntries(append(entries[:1:1], entries[2:]...))
+	return Skipped(errDown, []int{1})
+}
+
+func TestResilientSinkSkipped(t *testing.T) {
+	s := new(skipSink)
+	fb := new(recordSink)
+	r := NewResilientSink(s, fastRetries(fb))
+
+	err := r.WriteEntries([]Entry{{Message: "a"}, {Message: "b"}, {Message: "c"}})
+	if err != nil {
+		t.Fatalf("WriteEntries() = %v, want the fallback to take the skipped entry", err)
+	}
+	if s.calls != 1 || len(s.entries) != 2 {
+		t.Errorf("%d calls, %d entries written, want no retries", s.calls, len(s.entries))
+	}
+	if len(fb.entries) != 1 || fb.entries[0].Message != "b" {
+		t.Errorf("fallback got %+v, want the skipped entry only", fb.entries)
+	}
+	if r.CircuitOpen() || r.Lost() != 0 {
+		t.Errorf("circuit open %v, %d lost", r.CircuitOpen(), r.Lost())
+	}
+
+	if Skipped(errDown, nil) != nil {
+		t.Error("Skipped without indexes != nil")
+	}
+	if err := Skipped(errDown, []int{0, 2}); !errors.Is(err, errDown) || err.Error() != "2 entries skipped: down" {
+		t.Errorf("Skipped() = %v", err)
+	}
+}
+
+func TestResilientSinkCircuit(t *testing.T) {
+	s := &failSink{fails: 100, err: errDown}
+	fb := new(recordSink)
+	opts := fastRetries(fb)
+	opts.Retries = -1
+	r := NewResilientSink(s, opts)
+
+	for i := 0; i < 2; i++ {
+		r.WriteEntries([]Entry{{Message: "x"}})
+	}
+	if !r.CircuitOpen() {
+		t.Fatal("circuit closed after reaching the threshold")
+	}
+	r.WriteEntries([]Entry{{Message: "x"}})
+	if s.calls != 2 || len(fb.entries) != 3 {
+		t.Errorf("%d calls, %d fallback entries, want the open circuit to skip the sink", s.calls, len(fb.entries))
+	}
+
+	// after the cooldown a successful trial closes the circuit
+	r.openUntil = time.Now()
+	s.fails = 0
+	if err := r.WriteEntries([]Entry{{Message: "y"}}); err != nil || r.CircuitOpen() {
+		t.Errorf("trial write = %v, circuit open %v", err, r.CircuitOpen())
+	}
+	if len(s.entries) != 1 {
+		t.Errorf("sink got %d entries after the trial, want 1", len(s.entries))
+	}
+}
+
+func TestResilientSinkLost(t *testing.T) {
+	r := NewResilientSink(&failSink{fails: 100, err: errDown}, fastRetries(nil))
+
+	err := r.WriteEntries([]Entry{{Message: "a"}, {Message: "b"}})
+	if err == nil || !errors.Is(err, errDown) || !strings.Contains(err.Error(), "2 entries lost") {
+		t.Errorf("WriteEntries() = %v", err)
+	}
+	if r.Lost() != 2 {
+		t.Errorf("Lost() = %d, want 2", r.Lost())
+	}
+}
+
+func TestResilientSinkClose(t *testing.T) {
+	s, fb := new(recordSink), &recordSink{err: errDown}
+	r := NewResilientSink(s, ResilienceOptions{Fallback: fb})
+	if err := r.Close(); !errors.Is(err, errDown) || !s.closed || !fb.closed {
+		t.Errorf("Close() = %v, sink closed %v, fallback closed %v", err, s.closed, fb.closed)
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var b syncBuffer
+	s := WriterSink(&b)
+	if err := s.WriteEntries([]Entry{{Level: LevelWarn, Prefix: "WARN", Message: "disk"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := b.String(); !strings.Contains(got, `"disk"`) || !strings.HasSuffix(got, "}\n") {
+		t.Errorf("output = %q, want a JSON line", got)
+	}
+}
+
+func TestHookSink(t *testing.T) {
+	var got []string
+	s := HookSink(func(e Entry) error {
+		got = append(got, e.Message)
+		return errDown
+	})
+	err := s.WriteEntries([]Entry{{Message: "a"}, {Message: "b"}})
+	if len(got) != 2 || !errors.Is(err, errDown) {
+		t.Errorf("hook saw %v, error %v", got, err)
+	}
+}
@@ -0,0 +1,108 @@
+package MyLog
+
+import (
+	"context"
+	"log/slog"
+)
+
+// slogHandler adapts a Log to the slog.Handler interface
+type slogHandler struct {
+	l     *Log
+	group string // key prefix of the currently open groups
+}
+
+// SlogHandler returns a slog.Handler writing through l, so that
+// slog.New(l.SlogHandler()) logs with the prefixes, colors and
+// buffer settings of l. Attributes are logged as fields.
+func (l *Log) SlogHandler() slog.Handler {
+	if !l.ready() {
+		return &slogHandler{}
+	}
+	return &slogHandler{l: l}
+}
+
+// fromSlogLevel maps slog levels onto MyLog levels
+func fromSlogLevel(lv slog.Level) Level {
+	switch {
+	case lv < slog.LevelDebug:
+		return LevelTrace
+	case lv < slog.LevelInfo:
+		return LevelDebug
+	case lv < slog.LevelWarn:
+		return LevelInfo
+	case lv < slog.LevelError:
+		return LevelWarn
+	}
+	return LevelError
+}
+
+func (h *slogHandler) Enabled(_ context.Context, lv slog.Level) bool {
+	return h.l.Enabled(fromSlogLevel(lv))
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	lg := h.l
+	if r.NumAttrs() > 0 {
+		f := make(Fields, r.NumAttrs())
+		r.Attrs(func(a slog.Attr) bool {
+			addSlogAttr(f, h.group, a)
+			return true
+		})
+		lg = lg.WithFields(f)
+	}
+
+	switch fromSlogLevel(r.Level) {
+	case LevelTrace:
+		lg.Trace("%s", r.Message)
+	case LevelDebug:
+		lg.Debug("%s", r.Message)
+	case LevelInfo:
+		lg.StandardInfo("%s", r.Message)
+	case LevelWarn:
+		lg.Warn("%s", r.Message)
+	default:
+		lg.Error("%s", r.Message)
+	}
+
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+
+	f := make(Fields, len(attrs))
+	for _, a := range attrs {
+		addSlogAttr(f, h.group, a)
+	}
+
+	return &slogHandler{l: h.l.WithFields(f), group: h.group}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{l: h.l, group: h.group + name + "."}
+}
+
+// addSlogAttr stores a into f, flattening groups into dotted keys
+func addSlogAttr(f Fields, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			addSlogAttr(f, prefix, ga)
+		}
+		return
+	}
+
+	f[prefix+a.Key] = a.Value.Any()
+}
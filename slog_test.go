@@ -0,0 +1,39 @@
+package MyLog
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	sl := slog.New(l.SlogHandler())
+
+	sl.Info("started", "port", 8080)
+	sl.With("req", 7).WithGroup("db").Warn("slow", slog.Group("q", "ms", 250), "table", "users")
+	sl.Error("failed", slog.Group("", "inline", true))
+	sl.Debug("hidden")
+
+	wantOut := "INFO:  started port=8080\nWARN:  slow db.q.ms=250 db.table=users req=7\n"
+	if got := stdout.String(); got != wantOut {
+		t.Errorf("stdout = %q, want %q", got, wantOut)
+	}
+	if got, want := stderr.String(), "ERROR: failed inline=true\n"; got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
+
+func TestSlogHandlerEnabled(t *testing.T) {
+	l, _, _ := newTestLog()
+	h := l.SlogHandler()
+	ctx := context.Background()
+
+	if h.Enabled(ctx, slog.LevelDebug) || !h.Enabled(ctx, slog.LevelInfo) {
+		t.Error("slog levels not mapped onto the threshold")
+	}
+	l.SetLevel(LevelTrace)
+	if !h.Enabled(ctx, slog.LevelDebug-4) {
+		t.Error("levels below debug should map to trace")
+	}
+}
@@ -0,0 +1,164 @@
+// Package sqllog wraps a database/sql DB to log queries through a
+// MyLog.Log. Failed queries are logged as errors and slow queries as
+// warnings, with the query and duration as fields.
+package sqllog
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Options configures the logging of queries
+type Options struct {
+	// SlowThreshold makes slower queries warnings, default 200ms,
+	// negative to disable
+	SlowThreshold time.Duration
+
+	// All logs every query as a debug entry
+	All bool
+
+	// Args adds the query arguments as field
+	Args bool
+}
+
+// DB wraps a sql.DB, logging Exec, Query and QueryRow and those of
+// its transactions. All other methods are those of sql.DB.
+type DB struct {
+	*sql.DB
+	q queryLog
+}
+
+// Open opens a database like sql.Open and wraps it
+func Open(driverName, dsn string, l *MyLog.Log, opts Options) (*DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return Wrap(db, l, opts), nil
+}
+
+// Wrap returns db logging to l
+func Wrap(db *sql.DB, l *MyLog.Log, opts Options) *DB {
+	if opts.SlowThreshold == 0 {
+		opts.SlowThreshold = 200 * time.Millisecond
+	}
+	return &DB{DB: db, q: queryLog{l, opts}}
+}
+
+func (db *DB) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return db.ExecContext(context.Background(), query, args...)
+}
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := db.DB.ExecContext(ctx, query, args...)
+	db.q.log(query, args, start, err)
+	return res, err
+}
+
+func (db *DB) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return db.QueryContext(context.Background(), query, args...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := db.DB.QueryContext(ctx, query, args...)
+	db.q.log(query, args, start, err)
+	return rows, err
+}
+
+func (db *DB) QueryRow(query string, args ...interface{}) *sql.Row {
+	return db.QueryRowContext(context.Background(), query, args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := db.DB.QueryRowContext(ctx, query, args...)
+	db.q.log(query, args, start, row.Err())
+	return row
+}
+
+func (db *DB) Begin() (*Tx, error) {
+	return db.BeginTx(context.Background(), nil)
+}
+
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{Tx: tx, q: db.q}, nil
+}
+
+// Tx wraps a sql.Tx like DB
+type Tx struct {
+	*sql.Tx
+	q queryLog
+}
+
+func (tx *Tx) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return tx.ExecContext(context.Background(), query, args...)
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	start := time.Now()
+	res, err := tx.Tx.ExecContext(ctx, query, args...)
+	tx.q.log(query, args, start, err)
+	return res, err
+}
+
+func (tx *Tx) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return tx.QueryContext(context.Background(), query, args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	start := time.Now()
+	rows, err := tx.Tx.QueryContext(ctx, query, args...)
+	tx.q.log(query, args, start, err)
+	return rows, err
+}
+
+func (tx *Tx) QueryRow(query string, args ...interface{}) *sql.Row {
+	return tx.QueryRowContext(context.Background(), query, args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	start := time.Now()
+	row := tx.Tx.QueryRowContext(ctx, query, args...)
+	tx.q.log(query, args, start, row.Err())
+	return row
+}
+
+// queryLog logs finished queries
+type queryLog struct {
+	l    *MyLog.Log
+	opts Options
+}
+
+func (q queryLog) log(query string, args []interface{}, start time.Time, err error) {
+	elapsed := time.Since(start)
+	failed := err != nil && !errors.Is(err, sql.ErrNoRows)
+	slow := q.opts.SlowThreshold > 0 && elapsed > q.opts.SlowThreshold
+	if !failed && !slow && !q.opts.All {
+		return
+	}
+
+	f := MyLog.Fields{"query": query, "duration": elapsed}
+	if q.opts.Args && len(args) > 0 {
+		f["args"] = args
+	}
+	l := q.l.WithFields(f)
+
+	switch {
+	case failed:
+		l.WithField("error", err).Error("query failed")
+	case slow:
+		l.Warn("slow query, over %s", q.opts.SlowThreshold)
+	default:
+		l.Debug("query")
+	}
+}
@@ -0,0 +1,135 @@
+package sqllog
+
+import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// fakeDriver runs every query successfully, except queries containing
+// FAIL, and sleeps for queries containing SLOW
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt(query), nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt string
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) run() error {
+	if strings.Contains(string(s), "SLOW") {
+		time.Sleep(20 * time.Millisecond)
+	}
+	if strings.Contains(string(s), "FAIL") {
+		return errors.New("syntax error")
+	}
+	return nil
+}
+
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), s.run()
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	return fakeRows{}, s.run()
+}
+
+// fakeRows has no rows
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string         { return []string{"id"} }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+func init() {
+	sql.Register("sqllog-fake", fakeDriver{})
+}
+
+func open(t *testing.T, opts Options) (*DB, *bytes.Buffer, *bytes.Buffer) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	l := MyLog.New()
+	l.SetOutput(stdout, stderr)
+	l.SetFlags(0)
+	db, err := Open("sqllog-fake", "", l, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, stdout, stderr
+}
+
+func TestFailedQuery(t *testing.T) {
+	db, stdout, stderr := open(t, Options{Args: true})
+
+	db.Exec("UPDATE ok")
+	if _, err := db.Exec("UPDATE FAIL", 42); err == nil {
+		t.Fatal("Exec() succeeded")
+	}
+	if stdout.String() != "" {
+		t.Errorf("successful query logged: %q", stdout.String())
+	}
+	out := stderr.String()
+	for _, s := range []string{"ERROR: query failed ", "args=[42]", `error="syntax error"`, `query="UPDATE FAIL"`} {
+		if !strings.Contains(out, s) {
+			t.Errorf("stderr misses %q: %q", s, out)
+		}
+	}
+}
+
+func TestSlowQuery(t *testing.T) {
+	db, stdout, _ := open(t, Options{SlowThreshold: 10 * time.Millisecond})
+
+	rows, err := db.Query("SELECT SLOW", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows.Close()
+	out := stdout.String()
+	if !strings.HasPrefix(out, "WARN:  slow query, over 10ms ") || strings.Contains(out, "args=") {
+		t.Errorf("stdout = %q", out)
+	}
+}
+
+func TestAllQueries(t *testing.T) {
+	db, _, stderr := open(t, Options{All: true, SlowThreshold: -1})
+
+	// sql.ErrNoRows is no failure
+	var id int
+	if err := db.QueryRow("SELECT SLOW").Scan(&id); err != sql.ErrNoRows {
+		t.Fatalf("Scan() = %v", err)
+	}
+	if stderr.String() != "" {
+		t.Errorf("debug entries without debug mode: %q", stderr.String())
+	}
+
+	db.q.l.SetMode(MyLog.LgDebug)
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Exec("INSERT tx")
+	tx.QueryRow("SELECT tx")
+	tx.Commit()
+	if got := strings.Count(stderr.String(), "DEBUG: query "); got != 2 {
+		t.Errorf("%d debug entries for the transaction, want 2: %q", got, stderr.String())
+	}
+}
@@ -0,0 +1,121 @@
+package MyLog
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"strings"
+)
+
+// SetStackDepth limits stack traces to the top n frames; 0 is unlimited
+func (l *Log) SetStackDepth(n int) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stackDepth = n
+}
+
+// SetIncludeStack controls whether stack traces are also stored in the
+// buffer and written to JSON entries. Text output always shows them.
+func (l *Log) SetIncludeStack(b bool) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.includeStack = b
+}
+
+// ErrorWithStack logs an error entry for err together with the stack
+// trace of the caller. The message is followed by ": " and err.
+func (l *Log) ErrorWithStack(err error, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	if !l.Enabled(LevelError) {
+		return
+	}
+
+	msg := fmt.Sprintf(format, v...)
+	if err != nil {
+		msg += ": " + err.Error()
+	}
+
+	l.dumpRecorder()
+	e := l.newEntry(LevelError, "ERROR", msg)
+	e.Stack = l.stack()
+	l.outputEntry(l.errorVar, l.painter(LevelError), e)
+}
+
+// structured returns e as it goes to the buffer and JSON entries,
+// i.e. without stack trace unless SetIncludeStack is set
+func (l *Log) structured(e *Entry) *Entry {
+	l.mu.RLock()
+	include := l.includeStack
+	l.mu.RUnlock()
+
+	if include || e.Stack == "" {
+		return e
+	}
+
+	c := *e
+	c.Stack = ""
+	return &c
+}
+
+// stack returns the trace of the calling goroutine, formatted like
+// runtime/debug.Stack, starting at the first frame outside of MyLog
+// and the runtime's panic handling
+func (l *Log) stack() string {
+	l.mu.RLock()
+	depth := l.stackDepth
+	l.mu.RUnlock()
+
+	frames := runtime.CallersFrames(callers(3, depth))
+	if depth <= 0 {
+		depth = math.MaxInt
+	}
+
+	var sb strings.Builder
+	leading, count := true, 0
+	for count < depth {
+		f, more := frames.Next()
+		if leading && (isInternalFrame(f.Function) || strings.HasPrefix(f.Function, "runtime.")) {
+			if !more {
+				break
+			}
+			continue
+		}
+		leading = false
+
+		fmt.Fprintf(&sb, "%s()\n\t%s:%d\n", f.Function, f.File, f.Line)
+		count++
+
+		if !more {
+			break
+		}
+	}
+
+	return sb.String()
+}
+
+// callers returns the program counters of the calling goroutine,
+// skipping skip frames, enough for depth frames besides the ones of
+// MyLog, or all of them if depth is 0
+func callers(skip, depth int) []uintptr {
+	// leave room for the frames that are skipped by stack
+	size := depth + 32
+	if depth <= 0 {
+		size = 64
+	}
+	for {
+		pcs := make([]uintptr, size)
+		n := runtime.Callers(skip, pcs)
+		if n < size || depth > 0 {
+			return pcs[:n]
+		}
+		size *= 2
+	}
+}
@@ -0,0 +1,60 @@
+package MyLog_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/hleinders/MyLog"
+)
+
+func TestErrorWithStack(t *testing.T) {
+	l, b := newLog()
+	l.ErrorWithStack(errors.New("timeout"), "request %d failed", 7)
+
+	lines := strings.Split(b.String(), "\n")
+	if lines[0] != "ERROR: request 7 failed: timeout" {
+		t.Fatalf("first line = %q", lines[0])
+	}
+	if len(lines) < 3 || lines[1] != "github.com/hleinders/MyLog_test.TestErrorWithStack()" || !strings.Contains(lines[2], "stack_test.go:") {
+		t.Errorf("stack does not start at the caller:\n%s", b.String())
+	}
+}
+
+func TestSetStackDepth(t *testing.T) {
+	l, b := newLog()
+	l.SetStackDepth(1)
+	l.ErrorWithStack(nil, "failed")
+
+	// message, one frame of two lines and the final newline
+	if got := strings.Split(b.String(), "\n"); len(got) != 4 {
+		t.Errorf("got %d lines, want 4:\n%s", len(got), b.String())
+	}
+}
+
+func TestIncludeStack(t *testing.T) {
+	l, b := newLog()
+	l.SetFormat(MyLog.FormatJSON)
+
+	var e struct{ Stack string }
+	l.ErrorWithStack(nil, "failed")
+	if err := json.Unmarshal(b.Bytes(), &e); err != nil || e.Stack != "" {
+		t.Errorf("entry = %s, want no stack (%v)", b.String(), err)
+	}
+
+	b.Reset()
+	l.SetIncludeStack(true)
+	l.ErrorWithStack(nil, "failed")
+	if err := json.Unmarshal(b.Bytes(), &e); err != nil || !strings.HasPrefix(e.Stack, "github.com/hleinders/MyLog_test.TestIncludeStack()") {
+		t.Errorf("entry = %s, want the stack (%v)", b.String(), err)
+	}
+}
+
+func TestPanicStack(t *testing.T) {
+	l, b := newLog()
+	l.Panic("logged only")
+	if !strings.HasPrefix(b.String(), "PANIC: logged only\ngithub.com/hleinders/MyLog_test.TestPanicStack()\n") {
+		t.Errorf("output = %q", b.String())
+	}
+}
@@ -0,0 +1,88 @@
+package MyLog
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Stats holds the counters of a Log
+type Stats struct {
+	Levels       map[string]uint64 `json:"levels"`       // entries logged per level name
+	Dropped      uint64            `json:"dropped"`      // lost to a full async queue
+	Sampled      uint64            `json:"sampled"`      // discarded by SetSampling
+	RateLimited  uint64            `json:"rate_limited"` // suppressed by SetRateLimit
+	Deduplicated uint64            `json:"deduplicated"` // collapsed by SetDedup
+	Queued       int               `json:"queued"`       // entries waiting in the async queue
+	Writes       uint64            `json:"writes"`       // entries written
+	WriteTime    time.Duration     `json:"write_time"`   // total time spent writing them
+}
+
+// counters are updated while logging
+type counters struct {
+	levels  [LevelFatal + 1]atomic.Uint64
+	dropped atomic.Uint64
+	sampled atomic.Uint64
+	limited atomic.Uint64
+	deduped atomic.Uint64
+	writes  atomic.Uint64
+	wtime   atomic.Int64 // nanoseconds
+}
+
+// Stats returns the counters since Init or the last ResetStats. Each
+// entry is counted for its level, including entries that were not
+// written because of sampling, rate limits or deduplication.
+func (l *Log) Stats() Stats {
+	if !l.ready() {
+		return Stats{}
+	}
+	s := Stats{
+		Levels:       make(map[string]uint64, len(l.stats.levels)),
+		Dropped:      l.stats.dropped.Load(),
+		Sampled:      l.stats.sampled.Load(),
+		RateLimited:  l.stats.limited.Load(),
+		Deduplicated: l.stats.deduped.Load(),
+		Writes:       l.stats.writes.Load(),
+		WriteTime:    time.Duration(l.stats.wtime.Load()),
+	}
+	if q := l.asyncQueue(); q != nil {
+		s.Queued = len(q.ch)
+	}
+	for lv := range l.stats.levels {
+		s.Levels[Level(lv).String()] = l.stats.levels[lv].Load()
+	}
+	return s
+}
+
+// Count returns the number of entries logged with level lv
+func (s Stats) Count(lv Level) uint64 {
+	return s.Levels[lv.String()]
+}
+
+// ResetStats sets all counters to zero
+func (l *Log) ResetStats() {
+	if !l.ready() {
+		return
+	}
+	for lv := range l.stats.levels {
+		l.stats.levels[lv].Store(0)
+	}
+	l.stats.dropped.Store(0)
+	l.stats.sampled.Store(0)
+	l.stats.limited.Store(0)
+	l.stats.deduped.Store(0)
+	l.stats.writes.Store(0)
+	l.stats.wtime.Store(0)
+}
+
+// timeWrite adds a write that started at start
+func (l *Log) timeWrite(start time.Time) {
+	l.stats.writes.Add(1)
+	l.stats.wtime.Add(int64(time.Since(start)))
+}
+
+// count adds e to the counter of its level
+func (l *Log) count(e *Entry) {
+	if int(e.Level) < len(l.stats.levels) {
+		l.stats.levels[e.Level].Add(1)
+	}
+}
@@ -0,0 +1,46 @@
+package MyLog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.SetDedup(time.Hour)
+
+	l.Warn("once")
+	l.Error("twice")
+	l.Error("twice") // deduplicated
+	l.Debug("disabled")
+	l.Flush()
+
+	s := l.Stats()
+	if s.Count(LevelWarn) != 1 || s.Count(LevelError) != 2 || s.Count(LevelDebug) != 0 {
+		t.Errorf("levels = %v", s.Levels)
+	}
+	// one warn, one error and the repeat summary
+	if s.Deduplicated != 1 || s.Writes != 3 {
+		t.Errorf("deduplicated %d, writes %d, want 1 and 3", s.Deduplicated, s.Writes)
+	}
+
+	l.ResetStats()
+	if s := l.Stats(); s.Count(LevelWarn) != 0 || s.Writes != 0 || s.WriteTime != 0 || s.Deduplicated != 0 {
+		t.Errorf("after ResetStats: %+v", s)
+	}
+}
+
+func TestStatsSuppressed(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.SetSampling(1, 0)
+	l.SetRateLimit(LevelInfo, 1, time.Hour)
+
+	l.Warn("same")
+	l.Warn("same")
+	l.StandardInfo("a")
+	l.StandardInfo("b")
+	s := l.Stats()
+	if s.Sampled != 1 || s.RateLimited != 1 || s.Count(LevelWarn) != 2 || s.Count(LevelInfo) != 2 {
+		t.Errorf("stats = %+v", s)
+	}
+}
@@ -0,0 +1,127 @@
+package MyLog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// statusLine is pinned to the bottom row of a terminal, below a scroll
+// region holding all other output
+type statusLine struct {
+	w      io.Writer
+	raw    string // text as set, before fitting it to the width
+	text   string
+	rows   int
+	resize chan os.Signal
+	done   chan struct{}
+}
+
+// SetStatus shows a status line pinned to the bottom of the stderr
+// terminal, while log messages scroll above it. Each call replaces
+// the text, which is cut to the terminal width. The line follows size
+// changes of the terminal. Without a terminal SetStatus does nothing.
+func (l *Log) SetStatus(format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+
+	l.mu.RLock()
+	w := l.stdErr
+	l.mu.RUnlock()
+
+	cols, rows, ok := terminalSize(w)
+	if !ok || rows < 2 {
+		return
+	}
+	raw := fmt.Sprintf(format, v...)
+
+	l.wmu.Lock()
+	defer l.wmu.Unlock()
+
+	s := l.status
+	if s == nil {
+		s = &statusLine{w: w, resize: make(chan os.Signal, 1), done: make(chan struct{})}
+		l.status = s
+		io.WriteString(w, "\n")
+		s.reserve(rows)
+		io.WriteString(w, "\x1b[1A")
+		notifyResize(s.resize)
+		go l.watchResize(s)
+	} else if rows != s.rows {
+		s.reserve(rows)
+	}
+	s.raw, s.text = raw, fitWidth(raw, cols)
+	s.draw()
+}
+
+// ClearStatus removes the status line and restores the terminal
+func (l *Log) ClearStatus() {
+	if !l.ready() {
+		return
+	}
+
+	l.wmu.Lock()
+	s := l.status
+	l.status = nil
+	if s != nil {
+		signal.Stop(s.resize)
+		close(s.done)
+		fmt.Fprintf(s.w, "\x1b7\x1b[r\x1b8\x1b7\x1b[%d;1H\x1b[K\x1b8", s.rows)
+	}
+	l.wmu.Unlock()
+}
+
+// watchResize moves the status line when the terminal size changes
+func (l *Log) watchResize(s *statusLine) {
+	for {
+		select {
+		case <-s.resize:
+		case <-s.done:
+			return
+		}
+
+		cols, rows, ok := terminalSize(s.w)
+		l.wmu.Lock()
+		if l.status == s && ok && rows >= 2 {
+			s.reserve(rows)
+			s.text = fitWidth(s.raw, cols)
+			s.draw()
+		}
+		l.wmu.Unlock()
+	}
+}
+
+// reserve limits scrolling to all rows above the last one, keeping
+// the cursor position
+func (s *statusLine) reserve(rows int) {
+	if s.rows > rows {
+		fmt.Fprintf(s.w, "\x1b7\x1b[%d;1H\x1b[K\x1b8", s.rows)
+	}
+	s.rows = rows
+	fmt.Fprintf(s.w, "\x1b7\x1b[1;%dr\x1b8", rows-1)
+}
+
+// draw writes the text to the last row, keeping the cursor position
+func (s *statusLine) draw() {
+	fmt.Fprintf(s.w, "\x1b7\x1b[%d;1H\x1b[K%s\x1b8", s.rows, s.text)
+}
+
+// terminalSize returns the size of the terminal w writes to
+func terminalSize(w io.Writer) (cols, rows int, ok bool) {
+	f, isFile := w.(interface{ Fd() uintptr })
+	if !isFile || !isTerminal(w) {
+		return 0, 0, false
+	}
+	return termSize(f.Fd())
+}
+
+// fitWidth cuts s to at most cols visible characters
+func fitWidth(s string, cols int) string {
+	plain := []rune(stripANSI(s))
+	if len(plain) < cols {
+		return s
+	}
+	return string(plain[:max(cols-1, 0)])
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package MyLog
+
+import "os"
+
+// notifyResize does nothing where there is no SIGWINCH, the size is
+// checked by each SetStatus
+func notifyResize(c chan<- os.Signal) {}
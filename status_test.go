@@ -0,0 +1,69 @@
+package MyLog
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSetStatusWithoutTerminal(t *testing.T) {
+	l, _, stderr := newTestLog()
+	l.SetStatus("building %d/%d", 1, 3)
+	l.Error("failed")
+	l.ClearStatus()
+	if stderr.String() != "ERROR: failed\n" || l.status != nil {
+		t.Errorf("stderr = %q, status %v", stderr.String(), l.status)
+	}
+}
+
+func TestStatusLineEscapes(t *testing.T) {
+	l, _, stderr := newTestLog()
+	s := &statusLine{w: stderr, resize: make(chan os.Signal, 1), done: make(chan struct{})}
+
+	s.reserve(24)
+	s.text = "building"
+	s.draw()
+	if want := "\x1b7\x1b[1;23r\x1b8" + "\x1b7\x1b[24;1H\x1b[Kbuilding\x1b8"; stderr.String() != want {
+		t.Errorf("reserve and draw = %q, want %q", stderr.String(), want)
+	}
+
+	// shrinking clears the old last row first
+	out := len(stderr.String())
+	s.reserve(20)
+	if want := "\x1b7\x1b[24;1H\x1b[K\x1b8" + "\x1b7\x1b[1;19r\x1b8"; stderr.String()[out:] != want {
+		t.Errorf("shrink = %q, want %q", stderr.String()[out:], want)
+	}
+
+	out = len(stderr.String())
+	l.status = s
+	l.ClearStatus()
+	if want := "\x1b7\x1b[r\x1b8\x1b7\x1b[20;1H\x1b[K\x1b8"; stderr.String()[out:] != want {
+		t.Errorf("ClearStatus = %q, want %q", stderr.String()[out:], want)
+	}
+	if l.status != nil {
+		t.Error("ClearStatus kept the status line")
+	}
+	select {
+	case <-s.done:
+	default:
+		t.Error("ClearStatus did not stop the resize watcher")
+	}
+}
+
+func TestFitWidth(t *testing.T) {
+	tests := []struct {
+		in   string
+		cols int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10"},
+		{"größer als fünf", 6, "größe"},
+		{"\x1b[32mgreen\x1b[0m", 10, "\x1b[32mgreen\x1b[0m"},
+		{"any", 0, ""},
+	}
+	for _, tt := range tests {
+		if got := fitWidth(tt.in, tt.cols); got != tt.want {
+			t.Errorf("fitWidth(%q, %d) = %q, want %q", tt.in, tt.cols, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package MyLog
+
+import (
+	"fmt"
+	"sync"
+)
+
+// collector tallies the warnings and errors logged while enabled
+type collector struct {
+	mu       sync.Mutex
+	warnings int
+	errors   int // including panic and fatal entries
+	entries  ring
+}
+
+func newCollector(keep int) *collector {
+	return &collector{entries: ring{max: keep}}
+}
+
+// EnableCollector counts the Warn, Error, Panic and Fatal entries from
+// now on, for Summary and ExitCode, and keeps the last keep of them to
+// be listed by Summary. With keep 0 they are only counted. Enabling the
+// collector again starts a new tally.
+func (l *Log) EnableCollector(keep int) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.collector = newCollector(max(keep, 0))
+}
+
+// DisableCollector stops counting and drops the collected entries
+func (l *Log) DisableCollector() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.collector = nil
+}
+
+// Collected returns the entries kept by the collector, oldest first
+func (l *Log) Collected() []BufferEntry {
+	if !l.ready() {
+		return nil
+	}
+	c := l.getCollector()
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries.max == 0 {
+		return nil
+	}
+	return c.entries.entries()
+}
+
+// Summary writes the entries kept by the collector, if any, and a final
+// report like "3 errors, 7 warnings". It is written even in quiet mode.
+func (l *Log) Summary() {
+	if !l.ready() {
+		return
+	}
+	c := l.getCollector()
+	if c == nil {
+		return
+	}
+
+	entries := l.Collected()
+	c.mu.Lock()
+	errs, warnings := c.errors, c.warnings
+	c.mu.Unlock()
+
+	if len(entries) > 0 {
+		l.output(l.stdVar, LevelInfo, "", plain, "%s", "Errors and warnings:")
+		for _, e := range entries {
+			msg := e.Msg
+			if e.Prefix != "" {
+				msg = e.Prefix + ": " + msg
+			}
+			l.output(l.stdVar, LevelInfo, "", l.painter(e.Level), "  %s", msg)
+		}
+	}
+
+	paint := plain
+	switch {
+	case errs > 0:
+		paint = l.boldPainter(LevelError)
+	case warnings > 0:
+		paint = l.boldPainter(LevelWarn)
+	}
+	l.output(l.stdVar, LevelInfo, "", paint, "%s, %s", plural(errs, "error"), plural(warnings, "warning"))
+}
+
+// ExitCode returns 1 if the collector counted an error, otherwise 0,
+// for use with os.Exit at the end of a run
+func (l *Log) ExitCode() int {
+	if !l.ready() {
+		return 0
+	}
+	c := l.getCollector()
+	if c == nil {
+		return 0
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.errors > 0 {
+		return 1
+	}
+	return 0
+}
+
+// SetWarningsAsErrors logs all warnings like Error does, with its
+// writer, prefix and color and only if the error level is enabled, so
+// that they count as failures for Summary and ExitCode. This is meant
+// for strict CI runs.
+func (l *Log) SetWarningsAsErrors(b bool) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnErrors = b
+}
+
+func (l *Log) warningsAsErrors() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.warnErrors
+}
+
+func (l *Log) getCollector() *collector {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.collector
+}
+
+// collect counts e if the collector is enabled
+func (l *Log) collect(e *Entry) {
+	if e.Level < LevelWarn {
+		return
+	}
+	c := l.getCollector()
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e.Level == LevelWarn {
+		c.warnings++
+	} else {
+		c.errors++
+	}
+	if c.entries.max > 0 {
+		c.entries.add(BufferEntry{Time: e.Time, Level: e.Level, Prefix: e.Prefix, Msg: stripANSI(e.text())})
+	}
+}
+
+// plural returns n with word, adding an "s" unless n is 1
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("%d %s", n, word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
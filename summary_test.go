@@ -0,0 +1,93 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.EnableCollector(2)
+
+	l.StandardInfo("ignored")
+	l.Warn("first warning")
+	l.Named("db").Error("connection lost")
+	l.Warn("second warning")
+	if l.ExitCode() != 1 {
+		t.Errorf("ExitCode() = %d, want 1", l.ExitCode())
+	}
+
+	l.SetQuiet(true)
+	l.Summary()
+	got := stdout.lines()
+	want := []string{
+		"       Errors and warnings:",
+		"         ERROR: [db] connection lost",
+		"         WARN: second warning",
+		"       1 error, 2 warnings",
+	}
+	if strings.Join(got[len(got)-4:], "\n") != strings.Join(want, "\n") {
+		t.Errorf("summary =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestCollector(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.Warn("before")
+	l.Summary()
+	if l.ExitCode() != 0 || len(stdout.lines()) != 1 {
+		t.Error("the disabled collector counts")
+	}
+
+	// with keep 0 only the counts are written
+	l.EnableCollector(0)
+	l.Warn("one")
+	l.Summary()
+	if got := stdout.lines(); got[len(got)-1] != "       0 errors, 1 warning" || l.ExitCode() != 0 || l.Collected() != nil {
+		t.Errorf("counted summary = %q", got)
+	}
+
+	// enabling again starts a new tally
+	l.EnableCollector(5)
+	l.Error("again")
+	if c := l.Collected(); len(c) != 1 || c[0].Msg != "again" || c[0].Level != LevelError {
+		t.Errorf("Collected() = %+v", c)
+	}
+
+	l.DisableCollector()
+	if l.ExitCode() != 0 || l.Collected() != nil {
+		t.Error("DisableCollector kept the tally")
+	}
+}
+
+func TestPlural(t *testing.T) {
+	for n, want := range map[int]string{0: "0 errors", 1: "1 error", 2: "2 errors"} {
+		if got := plural(n, "error"); got != want {
+			t.Errorf("plural(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestWarningsAsErrors(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.EnableCollector(1)
+	l.SetWarningsAsErrors(true)
+
+	l.Warn("deprecated %s", "flag")
+	if stdout.String() != "" || stderr.String() != "ERROR: deprecated flag\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+	if l.ExitCode() != 1 {
+		t.Errorf("ExitCode() = %d, want 1", l.ExitCode())
+	}
+	if c := l.Collected(); len(c) != 1 || c[0].Level != LevelError {
+		t.Errorf("Collected() = %+v", c)
+	}
+
+	l.EnableCollector(0)
+	l.SetWarningsAsErrors(false)
+	l.Warn("plain")
+	if stdout.String() != "WARN:  plain\n" || l.ExitCode() != 0 {
+		t.Errorf("after disabling: stdout = %q, exit code %d", stdout.String(), l.ExitCode())
+	}
+}
@@ -0,0 +1,87 @@
+package MyLog
+
+// Facility is a syslog facility as defined in RFC 5424
+type Facility int
+
+const (
+	FacilityKern Facility = iota << 3
+	FacilityUser
+	FacilityMail
+	FacilityDaemon
+	FacilityAuth
+	FacilitySyslog
+	FacilityLpr
+	FacilityNews
+	FacilityUucp
+	FacilityCron
+	FacilityAuthPriv
+	FacilityFtp
+	_ // unused
+	_
+	_
+	_
+	FacilityLocal0
+	FacilityLocal1
+	FacilityLocal2
+	FacilityLocal3
+	FacilityLocal4
+	FacilityLocal5
+	FacilityLocal6
+	FacilityLocal7
+)
+
+// syslogConn sends entries with the severity matching their level
+type syslogConn interface {
+	send(lv Level, msg string) error
+	Close() error
+}
+
+// EnableSyslog additionally sends all entries to syslog. An empty network
+// and addr use the local syslog socket, otherwise network is "tcp" or "udp".
+// The syslog severity is derived from the level of each entry.
+func (l *Log) EnableSyslog(network, addr string, facility Facility, tag string) error {
+	if !l.ready() {
+		return nil
+	}
+	conn, err := dialSyslog(network, addr, facility, tag)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	old, shared := l.syslog, l.sharedSyslog
+	l.syslog, l.sharedSyslog = conn, false
+	l.mu.Unlock()
+
+	if old != nil && !shared {
+		return old.Close()
+	}
+	return nil
+}
+
+// DisableSyslog stops sending entries to syslog and closes the connection
+func (l *Log) DisableSyslog() error {
+	if !l.ready() {
+		return nil
+	}
+	l.mu.Lock()
+	old, shared := l.syslog, l.sharedSyslog
+	l.syslog, l.sharedSyslog = nil, false
+	l.mu.Unlock()
+
+	if old != nil && !shared {
+		return old.Close()
+	}
+	return nil
+}
+
+func (l *Log) toSyslog(e *Entry) {
+	l.mu.RLock()
+	conn := l.syslog
+	l.mu.RUnlock()
+
+	if conn != nil {
+		// log/syslog reconnects on its own, a lost entry is not worth reporting
+		_ = conn.send(e.Level, stripANSI(e.text()))
+	}
+}
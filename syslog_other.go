@@ -0,0 +1,9 @@
+//go:build windows || plan9
+
+package MyLog
+
+import "errors"
+
+func dialSyslog(network, addr string, facility Facility, tag string) (syslogConn, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
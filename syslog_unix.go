@@ -0,0 +1,34 @@
+//go:build !windows && !plan9
+
+package MyLog
+
+import "log/syslog"
+
+type syslogWriter struct {
+	*syslog.Writer
+}
+
+func dialSyslog(network, addr string, facility Facility, tag string) (syslogConn, error) {
+	w, err := syslog.Dial(network, addr, syslog.Priority(facility)|syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return syslogWriter{w}, nil
+}
+
+// send maps MyLog levels onto the RFC 5424 severities
+func (w syslogWriter) send(lv Level, msg string) error {
+	switch lv {
+	case LevelTrace, LevelDebug:
+		return w.Debug(msg)
+	case LevelVerbose, LevelInfo:
+		return w.Info(msg)
+	case LevelWarn:
+		return w.Warning(msg)
+	case LevelError:
+		return w.Err(msg)
+	case LevelPanic:
+		return w.Crit(msg)
+	}
+	return w.Alert(msg)
+}
@@ -0,0 +1,47 @@
+//go:build !windows && !plan9
+
+package MyLog
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslog(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer pc.Close()
+
+	l, _, _ := newTestLog()
+	if err := l.EnableSyslog("udp", pc.LocalAddr().String(), FacilityLocal0, "mytest"); err != nil {
+		t.Fatal(err)
+	}
+	defer l.DisableSyslog()
+
+	l.WithField("disk", "sda").Warn("almost full")
+
+	buf := make([]byte, 1024)
+	pc.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := pc.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := string(buf[:n])
+
+	// local0 is 16, warning 4: 16*8+4
+	if !strings.HasPrefix(msg, "<132>") || !strings.Contains(msg, "mytest") || !strings.HasSuffix(strings.TrimSpace(msg), "almost full disk=sda") {
+		t.Errorf("syslog message = %q", msg)
+	}
+}
+
+func TestSyslogDialError(t *testing.T) {
+	l, _, _ := newTestLog()
+	if err := l.EnableSyslog("tcp", "127.0.0.1:1", FacilityUser, "x"); err == nil {
+		l.DisableSyslog()
+		t.Error("dialing a closed port succeeded")
+	}
+}
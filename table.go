@@ -0,0 +1,105 @@
+package MyLog
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// TableOption changes how Table renders
+type TableOption func(t *tableStyle)
+
+type tableStyle struct {
+	right  map[int]bool
+	header paintFunc // nil for the default
+	rule   bool
+}
+
+// TableAlignRight right-aligns the given columns, counted from 0
+func TableAlignRight(cols ...int) TableOption {
+	return func(t *tableStyle) {
+		for _, c := range cols {
+			t.right[c] = true
+		}
+	}
+}
+
+// TableHeaderColor colors the header row in color mode, bold by default
+func TableHeaderColor(attrs ...color.Attribute) TableOption {
+	return func(t *tableStyle) { t.header = painterFor(attrs) }
+}
+
+// TableNoRule omits the rule below the header row
+func TableNoRule() TableOption {
+	return func(t *tableStyle) { t.rule = false }
+}
+
+// Table writes rows as aligned columns below headers, one standard
+// message per line. Missing cells are left empty. The header row is
+// colored in color mode; the buffer receives plain text.
+func (l *Log) Table(headers []string, rows [][]string, opts ...TableOption) {
+	if !l.ready() || !l.Enabled(LevelInfo) {
+		return
+	}
+
+	style := tableStyle{right: make(map[int]bool), rule: true}
+	for _, opt := range opts {
+		opt(&style)
+	}
+
+	n := len(headers)
+	for _, row := range rows {
+		n = max(n, len(row))
+	}
+	widths := make([]int, n)
+	for _, row := range append([][]string{headers}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], visibleWidth(cell))
+		}
+	}
+
+	if len(headers) > 0 {
+		paint := plain
+		if l.modeHas(LgColor) {
+			paint = bold
+			if style.header != nil {
+				paint = style.header
+			}
+		}
+		l.output(l.stdVar, LevelInfo, "", paint, "%s", style.line(headers, widths))
+
+		if style.rule {
+			rule, _ := l.rules()
+			cells := make([]string, n)
+			for i, w := range widths {
+				cells[i] = repeatTo(rule, w)
+			}
+			l.output(l.stdVar, LevelInfo, "", plain, "%s", style.line(cells, widths))
+		}
+	}
+	for _, row := range rows {
+		l.output(l.stdVar, LevelInfo, "", plain, "%s", style.line(row, widths))
+	}
+}
+
+// line renders one row padded to widths
+func (t *tableStyle) line(row []string, widths []int) string {
+	var sb strings.Builder
+	for i, w := range widths {
+		cell := ""
+		if i < len(row) {
+			cell = row[i]
+		}
+		pad := strings.Repeat(" ", w-visibleWidth(cell))
+
+		if i > 0 {
+			sb.WriteString("  ")
+		}
+		if t.right[i] {
+			sb.WriteString(pad + cell)
+		} else {
+			sb.WriteString(cell + pad)
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
@@ -0,0 +1,69 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+func TestTable(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetRuleChars("-", "=")
+
+	l.Table([]string{"NAME", "SIZE"}, [][]string{
+		{"a.txt", "12"},
+		{"longer.bin", "3456", "extra"},
+		{"empty"},
+	}, TableAlignRight(1))
+
+	want := []string{
+		"       NAME        SIZE",
+		"       ----------  ----  -----",
+		"       a.txt         12",
+		"       longer.bin  3456  extra",
+		"       empty",
+	}
+	if got := stdout.lines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("stdout =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestTableOptions(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.Table([]string{"A", "B"}, [][]string{{"1", "2"}}, TableNoRule())
+	if got := stdout.lines(); len(got) != 2 || got[1] != "       1  2" {
+		t.Errorf("without rule = %q", got)
+	}
+
+	// without headers, only the rows are written
+	l, stdout, _ = newTestLog()
+	l.Table(nil, [][]string{{"x", "y"}})
+	if stdout.String() != "       x  y\n" {
+		t.Errorf("without headers = %q", stdout.String())
+	}
+
+	l, stdout, _ = newTestLog()
+	l.SetLevel(LevelWarn)
+	l.Table([]string{"A"}, nil)
+	if stdout.String() != "" {
+		t.Errorf("table below the level = %q", stdout.String())
+	}
+}
+
+func TestTableColor(t *testing.T) {
+	l, out := coloredOutput(t)
+	l.Table([]string{"A"}, [][]string{{"\x1b[32mok\x1b[0m"}}, TableNoRule())
+	got := out.lines()
+	if len(got) != 2 || !strings.Contains(got[0], "\x1b[1m") {
+		t.Fatalf("header not bold: %q", got)
+	}
+	l.Table([]string{"B"}, nil, TableHeaderColor(color.FgRed))
+	if got := out.lines(); !strings.Contains(got[2], "\x1b[31m") {
+		t.Errorf("header color = %q", got[2])
+	}
+	// colored cells are padded by their visible width
+	if !strings.HasSuffix(got[1], "\x1b[32mok\x1b[0m") {
+		t.Errorf("colored cell = %q", got[1])
+	}
+}
@@ -0,0 +1,134 @@
+package MyLog
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// Task reports the outcome of a step of a CLI tool as a status line,
+// like "✓ Uploading artifacts (1.2s)". Status lines are colored in color
+// mode. Subtasks are indented below their parent.
+type Task struct {
+	l     *Log
+	name  string
+	depth int
+	start time.Time
+	done  atomic.Bool
+}
+
+const (
+	taskSuccess = iota
+	taskFail
+	taskSkip
+)
+
+// Task starts a task, logging its name as a standard message
+func (l *Log) Task(name string) *Task {
+	if !l.ready() {
+		return &Task{}
+	}
+	return l.task(name, 0)
+}
+
+func (l *Log) task(name string, depth int) *Task {
+	t := &Task{l: l, name: name, depth: depth, start: time.Now()}
+	if l.Enabled(LevelInfo) {
+		l.output(l.stdVar, LevelInfo, "", plain, "%s%s ...", t.indent(), name)
+	}
+	return t
+}
+
+// Task starts a subtask of t
+func (t *Task) Task(name string) *Task {
+	if t.l == nil {
+		return t
+	}
+	return t.l.task(name, t.depth+1)
+}
+
+// Success reports the task as done
+func (t *Task) Success() {
+	t.finish(taskSuccess, "")
+}
+
+// Fail reports the task as failed with err, as an error entry
+func (t *Task) Fail(err error) {
+	msg := ""
+	if err != nil {
+		msg = err.Error()
+	}
+	t.finish(taskFail, msg)
+}
+
+// Skip reports the task as skipped for reason
+func (t *Task) Skip(reason string) {
+	t.finish(taskSkip, reason)
+}
+
+// finish writes the status line; only the first call of a task counts
+func (t *Task) finish(state int, detail string) {
+	if t.l == nil || t.done.Swap(true) {
+		return
+	}
+
+	lv := LevelInfo
+	if state == taskFail {
+		lv = LevelError
+	}
+	if !t.l.Enabled(lv) {
+		return
+	}
+
+	icon := iconSuccess
+	switch state {
+	case taskFail:
+		icon = icons[LevelError]
+	case taskSkip:
+		icon = iconSkipped
+	}
+
+	msg := t.indent() + icon.String() + " " + t.name
+	if state != taskSkip {
+		msg += " (" + roundDuration(time.Since(t.start)).String() + ")"
+	}
+	if detail != "" {
+		msg += ": " + detail
+	}
+
+	paint := plain
+	if t.l.modeHas(LgColor) {
+		switch state {
+		case taskSuccess:
+			paint = painterFor([]color.Attribute{color.FgGreen})
+		default:
+			paint = painterFor([]color.Attribute{color.Faint})
+		}
+	}
+	if state == taskFail {
+		// the normal error path, with its prefix and recorder replay
+		t.l.error("%s", msg)
+		return
+	}
+	t.l.output(t.l.stdVar, lv, "", paint, "%s", msg)
+}
+
+func (t *Task) indent() string {
+	b := make([]byte, 2*t.depth)
+	for i := range b {
+		b[i] = ' '
+	}
+	return string(b)
+}
+
+// roundDuration shortens d for display
+func roundDuration(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(100 * time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(time.Millisecond)
+	}
+	return d.Round(time.Microsecond)
+}
@@ -0,0 +1,68 @@
+package MyLog
+
+import (
+	"errors"
+	"regexp"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestTask(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Unicode support is decided by WT_SESSION")
+	}
+	setLocale(t, "C")
+	l, stdout, stderr := newTestLog()
+
+	build := l.Task("Build")
+	build.Task("Compile").Success()
+	test := build.Task("Test")
+	test.Fail(errors.New("2 failed"))
+	test.Success()
+	build.Task("Deploy").Skip("dry run")
+	build.Success()
+
+	want := regexp.MustCompile(`^       Build \.\.\.
+         Compile \.\.\.
+         \+ Compile \(\d\S*s\)
+         Test \.\.\.
+         Deploy \.\.\.
+         - Deploy: dry run
+       \+ Build \(\d\S*s\)
+$`)
+	if !want.MatchString(stdout.String()) {
+		t.Errorf("stdout =\n%s", stdout.String())
+	}
+	if ok, _ := regexp.MatchString(`^ERROR:   x Test \(\d\S*s\): 2 failed\n$`, stderr.String()); !ok {
+		t.Errorf("stderr = %q, want the failure once", stderr.String())
+	}
+}
+
+func TestTaskLevels(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	l.SetLevel(LevelError)
+
+	l.Task("hidden").Success()
+	l.Task("failing").Fail(nil)
+	if stdout.String() != "" || stderr.String() == "" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	var nl *Log
+	nl.Task("nil").Task("sub").Success()
+}
+
+func TestRoundDuration(t *testing.T) {
+	tests := map[time.Duration]time.Duration{
+		1234567 * time.Microsecond: 1200 * time.Millisecond,
+		12345 * time.Microsecond:   12 * time.Millisecond,
+		123456:                     123 * time.Microsecond,
+		1234:                       time.Microsecond,
+	}
+	for in, want := range tests {
+		if got := roundDuration(in); got != want {
+			t.Errorf("roundDuration(%v) = %v, want %v", in, got, want)
+		}
+	}
+}
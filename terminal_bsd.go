@@ -0,0 +1,7 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package MyLog
+
+import "syscall"
+
+const ioctlReadTermios = syscall.TIOCGETA
@@ -0,0 +1,5 @@
+package MyLog
+
+import "syscall"
+
+const ioctlReadTermios = syscall.TCGETS
@@ -0,0 +1,15 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd || windows)
+
+package MyLog
+
+// isTerminalFd is not supported here, output is never colored
+// automatically
+func isTerminalFd(fd uintptr) bool {
+	return false
+}
+
+// termSize is not supported here, features fitting the terminal width
+// keep their defaults
+func termSize(fd uintptr) (cols, rows int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,22 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+package MyLog
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+// isTerminalFd reports whether fd refers to a terminal
+func isTerminalFd(fd uintptr) bool {
+	var t syscall.Termios
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, ioctlReadTermios, uintptr(unsafe.Pointer(&t)))
+	return errno == 0
+}
+
+// termSize returns the size of the terminal fd refers to
+func termSize(fd uintptr) (cols, rows int, ok bool) {
+	var ws struct{ Row, Col, Xpixel, Ypixel uint16 }
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, syscall.TIOCGWINSZ, uintptr(unsafe.Pointer(&ws)))
+	return int(ws.Col), int(ws.Row), errno == 0 && ws.Col > 0
+}
@@ -0,0 +1,111 @@
+package MyLog
+
+import "github.com/fatih/color"
+
+// Theme assigns color attributes to levels. Levels without
+// attributes are written uncolored.
+type Theme map[Level][]color.Attribute
+
+// Theme presets
+var (
+	// ThemeDark is the default, suited for dark terminal backgrounds
+	ThemeDark = Theme{
+		LevelTrace:   {color.FgRed},
+		LevelDebug:   {color.FgRed},
+		LevelVerbose: {color.FgGreen},
+		LevelInfo:    {color.FgGreen},
+		LevelWarn:    {color.FgYellow},
+		LevelError:   {color.FgRed},
+		LevelPanic:   {color.FgRed},
+		LevelFatal:   {color.FgRed},
+	}
+
+	// ThemeLight avoids yellow and bright colors on light backgrounds
+	ThemeLight = Theme{
+		LevelTrace:   {color.FgHiBlack},
+		LevelDebug:   {color.FgBlue},
+		LevelVerbose: {color.FgGreen},
+		LevelInfo:    {color.FgGreen},
+		LevelWarn:    {color.FgMagenta},
+		LevelError:   {color.FgRed},
+		LevelPanic:   {color.FgRed, color.Bold},
+		LevelFatal:   {color.FgRed, color.Bold},
+	}
+
+	// ThemeMonochrome only uses text attributes, no colors
+	ThemeMonochrome = Theme{
+		LevelTrace: {color.Faint},
+		LevelDebug: {color.Faint},
+		LevelWarn:  {color.Underline},
+		LevelError: {color.Bold},
+		LevelPanic: {color.Bold, color.Underline},
+		LevelFatal: {color.Bold, color.Underline},
+	}
+)
+
+// SetTheme sets the color attributes of all levels
+func (l *Log) SetTheme(t Theme) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	l.painters = make(map[Level]paintFunc, len(t))
+	for lv, attrs := range t {
+		l.painters[lv] = painterFor(attrs)
+	}
+	l.mu.Unlock()
+
+	l.refreshColorPrefix()
+}
+
+// SetLevelColor sets the color attributes of a single level.
+// Without attributes the level is written uncolored.
+func (l *Log) SetLevelColor(lv Level, attrs ...color.Attribute) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	if l.painters == nil {
+		l.painters = make(map[Level]paintFunc)
+	}
+	l.painters[lv] = painterFor(attrs)
+	l.mu.Unlock()
+
+	l.refreshColorPrefix()
+}
+
+func painterFor(attrs []color.Attribute) paintFunc {
+	if len(attrs) == 0 {
+		return plain
+	}
+	c := color.New(attrs...)
+	return func(s string) string { return c.Sprint(s) }
+}
+
+// painter returns the color func of lv according to the theme
+func (l *Log) painter(lv Level) paintFunc {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if p, ok := l.painters[lv]; ok {
+		return p
+	}
+	return plain
+}
+
+// boldPainter returns the color func of lv in bold
+func (l *Log) boldPainter(lv Level) paintFunc {
+	p := l.painter(lv)
+	return func(s string) string { return bold(p(s)) }
+}
+
+// refreshColorPrefix re-applies colored prefixes after a theme change
+func (l *Log) refreshColorPrefix() {
+	l.mu.RLock()
+	colored := l.coloredPrefix
+	l.mu.RUnlock()
+
+	if colored {
+		l.SetColorPrefix()
+	}
+}
@@ -0,0 +1,53 @@
+package MyLog
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+// coloredOutput returns a colored Log and an output receiving its
+// escape codes
+func coloredOutput(t *testing.T) (*Log, *syncBuffer) {
+	forceColor(t)
+	l, _, _ := newTestLog()
+	l.SetColor(true)
+	out := new(syncBuffer)
+	l.AddOutput(out, OutputOptions{Color: true})
+	return l, out
+}
+
+func TestSetTheme(t *testing.T) {
+	l, out := coloredOutput(t)
+	l.SetTheme(ThemeLight)
+
+	l.Warn("careful")
+	if got := out.String(); !strings.Contains(got, "\x1b[35m") || stripANSI(got) != "WARN:  careful\n" {
+		t.Errorf("output = %q, want magenta", got)
+	}
+
+	l.SetTheme(Theme{})
+	if p := l.painter(LevelWarn); p("x") != "x" {
+		t.Errorf("empty theme painted %q", p("x"))
+	}
+}
+
+func TestSetLevelColor(t *testing.T) {
+	l, out := coloredOutput(t)
+	l.SetLevelColor(LevelError, color.FgCyan)
+	l.SetLevelColor(LevelWarn)
+
+	l.Error("failed")
+	l.Warn("careful")
+	lines := out.lines()
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "\x1b[36m") {
+		t.Errorf("error line = %q, want cyan", lines[0])
+	}
+	if lines[1] != "WARN:  careful" {
+		t.Errorf("warn line = %q, want uncolored", lines[1])
+	}
+}
@@ -0,0 +1,39 @@
+package MyLog
+
+import "time"
+
+// Timed logs name as started and returns a func logging it as done
+// with the elapsed time as duration field. If a non-nil error is passed
+// to the func, the end is logged as error together with it:
+//
+//	done := l.Timed("loading index")
+//	err := load()
+//	done(err)
+func (l *Log) Timed(name string) func(err ...error) {
+	if !l.ready() {
+		return func(...error) {}
+	}
+
+	l.StandardInfo("%s ...", name)
+	start := time.Now()
+
+	return func(errs ...error) {
+		lg := l.WithField("duration", time.Since(start))
+		for _, err := range errs {
+			if err != nil {
+				lg.Error("%s failed: %v", name, err)
+				return
+			}
+		}
+		lg.StandardInfo("%s done", name)
+	}
+}
+
+// TimeFunc runs fn between the start and end lines of Timed and
+// returns its error
+func (l *Log) TimeFunc(name string, fn func() error) error {
+	done := l.Timed(name)
+	err := fn()
+	done(err)
+	return err
+}
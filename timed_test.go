@@ -0,0 +1,43 @@
+package MyLog
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+)
+
+func TestTimed(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+
+	done := l.Timed("loading index")
+	done()
+	l.Timed("saving")(nil, errors.New("disk full"))
+
+	want := regexp.MustCompile(`^INFO:  loading index \.\.\.\nINFO:  loading index done duration=\d\S*s\nINFO:  saving \.\.\.\n$`)
+	if !want.MatchString(stdout.String()) {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+	if ok, _ := regexp.MatchString(`^ERROR: saving failed: disk full duration=\d\S*s\n$`, stderr.String()); !ok {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestTimeFunc(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	errFail := errors.New("fail")
+
+	if err := l.TimeFunc("ok", func() error { return nil }); err != nil {
+		t.Errorf("TimeFunc() = %v", err)
+	}
+	if err := l.TimeFunc("bad", func() error { return errFail }); err != errFail {
+		t.Errorf("TimeFunc() = %v, want the error of fn", err)
+	}
+	if len(stdout.lines()) != 3 || len(stderr.lines()) != 1 {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	var nl *Log
+	if err := nl.TimeFunc("nil", func() error { return errFail }); err != errFail {
+		t.Errorf("TimeFunc on a nil Log = %v", err)
+	}
+}
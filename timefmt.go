@@ -0,0 +1,128 @@
+package MyLog
+
+import (
+	"log"
+	"strconv"
+	"time"
+)
+
+// Layouts for SetTimeFormat besides the ones of package time
+const (
+	TimeRFC3339     = time.RFC3339
+	TimeRFC3339Nano = time.RFC3339Nano
+	TimeUnixMilli   = "unixmilli" // milliseconds since the Unix epoch
+)
+
+// TimeMode selects what the timestamp of text lines shows
+type TimeMode uint8
+
+const (
+	TimeWall    TimeMode = iota // wall clock time (default)
+	TimeElapsed                 // time since Init, like [+12.345s]
+	TimeDelta                   // time since the previous entry
+)
+
+// timeFormat controls how timestamps of text lines are rendered
+type timeFormat struct {
+	layout string // replaces the date and time flags if set
+	utc    bool
+	mode   TimeMode
+	start  time.Time // for TimeElapsed
+	prev   time.Time // for TimeDelta, set per entry
+}
+
+// SetTimeFormat renders the timestamp of text lines with layout, in
+// place of the date and time flags. Lines without any of these flags
+// stay without timestamp. An empty layout restores the flags.
+func (l *Log) SetTimeFormat(layout string) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timeFmt.layout = layout
+}
+
+// SetTimeUTC renders timestamps in UTC instead of local time
+func (l *Log) SetTimeUTC(b bool) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timeFmt.utc = b
+}
+
+// SetTimeMode selects between wall clock, elapsed and delta timestamps.
+// Elapsed and delta times replace the date and time flags like a layout
+// set with SetTimeFormat.
+func (l *Log) SetTimeMode(m TimeMode) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timeFmt.mode = m
+}
+
+// sincePrev records e as the latest entry and remembers the time of the
+// one before for TimeDelta
+func (l *Log) sincePrev(e *Entry) {
+	if prev := l.lastEntry.Swap(e.Time.UnixNano()); prev != 0 {
+		e.prev = time.Unix(0, prev)
+	}
+}
+
+// timeFormat returns the format for e, or for buffered entries if e is nil
+func (l *Log) timeFormat(e *Entry) timeFormat {
+	l.mu.RLock()
+	tf := l.timeFmt
+	l.mu.RUnlock()
+
+	if e != nil {
+		tf.prev = e.prev
+	}
+	return tf
+}
+
+// appendTime appends t as selected by the log flags, followed by a space
+func (tf timeFormat) appendTime(b []byte, flags int, t time.Time) []byte {
+	if flags&(log.Ldate|log.Ltime|log.Lmicroseconds) == 0 {
+		return b
+	}
+	if flags&log.LUTC != 0 || tf.utc {
+		t = t.UTC()
+	}
+
+	switch {
+	case tf.mode == TimeElapsed:
+		return appendSince(b, t.Sub(tf.start))
+	case tf.mode == TimeDelta && tf.prev.IsZero():
+		return appendSince(b, 0)
+	case tf.mode == TimeDelta:
+		return appendSince(b, t.Sub(tf.prev))
+	case tf.layout == TimeUnixMilli:
+		b = strconv.AppendInt(b, t.UnixMilli(), 10)
+		return append(b, ' ')
+	case tf.layout != "":
+		b = t.AppendFormat(b, tf.layout)
+		return append(b, ' ')
+	}
+
+	if flags&log.Ldate != 0 {
+		b = t.AppendFormat(b, "2006/01/02 ")
+	}
+	if flags&log.Lmicroseconds != 0 {
+		b = t.AppendFormat(b, "15:04:05.000000 ")
+	} else if flags&log.Ltime != 0 {
+		b = t.AppendFormat(b, "15:04:05 ")
+	}
+	return b
+}
+
+// appendSince appends d like [+12.345s], followed by a space
+func appendSince(b []byte, d time.Duration) []byte {
+	b = append(b, "[+"...)
+	b = strconv.AppendFloat(b, d.Seconds(), 'f', 3, 64)
+	return append(b, "s] "...)
+}
@@ -0,0 +1,76 @@
+package MyLog
+
+import (
+	"log"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAppendTime(t *testing.T) {
+	ts := time.Date(2024, 3, 7, 14, 5, 9, 123456000, time.FixedZone("CET", 3600))
+	tests := []struct {
+		tf    timeFormat
+		flags int
+		want  string
+	}{
+		{timeFormat{}, 0, ""},
+		{timeFormat{}, log.LstdFlags, "2024/03/07 14:05:09 "},
+		{timeFormat{}, log.Ltime | log.Lmicroseconds, "14:05:09.123456 "},
+		{timeFormat{}, log.Ltime | log.LUTC, "13:05:09 "},
+		{timeFormat{layout: TimeRFC3339}, log.Ltime, "2024-03-07T14:05:09+01:00 "},
+		{timeFormat{layout: TimeRFC3339, utc: true}, log.Ltime, "2024-03-07T13:05:09Z "},
+		{timeFormat{layout: TimeUnixMilli}, log.Ltime, "1709816709123 "},
+		{timeFormat{layout: TimeRFC3339}, 0, ""},
+	}
+	for _, tt := range tests {
+		if got := string(tt.tf.appendTime(nil, tt.flags, ts)); got != tt.want {
+			t.Errorf("%+v, flags %d: got %q, want %q", tt.tf, tt.flags, got, tt.want)
+		}
+	}
+}
+
+func TestSetTimeFormat(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetFlags(log.LstdFlags)
+	l.SetTimeFormat(time.DateOnly)
+	l.SetTimeUTC(true)
+
+	l.StandardInfo("dated")
+	if got := stdout.String(); !regexp.MustCompile(`^INFO:  \d{4}-\d\d-\d\d dated\n$`).MatchString(got) {
+		t.Errorf("stdout = %q, want a date only", got)
+	}
+}
+
+func TestAppendSince(t *testing.T) {
+	start := time.Now()
+	tests := []struct {
+		tf   timeFormat
+		want string
+	}{
+		{timeFormat{mode: TimeElapsed, start: start.Add(-12345 * time.Millisecond)}, "[+12.345s] "},
+		{timeFormat{mode: TimeDelta}, "[+0.000s] "},
+		{timeFormat{mode: TimeDelta, prev: start.Add(-1500 * time.Millisecond)}, "[+1.500s] "},
+	}
+	for _, tt := range tests {
+		if got := string(tt.tf.appendTime(nil, log.Ltime, start)); got != tt.want {
+			t.Errorf("mode %d: got %q, want %q", tt.tf.mode, got, tt.want)
+		}
+	}
+	if got := string((timeFormat{mode: TimeElapsed}).appendTime(nil, 0, start)); got != "" {
+		t.Errorf("elapsed time without time flags: %q", got)
+	}
+}
+
+func TestSetTimeModeDelta(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetFlags(log.Ltime)
+	l.SetTimeMode(TimeDelta)
+
+	l.StandardInfo("first")
+	l.StandardInfo("second")
+	re := regexp.MustCompile(`^INFO:  \[\+0\.000s\] first\nINFO:  \[\+\d+\.\d{3}s\] second\n$`)
+	if !re.MatchString(stdout.String()) {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+}
@@ -0,0 +1,90 @@
+package MyLog
+
+// VLogger writes verbose messages of one verbosity, see V
+type VLogger struct {
+	l       *Log
+	n       int
+	enabled bool
+}
+
+// SetVerbosity sets the verbosity checked by V and VerboseN, for
+// example the number of -v flags. A verbosity of 1 or more enables
+// LgVerbose, 0 clears it.
+func (l *Log) SetVerbosity(n int) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.verbosity = n
+	if n > 0 {
+		l.modeRegister |= LgVerbose
+	} else {
+		l.modeRegister &^= LgVerbose
+	}
+}
+
+// GetVerbosity returns the verbosity. It is at least 1 if verbose
+// messages are enabled in some other way, e.g. by LgVerbose.
+func (l *Log) GetVerbosity() int {
+	if !l.ready() {
+		return 0
+	}
+	l.mu.RLock()
+	n := l.verbosity
+	l.mu.RUnlock()
+
+	if n < 1 && l.Enabled(LevelVerbose) {
+		return 1
+	}
+	return n
+}
+
+// V returns a VLogger writing only if the verbosity is at least n.
+// V(0) writes like StandardInfo, higher values like VerboseInfo.
+func (l *Log) V(n int) VLogger {
+	if !l.ready() {
+		return VLogger{}
+	}
+	return VLogger{l: l, n: n, enabled: n <= 0 && l.Enabled(LevelInfo) || n > 0 && n <= l.GetVerbosity()}
+}
+
+// Enabled reports whether the VLogger writes
+func (v VLogger) Enabled() bool {
+	return v.enabled
+}
+
+// Info writes a message with INFO prefix
+func (v VLogger) Info(format string, a ...interface{}) {
+	switch {
+	case v.l == nil:
+	case !v.enabled:
+		v.l.record(LevelVerbose, "INFO", format, a...)
+	case v.n <= 0:
+		v.l.info(format, a...)
+	default:
+		v.l.verboseinfo(format, a...)
+	}
+}
+
+// Standard writes a message without prefix
+func (v VLogger) Standard(format string, a ...interface{}) {
+	switch {
+	case v.l == nil:
+	case !v.enabled:
+		v.l.record(LevelVerbose, "", format, a...)
+	case v.n <= 0:
+		v.l.log(format, a...)
+	default:
+		v.l.verbose(format, a...)
+	}
+}
+
+// VerboseN writes a message like Verbose if the verbosity is at least n
+func (l *Log) VerboseN(n int, format string, v ...interface{}) {
+	if !l.ready() {
+		return
+	}
+	l.V(n).Standard(format, v...)
+}
@@ -0,0 +1,41 @@
+package MyLog
+
+import "testing"
+
+func TestVerbosity(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetVerbosity(2)
+	if !l.HasMode(LgVerbose) || l.GetVerbosity() != 2 {
+		t.Fatalf("verbosity %d, mode %b", l.GetVerbosity(), l.GetMode())
+	}
+
+	for n := 0; n <= 3; n++ {
+		l.V(n).Info("info %d", n)
+		l.VerboseN(n, "plain %d", n)
+	}
+	want := "INFO:  info 0\n       plain 0\nINFO:  info 1\n       plain 1\nINFO:  info 2\n       plain 2\n"
+	if stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	if l.V(3).Enabled() || !l.V(2).Enabled() {
+		t.Error("Enabled does not follow the verbosity")
+	}
+
+	l.SetVerbosity(0)
+	if l.HasMode(LgVerbose) || l.V(1).Enabled() {
+		t.Error("SetVerbosity(0) kept verbose messages")
+	}
+}
+
+func TestGetVerbosityFromMode(t *testing.T) {
+	l, _, _ := newTestLog()
+	l.SetMode(LgVerbose)
+	if n := l.GetVerbosity(); n != 1 {
+		t.Errorf("GetVerbosity() = %d with LgVerbose, want 1", n)
+	}
+	var zero VLogger
+	zero.Info("no logger")
+	if zero.Enabled() {
+		t.Error("zero VLogger is enabled")
+	}
+}
@@ -0,0 +1,104 @@
+package MyLog
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// watchInterval is the time between two checks of the config file
+const watchInterval = 500 * time.Millisecond
+
+// configWatcher re-applies a config file when it changes
+type configWatcher struct {
+	stopped chan struct{}
+	done    chan struct{}
+}
+
+// WatchConfig applies the config file at path, see LoadConfig, and
+// again whenever its size or modification time changes, until
+// StopWatchConfig or Close is called. The file is checked every 500ms;
+// a file that is missing is not reloaded until it reappears, so editors
+// replacing it on save are fine. A file that fails to load is reported
+// as error entry and leaves the settings unchanged.
+func (l *Log) WatchConfig(path string) error {
+	if !l.ready() {
+		return nil
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+	if err := l.ApplyConfig(cfg); err != nil {
+		return err
+	}
+
+	cw := &configWatcher{stopped: make(chan struct{}), done: make(chan struct{})}
+	go l.watch(cw, path, fi)
+
+	l.mu.Lock()
+	old := l.watcher
+	l.watcher = cw
+	l.mu.Unlock()
+
+	old.stop()
+	return nil
+}
+
+// StopWatchConfig stops watching the config file
+func (l *Log) StopWatchConfig() {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	cw := l.watcher
+	l.watcher = nil
+	l.mu.Unlock()
+
+	cw.stop()
+}
+
+func (l *Log) watch(cw *configWatcher, path string, last os.FileInfo) {
+	defer close(cw.done)
+
+	t := time.NewTicker(watchInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+		case <-cw.stopped:
+			return
+		}
+
+		fi, err := os.Stat(path)
+		if err != nil || (fi.Size() == last.Size() && fi.ModTime().Equal(last.ModTime())) {
+			continue
+		}
+		last = fi
+
+		cfg, err := LoadConfig(path)
+		if err == nil {
+			err = l.ApplyConfig(cfg)
+		}
+		if err != nil {
+			l.Error("reloading config: %v", err)
+		}
+	}
+}
+
+func (cw *configWatcher) stop() {
+	if cw == nil {
+		return
+	}
+	close(cw.stopped)
+	<-cw.done
+}
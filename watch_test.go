@@ -0,0 +1,58 @@
+package MyLog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// eventually waits up to five seconds for cond
+func eventually(cond func() bool) bool {
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return true
+}
+
+func TestWatchConfig(t *testing.T) {
+	path := writeFile(t, "log.json", `{"level": "warn"}`)
+	l, _, stderr := newTestLog()
+	if err := l.WatchConfig(path); err != nil {
+		t.Fatal(err)
+	}
+	defer l.StopWatchConfig()
+	if l.GetLevel() != LevelWarn {
+		t.Fatalf("level %v, want the initial config applied", l.GetLevel())
+	}
+
+	if err := os.WriteFile(path, []byte(`{"level": "debug"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !eventually(func() bool { return l.GetLevel() == LevelDebug }) {
+		t.Fatal("changed config not applied")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"level": "very loud"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if !eventually(func() bool { return strings.Contains(stderr.String(), "ERROR: reloading config") }) {
+		t.Fatal("invalid config not reported")
+	}
+	if l.GetLevel() != LevelDebug {
+		t.Errorf("invalid config changed the level to %v", l.GetLevel())
+	}
+}
+
+func TestWatchConfigMissing(t *testing.T) {
+	l, _, _ := newTestLog()
+	if err := l.WatchConfig(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("watching a missing file succeeded")
+	}
+	l.StopWatchConfig()
+}
@@ -0,0 +1,105 @@
+// Package webhook posts MyLog error and panic entries to a webhook,
+// using a Slack compatible payload.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// Options configures an Alerter
+type Options struct {
+	URL        string
+	MaxLines   int                     // entries listed per post, default 20
+	Client     *http.Client            // default http.DefaultClient
+	Batch      MyLog.BatchOptions      // used by Attach, Interval defaults to 10s
+	Resilience MyLog.ResilienceOptions // retries and circuit breaker used by Attach
+}
+
+// Alerter is a MyLog.Sink posting each batch of entries as one message.
+// Entries beyond MaxLines are only counted. Rejected requests are
+// reported as MyLog.Permanent, so only network errors, 429 and 5xx
+// responses are retried.
+type Alerter struct {
+	opts Options
+}
+
+// New returns an Alerter posting to opts.URL. It posts synchronously,
+// see Attach for batching and retries.
+func New(opts Options) *Alerter {
+	if opts.MaxLines <= 0 {
+		opts.MaxLines = 20
+	}
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+	if opts.Batch.Interval <= 0 {
+		opts.Batch.Interval = 10 * time.Second
+	}
+	return &Alerter{opts: opts}
+}
+
+// Attach adds an Alerter to l as a sink for Error and Panic entries,
+// posting at most once per Batch.Interval. Panic entries and l.Flush
+// and l.Close post the queued entries at once.
+func Attach(l *MyLog.Log, opts Options) *MyLog.BatchSink {
+	a := New(opts)
+	b := MyLog.Batch(MyLog.NewResilientSink(a, a.opts.Resilience), a.opts.Batch)
+	l.AddSink(b, MyLog.LevelError, MyLog.LevelPanic)
+	return b
+}
+
+// WriteEntries posts entries in one message
+func (a *Alerter) WriteEntries(entries []MyLog.Entry) error {
+	n := min(len(entries), a.opts.MaxLines)
+	body, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{text(entries[:n], len(entries)-n)})
+	if err != nil {
+		return MyLog.Permanent(err)
+	}
+
+	resp, err := a.opts.Client.Post(a.opts.URL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("webhook: %s", resp.Status)
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			err = MyLog.Permanent(err)
+		}
+		return err
+	}
+	return nil
+}
+
+// Close does nothing, there is no connection to close
+func (a *Alerter) Close() error {
+	return nil
+}
+
+// text renders entries as a Slack message, one line per entry
+func text(entries []MyLog.Entry, dropped int) string {
+	var sb strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "*%s* %s ", strings.ToUpper(e.Level.String()), e.Time.Format(time.RFC3339))
+		if e.Name != "" {
+			fmt.Fprintf(&sb, "[%s] ", e.Name)
+		}
+		sb.WriteString(e.Message)
+		sb.WriteString(e.Fields.String())
+		sb.WriteByte('\n')
+	}
+	if dropped > 0 {
+		fmt.Fprintf(&sb, "... and %d more\n", dropped)
+	}
+	return sb.String()
+}
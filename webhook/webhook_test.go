@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+// server records the text of every post and answers with status
+type server struct {
+	*httptest.Server
+	mu     sync.Mutex
+	texts  []string
+	status int
+}
+
+func newServer(t *testing.T, status int) *server {
+	s := &server{status: status}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct{ Text string }
+		json.NewDecoder(r.Body).Decode(&body)
+		s.mu.Lock()
+		s.texts = append(s.texts, body.Text)
+		s.mu.Unlock()
+		w.WriteHeader(s.status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *server) posts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.texts...)
+}
+
+func TestWriteEntries(t *testing.T) {
+	s := newServer(t, http.StatusOK)
+	a := New(Options{URL: s.URL, MaxLines: 2})
+
+	now := time.Now()
+	err := a.WriteEntries([]MyLog.Entry{
+		{Time: now, Level: MyLog.LevelError, Name: "db", Message: "query failed", Fields: MyLog.Fields{"table": "users"}},
+		{Time: now, Level: MyLog.LevelPanic, Message: "bad state"},
+		{Time: now, Level: MyLog.LevelError, Message: "not listed"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := now.Format(time.RFC3339)
+	want := "*ERROR* " + ts + " [db] query failed table=users\n*PANIC* " + ts + " bad state\n... and 1 more\n"
+	if p := s.posts(); len(p) != 1 || p[0] != want {
+		t.Errorf("posts = %q, want %q", p, want)
+	}
+}
+
+func TestWriteEntriesRejected(t *testing.T) {
+	for _, status := range []int{http.StatusBadRequest, http.StatusTooManyRequests, http.StatusBadGateway} {
+		s := newServer(t, status)
+		err := New(Options{URL: s.URL}).WriteEntries([]MyLog.Entry{{Message: "x"}})
+		if err == nil || !strings.Contains(err.Error(), http.StatusText(status)) {
+			t.Errorf("status %d: err = %v", status, err)
+		}
+	}
+}
+
+func TestAttach(t *testing.T) {
+	tests := []struct {
+		status, posts int
+	}{
+		{http.StatusOK, 1},
+		{http.StatusBadRequest, 1},         // not retried
+		{http.StatusServiceUnavailable, 3}, // retried twice
+	}
+	for _, tt := range tests {
+		s := newServer(t, tt.status)
+		l := MyLog.New(MyLog.WithOutput(io.Discard, io.Discard))
+		Attach(l, Options{URL: s.URL, Resilience: MyLog.ResilienceOptions{Retries: 2, MinBackoff: time.Millisecond}})
+
+		l.Warn("not sent")
+		l.Error("first")
+		l.Error("second")
+		l.Close()
+
+		p := s.posts()
+		if len(p) != tt.posts {
+			t.Errorf("status %d: %d posts, want %d", tt.status, len(p), tt.posts)
+			continue
+		}
+		if !strings.Contains(p[0], "first") || !strings.Contains(p[0], "second") || strings.Contains(p[0], "not sent") {
+			t.Errorf("status %d: post = %q", tt.status, p[0])
+		}
+	}
+}
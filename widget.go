@@ -0,0 +1,225 @@
+package MyLog
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// liveLine is the last line of a terminal, redrawn in place by a
+// Spinner or ProgressBar. Other output is written above it.
+type liveLine struct {
+	w    io.Writer // terminal, nil if no line is shown
+	text string
+}
+
+func (ll *liveLine) shown() bool {
+	return ll.w != nil
+}
+
+func (ll *liveLine) clear() {
+	io.WriteString(ll.w, "\r\x1b[K")
+}
+
+func (ll *liveLine) draw() {
+	io.WriteString(ll.w, ll.text)
+}
+
+// setLive shows text as live line on the stderr terminal, or removes
+// the line if text is empty
+func (l *Log) setLive(text string) {
+	l.mu.RLock()
+	w := l.stdErr
+	l.mu.RUnlock()
+
+	l.wmu.Lock()
+	defer l.wmu.Unlock()
+	if l.live.shown() {
+		l.live.clear()
+	}
+	if text == "" {
+		l.live = liveLine{}
+		return
+	}
+	l.live = liveLine{w: w, text: text}
+	l.live.draw()
+}
+
+// liveTerminal reports whether live lines can be shown
+func (l *Log) liveTerminal() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return isTerminal(l.stdErr)
+}
+
+const (
+	liveInterval  = 100 * time.Millisecond // redraw on terminals
+	plainInterval = 5 * time.Second        // progress lines elsewhere
+)
+
+// widget redraws a live line on a terminal, or writes it as standard
+// message every plainInterval on other outputs. Only one widget of a
+// Log should run at a time.
+type widget struct {
+	l      *Log
+	tty    bool
+	render func(frame int) string
+	stop   chan struct{}
+	wg     sync.WaitGroup
+	closed atomic.Bool
+}
+
+func (l *Log) startWidget(render func(frame int) string) *widget {
+	w := &widget{l: l, tty: l.liveTerminal(), render: render, stop: make(chan struct{})}
+
+	interval := plainInterval
+	if w.tty {
+		interval = liveInterval
+		l.setLive(render(0))
+	}
+
+	w.wg.Add(1)
+	go w.run(interval)
+	return w
+}
+
+func (w *widget) run(interval time.Duration) {
+	defer w.wg.Done()
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for frame := 1; ; frame++ {
+		select {
+		case <-t.C:
+			if w.tty {
+				w.l.setLive(w.render(frame))
+			} else {
+				w.l.Standard("%s", w.render(frame))
+			}
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// close stops the widget and removes its line
+func (w *widget) close() {
+	if w.closed.Swap(true) {
+		return
+	}
+	close(w.stop)
+	w.wg.Wait()
+	if w.tty {
+		w.l.setLive("")
+	}
+}
+
+// Spinner shows an animated message on a terminal while an operation
+// without known length runs
+type Spinner struct {
+	w     *widget
+	msg   atomic.Value
+	start time.Time
+}
+
+// Spinner starts a spinner with msg. On a terminal it is redrawn on the
+// last line of stderr, with log messages written above it; elsewhere
+// msg is logged at the start and every few seconds. Stop it with Stop.
+func (l *Log) Spinner(msg string) *Spinner {
+	s := &Spinner{start: time.Now()}
+	s.msg.Store(msg)
+	if !l.ready() || !l.Enabled(LevelInfo) {
+		return s
+	}
+
+	frames := spinnerFrames[1]
+	if unicodeSupported() {
+		frames = spinnerFrames[0]
+	}
+	tty := l.liveTerminal()
+	if !tty {
+		l.Standard("%s ...", msg)
+	}
+	s.w = l.startWidget(func(frame int) string {
+		msg := s.msg.Load().(string)
+		if tty {
+			return frames[frame%len(frames)] + " " + msg
+		}
+		return fmt.Sprintf("%s ... (%s)", msg, time.Since(s.start).Round(time.Second))
+	})
+	return s
+}
+
+// Update replaces the message of s
+func (s *Spinner) Update(msg string) {
+	s.msg.Store(msg)
+}
+
+// Stop removes the spinner
+func (s *Spinner) Stop() {
+	if s.w != nil {
+		s.w.close()
+	}
+}
+
+// ProgressBar shows the progress of an operation of known length
+type ProgressBar struct {
+	l     *Log
+	w     *widget
+	total int64
+	cur   atomic.Int64
+}
+
+const barWidth = 30
+
+// ProgressBar starts a progress bar for total steps. On a terminal it
+// is redrawn on the last line of stderr, with log messages written
+// above it; elsewhere the progress is logged every few seconds. Done
+// logs the final state.
+func (l *Log) ProgressBar(total int) *ProgressBar {
+	p := &ProgressBar{l: l, total: int64(total)}
+	if !l.ready() || !l.Enabled(LevelInfo) {
+		return p
+	}
+	p.w = l.startWidget(func(int) string { return p.String() })
+	return p
+}
+
+// Add advances p by n steps
+func (p *ProgressBar) Add(n int) {
+	p.cur.Add(int64(n))
+}
+
+// Set sets the number of finished steps
+func (p *ProgressBar) Set(n int) {
+	p.cur.Store(int64(n))
+}
+
+// Done removes the bar and logs its final state as standard message
+func (p *ProgressBar) Done() {
+	if p.w == nil || p.w.closed.Load() {
+		return
+	}
+	p.w.close()
+	p.l.Standard("%s", p.String())
+}
+
+// String renders p like "[=========>         ]  45% 45/100"
+func (p *ProgressBar) String() string {
+	cur := p.cur.Load()
+	frac := 1.0
+	if p.total > 0 {
+		frac = float64(cur) / float64(p.total)
+	}
+	frac = max(0, min(frac, 1))
+
+	filled := int(frac * barWidth)
+	bar := strings.Repeat("=", filled)
+	if filled < barWidth {
+		bar += ">" + strings.Repeat(" ", barWidth-filled-1)
+	}
+	return fmt.Sprintf("[%s] %3d%% %d/%d", bar, int(frac*100), cur, p.total)
+}
@@ -0,0 +1,73 @@
+package MyLog
+
+import "testing"
+
+func TestSpinnerPlain(t *testing.T) {
+	l, stdout, _ := newTestLog()
+
+	s := l.Spinner("downloading")
+	s.Update("unpacking")
+	s.Stop()
+	s.Stop()
+	if stdout.String() != "       downloading ...\n" {
+		t.Errorf("stdout = %q", stdout.String())
+	}
+
+	l.SetLevel(LevelWarn)
+	l.Spinner("hidden").Stop()
+	if stdout.String() != "       downloading ...\n" {
+		t.Errorf("spinner shown at the warn level: %q", stdout.String())
+	}
+}
+
+func TestProgressBarString(t *testing.T) {
+	p := &ProgressBar{total: 100}
+	tests := []struct {
+		set  int
+		want string
+	}{
+		{0, "[>                             ]   0% 0/100"},
+		{45, "[=============>                ]  45% 45/100"},
+		{100, "[==============================] 100% 100/100"},
+		{150, "[==============================] 100% 150/100"},
+	}
+	for _, tt := range tests {
+		p.Set(tt.set)
+		if got := p.String(); got != tt.want {
+			t.Errorf("Set(%d): %q, want %q", tt.set, got, tt.want)
+		}
+	}
+
+	p = &ProgressBar{}
+	if got := p.String(); got != "[==============================] 100% 0/0" {
+		t.Errorf("empty bar = %q", got)
+	}
+}
+
+func TestProgressBarDone(t *testing.T) {
+	l, stdout, _ := newTestLog()
+
+	p := l.ProgressBar(4)
+	p.Add(1)
+	p.Add(3)
+	p.Done()
+	p.Done()
+	if got := stdout.lines(); len(got) != 1 || got[0] != "       [==============================] 100% 4/4" {
+		t.Errorf("stdout = %q, want the final state once", got)
+	}
+}
+
+func TestLiveLine(t *testing.T) {
+	l, _, stderr := newTestLog()
+
+	l.setLive("| working")
+	l.Error("failed")
+	l.setLive("/ working")
+	l.setLive("")
+
+	want := "| working" + "\r\x1b[K" + "ERROR: failed\n" + "| working" +
+		"\r\x1b[K" + "/ working" + "\r\x1b[K"
+	if got := stderr.String(); got != want {
+		t.Errorf("stderr = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,16 @@
+module github.com/hleinders/MyLog/winevent
+
+go 1.21
+
+require (
+	github.com/hleinders/MyLog v0.0.0
+	golang.org/x/sys v0.22.0
+)
+
+require (
+	github.com/fatih/color v1.13.0 // indirect
+	github.com/mattn/go-colorable v0.1.9 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+)
+
+replace github.com/hleinders/MyLog => ../
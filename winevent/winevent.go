@@ -0,0 +1,48 @@
+// Package winevent writes MyLog entries to the Windows Event Log, so
+// that Windows services show up in the Event Viewer. Error, Panic and
+// Fatal entries become error events, Warn entries warning events and
+// all others information events.
+package winevent
+
+import MyLog "github.com/hleinders/MyLog"
+
+// Options configures a Writer
+type Options struct {
+	Source   string      // event source name, usually the service name
+	Install  bool        // register the source if needed, requires administrator rights
+	EventID  uint32      // event ID of all entries, default 1
+	MinLevel MyLog.Level // entries below this level are not written, e.g. LevelInfo
+}
+
+// Attach creates a Writer for the entries of l from opts.MinLevel on
+func Attach(l *MyLog.Log, opts Options) (*Writer, error) {
+	w, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var levels []MyLog.Level
+	for lv := opts.MinLevel; lv <= MyLog.LevelFatal; lv++ {
+		levels = append(levels, lv)
+	}
+	l.AddHook(w.Hook, levels...)
+	return w, nil
+}
+
+// message renders e as the event text
+func message(e MyLog.Entry) string {
+	msg := e.Message
+	if e.Name != "" {
+		msg = "[" + e.Name + "] " + msg
+	}
+	if len(e.Fields) > 0 {
+		msg += e.Fields.String()
+	}
+	if e.Caller != "" {
+		msg += " [" + e.Caller + "]"
+	}
+	if e.Stack != "" {
+		msg += "\r\n" + e.Stack
+	}
+	return msg
+}
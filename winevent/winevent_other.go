@@ -0,0 +1,39 @@
+//go:build !windows
+
+package winevent
+
+import (
+	"errors"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+var errUnsupported = errors.New("winevent: the event log is only available on Windows")
+
+// Writer sends entries to the event log
+type Writer struct{}
+
+// New fails, the event log is only available on Windows
+func New(opts Options) (*Writer, error) {
+	return nil, errUnsupported
+}
+
+// Install fails, the event log is only available on Windows
+func Install(source string) error {
+	return errUnsupported
+}
+
+// Remove fails, the event log is only available on Windows
+func Remove(source string) error {
+	return errUnsupported
+}
+
+// Hook does nothing
+func (w *Writer) Hook(e MyLog.Entry) error {
+	return errUnsupported
+}
+
+// Close does nothing
+func (w *Writer) Close() error {
+	return nil
+}
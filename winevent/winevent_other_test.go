@@ -0,0 +1,15 @@
+//go:build !windows
+
+package winevent
+
+import (
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestNewUnsupported(t *testing.T) {
+	if _, err := Attach(MyLog.New(), Options{Source: "test"}); err != errUnsupported {
+		t.Errorf("Attach() = %v, want %v", err, errUnsupported)
+	}
+}
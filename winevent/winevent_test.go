@@ -0,0 +1,20 @@
+package winevent
+
+import (
+	"testing"
+
+	MyLog "github.com/hleinders/MyLog"
+)
+
+func TestMessage(t *testing.T) {
+	got := message(MyLog.Entry{
+		Name:    "svc",
+		Message: "stopped",
+		Fields:  MyLog.Fields{"code": 3},
+		Caller:  "main.go:9 main.run",
+		Stack:   "main.run()",
+	})
+	if want := "[svc] stopped code=3 [main.go:9 main.run]\r\nmain.run()"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+package winevent
+
+import (
+	"errors"
+	"strings"
+
+	MyLog "github.com/hleinders/MyLog"
+	"golang.org/x/sys/windows/registry"
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// Writer sends entries to the event log
+type Writer struct {
+	log *eventlog.Log
+	id  uint32
+}
+
+// New returns a Writer for opts.Source, registering the source first
+// if opts.Install is set
+func New(opts Options) (*Writer, error) {
+	if opts.Source == "" {
+		return nil, errors.New("winevent: source is required")
+	}
+	if opts.EventID == 0 {
+		opts.EventID = 1
+	}
+	if opts.Install {
+		if err := Install(opts.Source); err != nil {
+			return nil, err
+		}
+	}
+
+	log, err := eventlog.Open(opts.Source)
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{log: log, id: opts.EventID}, nil
+}
+
+// Install registers source in the Application log using the message
+// file of EventCreate. An already registered source is not an error.
+func Install(source string) error {
+	err := eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info)
+	if err != nil && isExists(source) {
+		return nil
+	}
+	return err
+}
+
+// Remove deletes the registration of source
+func Remove(source string) error {
+	return eventlog.Remove(source)
+}
+
+// isExists reports whether source is already registered
+func isExists(source string) bool {
+	k, err := registry.OpenKey(registry.LOCAL_MACHINE, `SYSTEM\CurrentControlSet\Services\EventLog\Application\`+source, registry.QUERY_VALUE)
+	if err != nil {
+		return false
+	}
+	k.Close()
+	return true
+}
+
+// Hook writes e as an event of the matching type
+func (w *Writer) Hook(e MyLog.Entry) error {
+	// EventCreate messages are limited to 31839 characters
+	msg := message(e)
+	if len(msg) > 31839 {
+		msg = strings.ToValidUTF8(msg[:31839], "")
+	}
+
+	switch {
+	case e.Level >= MyLog.LevelError:
+		return w.log.Error(w.id, msg)
+	case e.Level == MyLog.LevelWarn:
+		return w.log.Warning(w.id, msg)
+	}
+	return w.log.Info(w.id, msg)
+}
+
+// Close closes the event log handle
+func (w *Writer) Close() error {
+	return w.log.Close()
+}
@@ -0,0 +1,85 @@
+package MyLog
+
+import (
+	"io"
+	"log"
+	"strings"
+	"unicode/utf8"
+)
+
+// SetWrap enables soft-wrapping of messages at the width of the
+// terminal, with continuation lines indented below the message. Lines
+// written to other writers are not wrapped; a log file next to a
+// terminal receives the wrapped lines, though.
+func (l *Log) SetWrap(b bool) {
+	if !l.ready() {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.wrap = b
+}
+
+// terminalWidth returns the width of the terminal lg writes entries of
+// lv to, or 0; l.mu must be held
+func (l *Log) terminalWidth(lg *log.Logger, lv Level) int {
+	cols, _, ok := terminalSize(l.targetOut(lg, lv))
+	if !ok {
+		return 0
+	}
+	return cols
+}
+
+// targetOut returns the configured writer lg writes entries of lv to,
+// without the log file; l.mu must be held
+func (l *Log) targetOut(lg *log.Logger, lv Level) io.Writer {
+	w, ok := l.levelOut[lv]
+	if !ok {
+		switch lg {
+		case l.debugVar, l.traceVar, l.errorVar:
+			w = l.stdErr
+		case l.panicVar, l.fatalVar:
+			w = l.panicOut
+		default:
+			w = l.stdOut
+		}
+	}
+	return w
+}
+
+// wrapText breaks s at blanks into lines of at most width visible
+// characters, splitting longer words. Widths below 10 do not wrap.
+func wrapText(s string, width int) []string {
+	if width < 10 || visibleWidth(s) <= width {
+		return []string{s}
+	}
+
+	var lines []string
+	var line strings.Builder
+	n := 0
+	for _, word := range strings.Split(s, " ") {
+		wn := visibleWidth(word)
+		if n > 0 && n+1+wn > width {
+			lines = append(lines, line.String())
+			line.Reset()
+			n = 0
+		}
+		for wn > width {
+			r := []rune(word)
+			lines = append(lines, string(r[:width]))
+			word, wn = string(r[width:]), wn-width
+		}
+		if n > 0 {
+			line.WriteByte(' ')
+			n++
+		}
+		line.WriteString(word)
+		n += wn
+	}
+	return append(lines, line.String())
+}
+
+// visibleWidth returns the number of characters of s on a terminal
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(stripANSI(s))
+}
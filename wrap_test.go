@@ -0,0 +1,65 @@
+package MyLog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  []string
+	}{
+		{"short text", 20, []string{"short text"}},
+		{"the quick brown fox jumps", 10, []string{"the quick", "brown fox", "jumps"}},
+		{"a verylongwordthatsplits b", 10, []string{"a", "verylongwo", "rdthatspli", "ts b"}},
+		{"\x1b[31mred\x1b[0m text here", 10, []string{"\x1b[31mred\x1b[0m text", "here"}},
+		{"too narrow to wrap", 5, []string{"too narrow to wrap"}},
+	}
+	for _, tt := range tests {
+		if got := wrapText(tt.s, tt.width); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("wrapText(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestWrapLine(t *testing.T) {
+	f := lineFormat{prefix: "WARN:  ", paint: plain, wrap: 20}
+	e := Entry{Message: "disk almost full on /var/lib"}
+
+	b, _ := f.appendEntry(nil, &e)
+	if want := "WARN:  disk almost\n       full on\n       /var/lib\n"; string(b) != want {
+		t.Errorf("wrapped = %q, want %q", b, want)
+	}
+	for _, ln := range strings.Split(strings.TrimSuffix(string(b), "\n"), "\n") {
+		if len(ln) > 20 {
+			t.Errorf("%q is wider than 20 columns", ln)
+		}
+	}
+}
+
+func TestWrapOnlyTerminals(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	l.SetWrap(true)
+
+	msg := strings.Repeat("word ", 30)
+	l.StandardInfo("%s", msg)
+	if got := stdout.lines(); len(got) != 1 {
+		t.Errorf("output to a buffer wrapped into %d lines", len(got))
+	}
+}
+
+func TestTargetOut(t *testing.T) {
+	l, stdout, stderr := newTestLog()
+	errs := new(syncBuffer)
+	l.SetLevelOutput(LevelError, errs)
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.targetOut(l.infoVar, LevelInfo) != stdout || l.targetOut(l.debugVar, LevelDebug) != stderr ||
+		l.targetOut(l.errorVar, LevelError) != errs {
+		t.Error("targetOut does not follow the level outputs")
+	}
+}
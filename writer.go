@@ -0,0 +1,75 @@
+package MyLog
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync"
+)
+
+// levelWriter turns every line written to it into an entry
+type levelWriter struct {
+	mu      sync.Mutex
+	l       *Log
+	lv      Level
+	partial []byte // incomplete last line
+}
+
+// WriterLevel returns a writer whose lines are logged as entries of
+// level lv, e.g. for http.Server.ErrorLog or exec.Cmd.Stderr. A line
+// is logged once its newline is written; Close logs an incomplete
+// last line. Logging at LevelFatal does not exit the process.
+func (l *Log) WriterLevel(lv Level) io.WriteCloser {
+	if !l.ready() {
+		return nopWriteCloser{}
+	}
+	return &levelWriter{l: l, lv: lv}
+}
+
+// StdLogger returns a standard library logger writing through l at
+// level lv, for code that only accepts a *log.Logger. Prefix, color,
+// timestamp and buffer handling are those of l.
+func (l *Log) StdLogger(lv Level) *log.Logger {
+	if !l.ready() {
+		return log.New(io.Discard, "", 0)
+	}
+	return log.New(l.WriterLevel(lv), "", 0)
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		w.logLine(data[:i])
+		data = data[i+1:]
+	}
+	w.partial = append(w.partial[:0], data...)
+
+	return len(p), nil
+}
+
+// Close logs any incomplete last line
+func (w *levelWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) > 0 {
+		w.logLine(w.partial)
+		w.partial = nil
+	}
+	return nil
+}
+
+func (w *levelWriter) logLine(line []byte) {
+	line = bytes.TrimRight(line, "\r")
+	if len(bytes.TrimSpace(line)) == 0 {
+		return
+	}
+	w.l.logLevel(w.lv, string(line))
+}
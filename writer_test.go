@@ -0,0 +1,39 @@
+package MyLog
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWriterLevel(t *testing.T) {
+	l, stdout, _ := newTestLog()
+	w := l.WriterLevel(LevelWarn)
+
+	fmt.Fprint(w, "first line\r\nsecond ")
+	fmt.Fprint(w, "line\n\n   \npartial")
+	if want := "WARN:  first line\nWARN:  second line\n"; stdout.String() != want {
+		t.Errorf("stdout = %q, want %q", stdout.String(), want)
+	}
+	w.Close()
+	if want := "WARN:  first line\nWARN:  second line\nWARN:  partial\n"; stdout.String() != want {
+		t.Errorf("stdout after Close = %q, want %q", stdout.String(), want)
+	}
+}
+
+func TestWriterLevelFatal(t *testing.T) {
+	code := stubExit(t)
+	l, _, stderr := newTestLog()
+	fmt.Fprintln(l.WriterLevel(LevelFatal), "disk full")
+	if *code >= 0 || stderr.String() != "FATAL: disk full\n" {
+		t.Errorf("exit code %d, stderr = %q", *code, stderr.String())
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	l, _, stderr := newTestLog()
+	std := l.StdLogger(LevelError)
+	std.Printf("accept: %s", "too many open files")
+	if want := "ERROR: accept: too many open files\n"; stderr.String() != want {
+		t.Errorf("stderr = %q, want %q", stderr.String(), want)
+	}
+}